@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -10,11 +9,10 @@ import (
 	"syscall"
 	"time"
 
-	"typeten/internal/domain"
 	"typeten/internal/handlers"
-	infraRepo "typeten/internal/infrastructure/repository"
-	"typeten/internal/repository"
 	"typeten/internal/usecases"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 const (
@@ -23,39 +21,105 @@ const (
 )
 
 func main() {
-	// Initialize repositories (in-memory for MVP)
-	userRepo := infraRepo.NewMemoryUserRepository()
-	textRepo := infraRepo.NewMemoryTextRepository()
-	sessionRepo := infraRepo.NewMemorySessionRepository()
-
-	// Create a default user for MVP (in production, this would come from auth)
-	ctx := context.Background()
-	defaultUser, err := createDefaultUser(ctx, userRepo)
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	// Get storage configuration from environment
+	storageCfg, err := loadStorageConfig()
+	if err != nil {
+		log.Fatalf("Invalid storage configuration: %v", err)
+	}
+
+	// Subcommands: "typeten migrate up|down|status"
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, storageCfg.DatabasePath, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	sessionCfg, err := loadSessionConfig()
+	if err != nil {
+		log.Fatalf("Invalid session configuration: %v", err)
+	}
+	sweeperCfg, err := loadSweeperConfig()
+	if err != nil {
+		log.Fatalf("Invalid sweeper configuration: %v", err)
+	}
+
+	// Initialize repositories
+	repos, err := openRepositories(ctx, storageCfg)
 	if err != nil {
-		log.Fatalf("Failed to create default user: %v", err)
+		log.Fatalf("Failed to initialize storage: %v", err)
 	}
+	defer func() {
+		stop() // stop background storage work before closing
+		if err := repos.close(); err != nil {
+			log.Printf("Failed to close storage: %v", err)
+		}
+	}()
+	userRepo, textRepo, sessionRepo, tokenRepo := repos.users, repos.texts, repos.sessions, repos.tokens
+	keystrokeRepo, lineResultRepo := repos.keystrokes, repos.lines
 
 	// Initialize use cases
 	createTextUseCase := usecases.NewCreateTextUseCase(textRepo, userRepo, defaultFragmentSize)
+	importTextUseCase := usecases.NewImportTextUseCase(textRepo, userRepo, usecases.NewDefaultImporterRegistry(), defaultFragmentSize)
 	createSessionUseCase := usecases.NewCreateSessionUseCase(sessionRepo, textRepo, userRepo)
-	recordProgressUseCase := usecases.NewRecordProgressUseCase(sessionRepo)
+	recordProgressUseCase := usecases.NewRecordProgressUseCase(sessionRepo, textRepo, lineResultRepo)
 	getSessionUseCase := usecases.NewGetSessionUseCase(sessionRepo)
 	listTextsUseCase := usecases.NewListTextsUseCase(textRepo, userRepo)
 	getTextFragmentsUseCase := usecases.NewGetTextFragmentsUseCase(textRepo)
+	passwordHasher := usecases.NewBcryptPasswordHasher(bcrypt.DefaultCost)
+	registerUserUseCase := usecases.NewRegisterUserUseCase(userRepo, passwordHasher)
+	loginUseCase := usecases.NewLoginUseCase(userRepo, passwordHasher)
+	createAPITokenUseCase := usecases.NewCreateAPITokenUseCase(tokenRepo, userRepo)
+	listAPITokensUseCase := usecases.NewListAPITokensUseCase(tokenRepo)
+	revokeAPITokenUseCase := usecases.NewRevokeAPITokenUseCase(tokenRepo)
+	authAPITokenUseCase := usecases.NewAuthenticateAPITokenUseCase(tokenRepo)
+	listUnfinishedUseCase := usecases.NewListUnfinishedSessionsUseCase(sessionRepo)
+	recordKeystrokesUseCase := usecases.NewRecordKeystrokesUseCase(sessionRepo, keystrokeRepo)
+	listLineResultsUseCase := usecases.NewListLineResultsUseCase(sessionRepo, lineResultRepo)
+	listSessionsUseCase := usecases.NewListSessionsUseCase(sessionRepo)
+	progressStatsUseCase := usecases.NewGetProgressStatsUseCase(sessionRepo)
+	keyStatsUseCase := usecases.NewGetKeyStatsUseCase(sessionRepo, textRepo, keystrokeRepo, lineResultRepo)
+	createDrillUseCase := usecases.NewCreateDrillUseCase(textRepo, sessionRepo, userRepo, keyStatsUseCase, defaultFragmentSize)
+	changeSessionStatusUseCase := usecases.NewChangeSessionStatusUseCase(sessionRepo)
+	abandonIdleUseCase := usecases.NewAbandonIdleSessionsUseCase(sessionRepo)
+
+	// Abandon sessions nobody has touched within the idle timeout
+	if sweeperCfg.IdleTimeout > 0 {
+		go runSessionSweeper(ctx, abandonIdleUseCase, sweeperCfg)
+	}
 
 	// Initialize handlers
 	httpHandlers := handlers.NewHandlers(
 		createTextUseCase,
+		importTextUseCase,
 		createSessionUseCase,
 		recordProgressUseCase,
 		getSessionUseCase,
 		listTextsUseCase,
 		getTextFragmentsUseCase,
-		defaultUser.ID,
+		registerUserUseCase,
+		loginUseCase,
+		createAPITokenUseCase,
+		listAPITokensUseCase,
+		revokeAPITokenUseCase,
+		authAPITokenUseCase,
+		listUnfinishedUseCase,
+		recordKeystrokesUseCase,
+		listLineResultsUseCase,
+		listSessionsUseCase,
+		progressStatsUseCase,
+		keyStatsUseCase,
+		createDrillUseCase,
+		changeSessionStatusUseCase,
+		handlers.NewCookieSessions(sessionCfg.Secret, sessionCfg.TTL),
+		"", // no anonymous fallback: every request must log in
 	)
 
-	// Setup router
-	router := handlers.NewRouter(httpHandlers)
+	// Setup router; RequireLogin resolves the session cookie before routing
+	router := httpHandlers.RequireLogin(handlers.NewRouter(httpHandlers))
 
 	// Get port from environment or use default
 	port := os.Getenv("PORT")
@@ -74,14 +138,8 @@ func main() {
 
 	// Start server in a goroutine
 	go func() {
-		log.Printf("Server starting on port %s", port)
-		log.Printf("API endpoints:")
-		log.Printf("  POST   /api/texts")
-		log.Printf("  GET    /api/texts")
-		log.Printf("  GET    /api/texts/:id/fragments")
-		log.Printf("  POST   /api/sessions")
-		log.Printf("  GET    /api/sessions/:id")
-		log.Printf("  POST   /api/sessions/:id/progress")
+		// The API is documented in README.md; listing it here only goes stale.
+		log.Printf("Server listening on %s (storage: %s)", server.Addr, storageCfg.Storage)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Server failed to start: %v", err)
 		}
@@ -99,36 +157,9 @@ func main() {
 	defer cancel()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		log.Printf("Server forced to shutdown: %v", err)
+		return
 	}
 
 	log.Println("Server exited")
 }
-
-// createDefaultUser creates a default user for MVP testing.
-func createDefaultUser(ctx context.Context, userRepo repository.UserRepository) (*domain.User, error) {
-	// Try to get existing user
-	userID := domain.UserID("user_default")
-	user, err := userRepo.GetByID(ctx, userID)
-	if err == nil {
-		return user, nil
-	}
-
-	// Create new user
-	now := time.Now()
-	user, err = domain.NewUser(
-		userID,
-		"user@example.com",
-		"default_user",
-		now,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
-
-	if err := userRepo.Create(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to store user: %w", err)
-	}
-
-	return user, nil
-}
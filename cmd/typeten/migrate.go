@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"typeten/internal/infrastructure/migrations"
+	infraRepo "typeten/internal/infrastructure/repository"
+)
+
+const migrateUsage = "usage: typeten migrate up|down|status"
+
+// runMigrate implements the "migrate" subcommand against the SQLite database at databasePath.
+func runMigrate(ctx context.Context, databasePath string, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := infraRepo.OpenSQLite(ctx, databasePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			log.Printf("Applied %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			log.Printf("Database is up to date")
+		}
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("Reverted %04d_%s", reverted.Version, reverted.Name)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format("2006-01-02T15:04:05Z07:00")
+			}
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+	return nil
+}
+
+// ensureMigrated returns an error if the database has migrations that have not been applied.
+func ensureMigrated(ctx context.Context, migrator *migrations.Migrator) error {
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("database has %d pending migration(s); run \"typeten migrate up\" first", len(pending))
+	}
+	return nil
+}
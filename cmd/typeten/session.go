@@ -0,0 +1,41 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+const defaultSessionTTL = 7 * 24 * time.Hour
+
+// sessionConfig configures signed login session cookies.
+type sessionConfig struct {
+	Secret []byte        // SESSION_SECRET: HMAC key for session cookies
+	TTL    time.Duration // SESSION_TTL: how long a login stays valid
+}
+
+// loadSessionConfig reads sessionConfig from the environment. Without SESSION_SECRET
+// a random secret is generated, so logins do not survive a restart.
+func loadSessionConfig() (sessionConfig, error) {
+	cfg := sessionConfig{
+		Secret: []byte(os.Getenv("SESSION_SECRET")),
+		TTL:    defaultSessionTTL,
+	}
+	if v := os.Getenv("SESSION_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return cfg, fmt.Errorf("SESSION_TTL must be a positive duration, got %q", v)
+		}
+		cfg.TTL = d
+	}
+	if len(cfg.Secret) == 0 {
+		cfg.Secret = make([]byte, 32)
+		if _, err := rand.Read(cfg.Secret); err != nil {
+			return cfg, fmt.Errorf("failed to generate session secret: %w", err)
+		}
+		log.Printf("SESSION_SECRET is not set; using a random secret, logins will not survive a restart")
+	}
+	return cfg, nil
+}
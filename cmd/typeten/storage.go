@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"typeten/internal/infrastructure/migrations"
+	infraRepo "typeten/internal/infrastructure/repository"
+	"typeten/internal/repository"
+)
+
+const (
+	defaultStorage          = "memory"
+	defaultDatabasePath     = "typeten.db"
+	defaultDataDir          = "typeten-data"
+	defaultSnapshotInterval = 5 * time.Minute
+)
+
+// storageConfig selects and configures the storage backend.
+type storageConfig struct {
+	Storage          string        // STORAGE: "memory", "journal" or "sqlite"
+	DatabasePath     string        // DATABASE_PATH: SQLite database file
+	DataDir          string        // DATA_DIR: journal and snapshot directory
+	SnapshotInterval time.Duration // SNAPSHOT_INTERVAL: how often the journal is compacted
+}
+
+// repositories groups the storage backends selected at startup.
+type repositories struct {
+	users      repository.UserRepository
+	texts      repository.TextRepository
+	sessions   repository.SessionRepository
+	tokens     repository.APITokenRepository
+	keystrokes repository.KeystrokeRepository
+	lines      repository.LineResultRepository
+	close      func() error
+}
+
+// loadStorageConfig reads storageConfig from the environment, applying defaults.
+func loadStorageConfig() (storageConfig, error) {
+	cfg := storageConfig{
+		Storage:          getEnv("STORAGE", defaultStorage),
+		DatabasePath:     getEnv("DATABASE_PATH", defaultDatabasePath),
+		DataDir:          getEnv("DATA_DIR", defaultDataDir),
+		SnapshotInterval: defaultSnapshotInterval,
+	}
+	if v := os.Getenv("SNAPSHOT_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return cfg, fmt.Errorf("SNAPSHOT_INTERVAL must be a positive duration, got %q", v)
+		}
+		cfg.SnapshotInterval = d
+	}
+	return cfg, nil
+}
+
+// openRepositories initializes the repositories for the configured storage backend.
+// Supported backends are "memory" (data is lost on restart), "journal" (in-memory with
+// a write-ahead journal and periodic snapshots in DataDir) and "sqlite" (stored in DatabasePath).
+// The sqlite backend refuses to start while the database has pending migrations.
+// Background work started here stops when ctx is cancelled.
+func openRepositories(ctx context.Context, cfg storageConfig) (*repositories, error) {
+	switch cfg.Storage {
+	case "memory":
+		sessions := infraRepo.NewMemorySessionRepository()
+		return &repositories{
+			users:      infraRepo.NewMemoryUserRepository(),
+			texts:      infraRepo.NewMemoryTextRepository(),
+			sessions:   sessions,
+			tokens:     infraRepo.NewMemoryAPITokenRepository(),
+			keystrokes: infraRepo.NewMemoryKeystrokeRepository(sessions),
+			lines:      infraRepo.NewMemoryLineResultRepository(sessions),
+			close:      func() error { return nil },
+		}, nil
+	case "journal":
+		store, err := infraRepo.OpenJournalStore(cfg.DataDir)
+		if err != nil {
+			return nil, err
+		}
+		go store.RunCompaction(ctx, cfg.SnapshotInterval, func(err error) {
+			log.Printf("Failed to compact journal: %v", err)
+		})
+		return &repositories{
+			users:      store.Users(),
+			texts:      store.Texts(),
+			sessions:   store.Sessions(),
+			tokens:     store.APITokens(),
+			keystrokes: store.Keystrokes(),
+			lines:      store.LineResults(),
+			close:      store.Close,
+		}, nil
+	case "sqlite":
+		db, err := infraRepo.OpenSQLite(ctx, cfg.DatabasePath)
+		if err != nil {
+			return nil, err
+		}
+		migrator, err := migrations.New(db)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		if err := ensureMigrated(ctx, migrator); err != nil {
+			db.Close()
+			return nil, err
+		}
+		return &repositories{
+			users:      infraRepo.NewSQLiteUserRepository(db),
+			texts:      infraRepo.NewSQLiteTextRepository(db),
+			sessions:   infraRepo.NewSQLiteSessionRepository(db),
+			tokens:     infraRepo.NewSQLiteAPITokenRepository(db),
+			keystrokes: infraRepo.NewSQLiteKeystrokeRepository(db),
+			lines:      infraRepo.NewSQLiteLineResultRepository(db),
+			close:      db.Close,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q (expected \"memory\", \"journal\" or \"sqlite\")", cfg.Storage)
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"typeten/internal/usecases"
+)
+
+const (
+	defaultSessionIdleTimeout = 24 * time.Hour
+	defaultSweepInterval      = 10 * time.Minute
+)
+
+// sweeperConfig configures the background abandoning of idle sessions.
+type sweeperConfig struct {
+	IdleTimeout time.Duration // SESSION_IDLE_TIMEOUT: idle time before a session is abandoned; 0 disables the sweeper
+	Interval    time.Duration // SESSION_SWEEP_INTERVAL: how often idle sessions are looked for
+}
+
+// loadSweeperConfig reads sweeperConfig from the environment, applying defaults.
+func loadSweeperConfig() (sweeperConfig, error) {
+	cfg := sweeperConfig{
+		IdleTimeout: defaultSessionIdleTimeout,
+		Interval:    defaultSweepInterval,
+	}
+	if v := os.Getenv("SESSION_IDLE_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return cfg, fmt.Errorf("SESSION_IDLE_TIMEOUT must be a non-negative duration, got %q", v)
+		}
+		cfg.IdleTimeout = d
+	}
+	if v := os.Getenv("SESSION_SWEEP_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return cfg, fmt.Errorf("SESSION_SWEEP_INTERVAL must be a positive duration, got %q", v)
+		}
+		cfg.Interval = d
+	}
+	return cfg, nil
+}
+
+// runSessionSweeper abandons idle sessions every cfg.Interval until ctx is cancelled.
+func runSessionSweeper(ctx context.Context, uc *usecases.AbandonIdleSessionsUseCase, cfg sweeperConfig) {
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			out, err := uc.Execute(ctx, usecases.AbandonIdleSessionsInput{IdleTimeout: cfg.IdleTimeout})
+			if err != nil {
+				log.Printf("Failed to abandon idle sessions: %v", err)
+				continue
+			}
+			if len(out.Sessions) > 0 {
+				log.Printf("Abandoned %d idle sessions", len(out.Sessions))
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// APITokenID identifies a personal API token.
+type APITokenID string
+
+// TokenScope limits what an API token may do.
+type TokenScope string
+
+const (
+	// ScopeRead allows only read requests (GET and HEAD).
+	ScopeRead TokenScope = "read"
+	// ScopeReadWrite allows every request the owning user may make.
+	ScopeReadWrite TokenScope = "read_write"
+)
+
+// APIToken is a personal token used to call the JSON API without a browser login.
+// Only a hash of the token secret is stored; the secret itself is shown once on creation.
+// LastUsedAt and RevokedAt are nil until the token is used or revoked.
+type APIToken struct {
+	ID         APITokenID
+	UserID     UserID
+	Name       string
+	TokenHash  string
+	Scope      TokenScope
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// NewAPIToken creates an APIToken after validating IDs, name, hash and scope.
+// Returns ErrInvalidID or ErrInvalidAPIToken if any field is invalid.
+func NewAPIToken(id APITokenID, userID UserID, name, tokenHash string, scope TokenScope, createdAt time.Time) (*APIToken, error) {
+	if strings.TrimSpace(string(id)) == "" {
+		return nil, ErrInvalidID
+	}
+	if err := validateUserID(userID); err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, NewFieldError(ErrInvalidAPIToken, "name", "must not be empty")
+	}
+	if !scope.Valid() {
+		return nil, NewFieldError(ErrInvalidAPIToken, "scope", `must be "read" or "read_write"`)
+	}
+	if tokenHash == "" {
+		return nil, ErrInvalidAPIToken
+	}
+	return &APIToken{
+		ID:        id,
+		UserID:    userID,
+		Name:      name,
+		TokenHash: tokenHash,
+		Scope:     scope,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// Valid reports whether s is a known scope.
+func (s TokenScope) Valid() bool {
+	return s == ScopeRead || s == ScopeReadWrite
+}
+
+// Allows reports whether the token may perform a request; write is true for requests that modify data.
+func (t *APIToken) Allows(write bool) bool {
+	return !write || t.Scope == ScopeReadWrite
+}
+
+// IsRevoked reports whether the token has been revoked.
+func (t *APIToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// Revoke marks the token as revoked at now. Revoking a revoked token keeps the original time.
+func (t *APIToken) Revoke(now time.Time) {
+	if t.RevokedAt == nil {
+		t.RevokedAt = &now
+	}
+}
+
+// MarkUsed records now as the last time the token authenticated a request.
+func (t *APIToken) MarkUsed(now time.Time) {
+	t.LastUsedAt = &now
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewAPIToken(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		id      APITokenID
+		userID  UserID
+		tokName string
+		hash    string
+		scope   TokenScope
+		wantErr error
+	}{
+		{name: "valid read token", id: "token_1", userID: "user_1", tokName: "ci", hash: "abc", scope: ScopeRead},
+		{name: "valid read-write token", id: "token_1", userID: "user_1", tokName: "ci", hash: "abc", scope: ScopeReadWrite},
+		{name: "empty ID", id: "", userID: "user_1", tokName: "ci", hash: "abc", scope: ScopeRead, wantErr: ErrInvalidID},
+		{name: "empty user ID", id: "token_1", userID: "", tokName: "ci", hash: "abc", scope: ScopeRead, wantErr: ErrInvalidID},
+		{name: "blank name", id: "token_1", userID: "user_1", tokName: "  ", hash: "abc", scope: ScopeRead, wantErr: ErrInvalidAPIToken},
+		{name: "empty hash", id: "token_1", userID: "user_1", tokName: "ci", hash: "", scope: ScopeRead, wantErr: ErrInvalidAPIToken},
+		{name: "unknown scope", id: "token_1", userID: "user_1", tokName: "ci", hash: "abc", scope: "admin", wantErr: ErrInvalidAPIToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAPIToken(tt.id, tt.userID, tt.tokName, tt.hash, tt.scope, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewAPIToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && (got.IsRevoked() || got.LastUsedAt != nil) {
+				t.Error("NewAPIToken() token should be unused and not revoked")
+			}
+		})
+	}
+}
+
+func TestAPIToken_Allows(t *testing.T) {
+	tests := []struct {
+		scope TokenScope
+		write bool
+		want  bool
+	}{
+		{scope: ScopeRead, write: false, want: true},
+		{scope: ScopeRead, write: true, want: false},
+		{scope: ScopeReadWrite, write: false, want: true},
+		{scope: ScopeReadWrite, write: true, want: true},
+	}
+
+	for _, tt := range tests {
+		token := &APIToken{Scope: tt.scope}
+		if got := token.Allows(tt.write); got != tt.want {
+			t.Errorf("Allows(%v) with scope %v = %v, want %v", tt.write, tt.scope, got, tt.want)
+		}
+	}
+}
+
+func TestAPIToken_Revoke(t *testing.T) {
+	now := time.Now()
+	token, err := NewAPIToken("token_1", "user_1", "ci", "abc", ScopeRead, now)
+	if err != nil {
+		t.Fatalf("NewAPIToken() error = %v", err)
+	}
+
+	token.Revoke(now)
+	token.Revoke(now.Add(time.Hour))
+	if !token.IsRevoked() || !token.RevokedAt.Equal(now) {
+		t.Errorf("Revoke() RevokedAt = %v, want %v", token.RevokedAt, now)
+	}
+}
@@ -11,4 +11,42 @@ var (
 	ErrInvalidFragment  = errors.New("domain: invalid text fragment")
 	ErrInvalidSession   = errors.New("domain: invalid session")
 	ErrInvalidSessionOp = errors.New("domain: invalid session operation")
+
+	ErrWeakPassword       = errors.New("domain: password does not meet requirements")
+	ErrEmailTaken         = errors.New("domain: email already registered")
+	ErrInvalidCredentials = errors.New("domain: invalid credentials")
+
+	ErrInvalidAPIToken   = errors.New("domain: invalid api token")
+	ErrInvalidKeystroke  = errors.New("domain: invalid keystroke event")
+	ErrInvalidLineResult = errors.New("domain: invalid line result")
+
+	// ErrInvalidQuery means list filters or pagination parameters are malformed.
+	ErrInvalidQuery = errors.New("domain: invalid query")
+
+	// ErrNotEnoughData means the user has not typed enough yet for the requested analysis.
+	ErrNotEnoughData = errors.New("domain: not enough typing data")
+
+	// ErrForbidden means the caller is authenticated but does not own the resource.
+	ErrForbidden = errors.New("domain: forbidden")
 )
+
+// FieldError reports invalid input in a single field. It wraps one of the sentinel
+// errors above, so errors.Is keeps working for callers that do not care about the field.
+type FieldError struct {
+	Field   string // input field name as seen by clients, e.g. "title"
+	Message string // human-readable reason, e.g. "must not be empty"
+	Err     error
+}
+
+// NewFieldError returns a FieldError for field wrapping err.
+func NewFieldError(err error, field, message string) error {
+	return &FieldError{Field: field, Message: message, Err: err}
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error() + ": " + e.Field + " " + e.Message
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFieldError(t *testing.T) {
+	_, err := NewTextInfo("text_1", "user_1", "  ", 1, 1, 1, time.Now())
+
+	if !errors.Is(err, ErrInvalidTextInfo) {
+		t.Errorf("NewTextInfo() error = %v, want wrapping %v", err, ErrInvalidTextInfo)
+	}
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "title" {
+		t.Errorf("NewTextInfo() error = %v, want FieldError for title", err)
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// maxKeyLength bounds KeystrokeEvent.Key; browser key names such as "Backspace" are short.
+const maxKeyLength = 32
+
+// KeystrokeEvent is one key press captured while a line of a session was typed.
+// LineIdx is the zero-based line index into the whole text (see Session.CurrentLine).
+// Key is the key as reported by the browser, e.g. "a" or "Backspace"; Expected is the
+// character under the cursor when the key was pressed, empty past the end of the line.
+// OffsetMs is the time since the line was shown. IsCorrection marks Backspace/Delete.
+type KeystrokeEvent struct {
+	SessionID    SessionID
+	LineIdx      int
+	Key          string
+	Expected     string
+	OffsetMs     int64
+	IsCorrection bool
+	RecordedAt   time.Time
+}
+
+// NewKeystrokeEvent creates a KeystrokeEvent after validating its fields.
+// Returns ErrInvalidID for an empty session ID and ErrInvalidKeystroke otherwise.
+func NewKeystrokeEvent(sessionID SessionID, lineIdx int, key, expected string, offsetMs int64, isCorrection bool, recordedAt time.Time) (*KeystrokeEvent, error) {
+	if err := validateSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	if lineIdx < 0 {
+		return nil, NewFieldError(ErrInvalidKeystroke, "line_idx", "must not be negative")
+	}
+	if key == "" || len(key) > maxKeyLength {
+		return nil, NewFieldError(ErrInvalidKeystroke, "key", "must be between 1 and 32 bytes")
+	}
+	if utf8.RuneCountInString(expected) > 1 {
+		return nil, NewFieldError(ErrInvalidKeystroke, "expected", "must be at most one character")
+	}
+	if offsetMs < 0 {
+		return nil, NewFieldError(ErrInvalidKeystroke, "offset_ms", "must not be negative")
+	}
+	return &KeystrokeEvent{
+		SessionID:    sessionID,
+		LineIdx:      lineIdx,
+		Key:          key,
+		Expected:     expected,
+		OffsetMs:     offsetMs,
+		IsCorrection: isCorrection,
+		RecordedAt:   recordedAt,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewKeystrokeEvent(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		sessionID SessionID
+		lineIdx   int
+		key       string
+		expected  string
+		offsetMs  int64
+		wantErr   error
+	}{
+		{name: "character", sessionID: "session_1", lineIdx: 0, key: "a", expected: "a", offsetMs: 120},
+		{name: "non-ascii character", sessionID: "session_1", lineIdx: 2, key: "ж", expected: "ж", offsetMs: 0},
+		{name: "backspace past the end", sessionID: "session_1", lineIdx: 1, key: "Backspace", expected: "", offsetMs: 900},
+		{name: "empty session ID", sessionID: "", key: "a", wantErr: ErrInvalidID},
+		{name: "negative line", sessionID: "session_1", lineIdx: -1, key: "a", wantErr: ErrInvalidKeystroke},
+		{name: "empty key", sessionID: "session_1", key: "", wantErr: ErrInvalidKeystroke},
+		{name: "key too long", sessionID: "session_1", key: "ThisIsNotARealKeyNameButVeryLong!", wantErr: ErrInvalidKeystroke},
+		{name: "expected two characters", sessionID: "session_1", key: "a", expected: "ab", wantErr: ErrInvalidKeystroke},
+		{name: "negative offset", sessionID: "session_1", key: "a", offsetMs: -5, wantErr: ErrInvalidKeystroke},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewKeystrokeEvent(tt.sessionID, tt.lineIdx, tt.key, tt.expected, tt.offsetMs, false, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewKeystrokeEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && (got.Key != tt.key || got.LineIdx != tt.lineIdx) {
+				t.Errorf("NewKeystrokeEvent() = %+v, want key %q on line %d", got, tt.key, tt.lineIdx)
+			}
+		})
+	}
+}
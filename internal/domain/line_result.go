@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// LineResult is the score of one completed line of a session.
+// FragmentIdx and LineIdx locate the line like Session.CurrentFragmentIdx and
+// Session.CurrentLineIdx; Typed is the input as submitted by the user.
+type LineResult struct {
+	SessionID       SessionID
+	FragmentIdx     int
+	LineIdx         int
+	Typed           string
+	AccuracyPercent float64
+	WPM             float64
+	Duration        time.Duration
+	Errors          int
+	RecordedAt      time.Time
+}
+
+// NewLineResult creates a LineResult after validating its fields.
+// Returns ErrInvalidID for an empty session ID and ErrInvalidLineResult otherwise.
+func NewLineResult(sessionID SessionID, fragmentIdx, lineIdx int, typed string, accuracyPercent, wpm float64, duration time.Duration, errorCount int, recordedAt time.Time) (*LineResult, error) {
+	if err := validateSessionID(sessionID); err != nil {
+		return nil, err
+	}
+	if fragmentIdx < 0 {
+		return nil, NewFieldError(ErrInvalidLineResult, "fragment_idx", "must not be negative")
+	}
+	if lineIdx < 0 {
+		return nil, NewFieldError(ErrInvalidLineResult, "line_idx", "must not be negative")
+	}
+	if accuracyPercent < 0 || accuracyPercent > 100 {
+		return nil, NewFieldError(ErrInvalidLineResult, "accuracy_percent", "must be between 0 and 100")
+	}
+	if wpm < 0 {
+		return nil, NewFieldError(ErrInvalidLineResult, "wpm", "must not be negative")
+	}
+	if duration < 0 {
+		return nil, NewFieldError(ErrInvalidLineResult, "duration", "must not be negative")
+	}
+	if errorCount < 0 {
+		return nil, NewFieldError(ErrInvalidLineResult, "errors", "must not be negative")
+	}
+	return &LineResult{
+		SessionID:       sessionID,
+		FragmentIdx:     fragmentIdx,
+		LineIdx:         lineIdx,
+		Typed:           typed,
+		AccuracyPercent: accuracyPercent,
+		WPM:             wpm,
+		Duration:        duration,
+		Errors:          errorCount,
+		RecordedAt:      recordedAt,
+	}, nil
+}
+
+// TextLine returns the zero-based index of the line in the whole text, given the
+// fragment size of the session's layout (see Session.CurrentLine).
+func (r *LineResult) TextLine(fragmentSize int) int {
+	return r.FragmentIdx*fragmentSize + r.LineIdx
+}
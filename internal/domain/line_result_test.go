@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewLineResult(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		sessionID   SessionID
+		fragmentIdx int
+		lineIdx     int
+		accuracy    float64
+		wpm         float64
+		duration    time.Duration
+		errorCount  int
+		wantErr     error
+	}{
+		{name: "valid", sessionID: "session_1", fragmentIdx: 1, lineIdx: 2, accuracy: 95, wpm: 40, duration: time.Second, errorCount: 1},
+		{name: "empty line", sessionID: "session_1", accuracy: 100},
+		{name: "empty session ID", sessionID: "", wantErr: ErrInvalidID},
+		{name: "negative fragment", sessionID: "session_1", fragmentIdx: -1, wantErr: ErrInvalidLineResult},
+		{name: "negative line", sessionID: "session_1", lineIdx: -1, wantErr: ErrInvalidLineResult},
+		{name: "accuracy above 100", sessionID: "session_1", accuracy: 101, wantErr: ErrInvalidLineResult},
+		{name: "negative WPM", sessionID: "session_1", wpm: -1, wantErr: ErrInvalidLineResult},
+		{name: "negative duration", sessionID: "session_1", duration: -time.Second, wantErr: ErrInvalidLineResult},
+		{name: "negative errors", sessionID: "session_1", errorCount: -1, wantErr: ErrInvalidLineResult},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewLineResult(tt.sessionID, tt.fragmentIdx, tt.lineIdx, "typed", tt.accuracy, tt.wpm, tt.duration, tt.errorCount, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewLineResult() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && (got.FragmentIdx != tt.fragmentIdx || got.LineIdx != tt.lineIdx || got.Errors != tt.errorCount) {
+				t.Errorf("NewLineResult() = %+v, want line (%d, %d) with %d errors", got, tt.fragmentIdx, tt.lineIdx, tt.errorCount)
+			}
+		})
+	}
+}
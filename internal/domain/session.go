@@ -9,19 +9,36 @@ import (
 type SessionID string
 
 // Session represents one user typing one text. CurrentFragmentIdx and CurrentLineIdx
-// are the current typing position; CompletedLines is the number of lines fully
-// completed. TotalAccuracyPercent and AverageWPM are running session-wide stats.
-// Use RecordLineCompleted to update progress and MarkCompleted when the session ends.
+// are the current typing position (CurrentLineIdx is relative to the fragment);
+// CompletedLines is the number of lines fully completed. TotalLines and FragmentSize
+// copy the text's layout so the session can move its cursor; zero means the layout is
+// unknown (sessions created before it was tracked, see SetLayout).
+// TotalAccuracyPercent and AverageWPM are running session-wide stats; CompletedWords
+// and PracticeTime sum the words and typing time of the completed lines.
+// Mode decides when the session ends (see SessionMode and SetMode).
+// Status is the session state (see SessionStatus); PausedAt is when the current pause
+// started (nil unless paused) and PausedTime sums the earlier pauses, so ActiveTime
+// can leave them out.
+// Use RecordLineCompleted to update progress; the session completes itself after the
+// last line or once its mode's limit is reached. MarkCompleted ends it early and
+// Abandon gives it up.
 type Session struct {
 	ID                   SessionID
 	UserID               UserID
 	TextID               TextID
+	TotalLines           int
+	FragmentSize         int
 	CurrentFragmentIdx   int
 	CurrentLineIdx       int
 	CompletedLines       int
 	TotalAccuracyPercent float64
 	AverageWPM           float64
-	IsCompleted          bool
+	Mode                 SessionMode
+	CompletedWords       int
+	PracticeTime         time.Duration
+	Status               SessionStatus
+	PausedAt             *time.Time
+	PausedTime           time.Duration
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 }
@@ -47,47 +64,214 @@ func NewSession(id SessionID, userID UserID, textID TextID, now time.Time) (*Ses
 		CompletedLines:       0,
 		TotalAccuracyPercent: 0,
 		AverageWPM:           0,
-		IsCompleted:          false,
+		Mode:                 SessionMode{Kind: SessionModeFullText},
+		Status:               SessionActive,
 		CreatedAt:            now,
 		UpdatedAt:            now,
 	}, nil
 }
 
-// RecordLineCompleted updates CompletedLines and running averages for accuracy and WPM.
-// accuracyPercent must be in [0, 100]; wpm must be >= 0. UpdatedAt is set to now.
-// Returns ErrInvalidSessionOp if the session is already completed or values are invalid.
-func (s *Session) RecordLineCompleted(accuracyPercent, wpm float64, now time.Time) error {
+// SetLayout records the text layout and moves the cursor to the first line not yet
+// completed. totalLines and fragmentSize must be positive and CompletedLines must not
+// exceed totalLines. Returns ErrInvalidSession otherwise.
+func (s *Session) SetLayout(totalLines, fragmentSize int) error {
 	if s == nil {
+		return ErrInvalidSession
+	}
+	if totalLines <= 0 || fragmentSize <= 0 || s.CompletedLines > totalLines {
+		return ErrInvalidSession
+	}
+	s.TotalLines = totalLines
+	s.FragmentSize = fragmentSize
+	s.moveCursor()
+	return nil
+}
+
+// SetMode sets when the session ends. It is only allowed before the first line is
+// completed; returns ErrInvalidSessionOp afterwards.
+func (s *Session) SetMode(mode SessionMode) error {
+	if s == nil || s.Status.IsFinal() || s.CompletedLines > 0 {
 		return ErrInvalidSessionOp
 	}
-	if s.IsCompleted {
+	s.Mode = mode
+	return nil
+}
+
+// HasLayout reports whether the session knows its text layout.
+func (s *Session) HasLayout() bool {
+	return s.TotalLines > 0 && s.FragmentSize > 0
+}
+
+// CurrentLine returns the cursor as a zero-based line index into the whole text.
+// Without a layout the cursor cannot be resolved, so the next uncompleted line is returned.
+func (s *Session) CurrentLine() int {
+	if !s.HasLayout() {
+		return s.CompletedLines
+	}
+	return s.CurrentFragmentIdx*s.FragmentSize + s.CurrentLineIdx
+}
+
+// RecordLineCompleted updates CompletedLines and running averages for accuracy and WPM,
+// adds the line's words and typing time, then advances the cursor to the next line.
+// Completing the last line of the text or reaching the mode's limit marks the session
+// completed. accuracyPercent must be in [0, 100]; wpm, words and elapsed must be >= 0.
+// UpdatedAt is set to now.
+// Returns ErrInvalidSessionOp if the session is not active, every line of the text
+// has been completed, or values are invalid.
+func (s *Session) RecordLineCompleted(accuracyPercent, wpm float64, words int, elapsed time.Duration, now time.Time) error {
+	if s == nil {
 		return ErrInvalidSessionOp
 	}
-	if accuracyPercent < 0 || accuracyPercent > 100 || wpm < 0 {
+	if s.Status != SessionActive {
 		return ErrInvalidSessionOp
 	}
+	if s.HasLayout() && s.CompletedLines >= s.TotalLines {
+		return ErrInvalidSessionOp
+	}
+	if accuracyPercent < 0 || accuracyPercent > 100 {
+		return NewFieldError(ErrInvalidSessionOp, "accuracy_percent", "must be between 0 and 100")
+	}
+	if wpm < 0 {
+		return NewFieldError(ErrInvalidSessionOp, "wpm", "must not be negative")
+	}
+	if words < 0 {
+		return NewFieldError(ErrInvalidSessionOp, "words", "must not be negative")
+	}
+	if elapsed < 0 {
+		return NewFieldError(ErrInvalidSessionOp, "elapsed_ms", "must not be negative")
+	}
 	n := float64(s.CompletedLines)
 	s.TotalAccuracyPercent = (s.TotalAccuracyPercent*n + accuracyPercent) / (n + 1)
 	s.AverageWPM = (s.AverageWPM*n + wpm) / (n + 1)
 	s.CompletedLines++
+	s.CompletedWords += words
+	s.PracticeTime += elapsed
+	s.UpdatedAt = now
+	if s.LimitReached() {
+		s.Status = SessionCompleted
+	}
+	if s.HasLayout() {
+		if s.CompletedLines == s.TotalLines {
+			s.Status = SessionCompleted
+		}
+		s.moveCursor()
+	}
+	return nil
+}
+
+// LimitReached reports whether the session's mode limit has been reached.
+// Always false for full-text sessions.
+func (s *Session) LimitReached() bool {
+	switch s.Mode.Kind {
+	case SessionModeMinutes:
+		return s.PracticeTime >= s.Mode.TimeLimit()
+	case SessionModeWords:
+		return s.CompletedWords >= s.Mode.Limit
+	case SessionModeLines:
+		return s.CompletedLines >= s.Mode.Limit
+	}
+	return false
+}
+
+// RemainingTime returns the typing time left in a minutes session, zero otherwise.
+func (s *Session) RemainingTime() time.Duration {
+	if s.Mode.Kind != SessionModeMinutes {
+		return 0
+	}
+	return max(0, s.Mode.TimeLimit()-s.PracticeTime)
+}
+
+// IsCompleted reports whether the session was completed.
+func (s *Session) IsCompleted() bool {
+	return s.Status == SessionCompleted
+}
+
+// IsOpen reports whether the session can still be typed, possibly after resuming it.
+func (s *Session) IsOpen() bool {
+	return s.Status == SessionActive || s.Status == SessionPaused
+}
+
+// ActiveTime returns the time since the session was created, up to now or until it
+// ended, without the time spent paused.
+func (s *Session) ActiveTime(now time.Time) time.Duration {
+	end := now
+	switch {
+	case s.Status == SessionPaused && s.PausedAt != nil:
+		end = *s.PausedAt
+	case s.Status.IsFinal():
+		end = s.UpdatedAt
+	}
+	return max(0, end.Sub(s.CreatedAt)-s.PausedTime)
+}
+
+// Pause stops the clock of an active session and sets UpdatedAt to now.
+// Returns ErrInvalidSessionOp unless the session is active.
+func (s *Session) Pause(now time.Time) error {
+	if s == nil || s.Status != SessionActive {
+		return ErrInvalidSessionOp
+	}
+	s.Status = SessionPaused
+	s.PausedAt = &now
 	s.UpdatedAt = now
 	return nil
 }
 
-// MarkCompleted marks the session as completed and sets UpdatedAt to now.
-// Returns ErrInvalidSessionOp if the session is nil or already completed.
+// Resume makes a paused session active again and sets UpdatedAt to now.
+// Returns ErrInvalidSessionOp unless the session is paused.
+func (s *Session) Resume(now time.Time) error {
+	if s == nil || s.Status != SessionPaused {
+		return ErrInvalidSessionOp
+	}
+	s.endPause(now)
+	s.Status = SessionActive
+	s.UpdatedAt = now
+	return nil
+}
+
+// MarkCompleted marks an active or paused session as completed and sets UpdatedAt to now.
+// Returns ErrInvalidSessionOp if the session is nil, completed or abandoned.
 func (s *Session) MarkCompleted(now time.Time) error {
-	if s == nil {
+	if s == nil || !s.IsOpen() {
 		return ErrInvalidSessionOp
 	}
-	if s.IsCompleted {
+	s.endPause(now)
+	s.Status = SessionCompleted
+	s.UpdatedAt = now
+	return nil
+}
+
+// Abandon gives up an active or paused session and sets UpdatedAt to now.
+// Returns ErrInvalidSessionOp if the session is nil, completed or abandoned.
+func (s *Session) Abandon(now time.Time) error {
+	if s == nil || !s.IsOpen() {
 		return ErrInvalidSessionOp
 	}
-	s.IsCompleted = true
+	s.endPause(now)
+	s.Status = SessionAbandoned
 	s.UpdatedAt = now
 	return nil
 }
 
+// endPause adds a pause in progress to PausedTime.
+func (s *Session) endPause(now time.Time) {
+	if s.Status != SessionPaused || s.PausedAt == nil {
+		return
+	}
+	s.PausedTime += max(0, now.Sub(*s.PausedAt))
+	s.PausedAt = nil
+}
+
+// moveCursor points CurrentFragmentIdx/CurrentLineIdx at the line after the last
+// completed one, or at the last line once every line is done. Requires a layout.
+func (s *Session) moveCursor() {
+	line := s.CompletedLines
+	if line >= s.TotalLines {
+		line = s.TotalLines - 1
+	}
+	s.CurrentFragmentIdx = line / s.FragmentSize
+	s.CurrentLineIdx = line % s.FragmentSize
+}
+
 func validateSessionID(id SessionID) error {
 	if strings.TrimSpace(string(id)) == "" {
 		return ErrInvalidID
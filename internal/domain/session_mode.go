@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// SessionModeKind selects when a session ends.
+type SessionModeKind string
+
+// Session modes. A full-text session ends after the last line of the text; the others
+// end once the limit is reached, or at the end of the text if that comes first.
+const (
+	SessionModeFullText SessionModeKind = "full_text"
+	SessionModeMinutes  SessionModeKind = "minutes"
+	SessionModeWords    SessionModeKind = "words"
+	SessionModeLines    SessionModeKind = "lines"
+)
+
+// Upper bounds of SessionMode.Limit per kind.
+const (
+	MaxSessionMinutes = 120
+	MaxSessionWords   = 10000
+	MaxSessionLines   = 10000
+)
+
+// SessionMode is the kind of a session and its limit: minutes of typing, words or
+// lines. Limit is zero for full-text sessions. The zero value is a full-text mode,
+// which is what sessions stored before modes existed decode to.
+type SessionMode struct {
+	Kind  SessionModeKind
+	Limit int
+}
+
+// NewSessionMode validates kind and limit. An empty kind means full text.
+// Returns ErrInvalidSession for an unknown kind or a limit out of range.
+func NewSessionMode(kind SessionModeKind, limit int) (SessionMode, error) {
+	if kind == "" {
+		kind = SessionModeFullText
+	}
+	var maxLimit int
+	switch kind {
+	case SessionModeFullText:
+		if limit != 0 {
+			return SessionMode{}, NewFieldError(ErrInvalidSession, "mode_limit", "must be empty for full_text")
+		}
+		return SessionMode{Kind: kind}, nil
+	case SessionModeMinutes:
+		maxLimit = MaxSessionMinutes
+	case SessionModeWords:
+		maxLimit = MaxSessionWords
+	case SessionModeLines:
+		maxLimit = MaxSessionLines
+	default:
+		return SessionMode{}, NewFieldError(ErrInvalidSession, "mode", "must be one of full_text, minutes, words, lines")
+	}
+	if limit < 1 || limit > maxLimit {
+		return SessionMode{}, NewFieldError(ErrInvalidSession, "mode_limit", fmt.Sprintf("must be between 1 and %d", maxLimit))
+	}
+	return SessionMode{Kind: kind, Limit: limit}, nil
+}
+
+// IsFullText reports whether the session runs to the end of the text.
+func (m SessionMode) IsFullText() bool {
+	return m.Kind == "" || m.Kind == SessionModeFullText
+}
+
+// TimeLimit returns the typing time allowed by a minutes mode, zero otherwise.
+func (m SessionMode) TimeLimit() time.Duration {
+	if m.Kind != SessionModeMinutes {
+		return 0
+	}
+	return time.Duration(m.Limit) * time.Minute
+}
+
+// String describes the mode for display, e.g. "5 minutes".
+func (m SessionMode) String() string {
+	if m.IsFullText() {
+		return "Full text"
+	}
+	unit := string(m.Kind)
+	if m.Limit == 1 {
+		unit = unit[:len(unit)-1]
+	}
+	return fmt.Sprintf("%d %s", m.Limit, unit)
+}
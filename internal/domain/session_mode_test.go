@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewSessionMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    SessionModeKind
+		limit   int
+		want    SessionMode
+		wantErr bool
+	}{
+		{name: "default", want: SessionMode{Kind: SessionModeFullText}},
+		{name: "full text", kind: SessionModeFullText, want: SessionMode{Kind: SessionModeFullText}},
+		{name: "full text with limit", kind: SessionModeFullText, limit: 5, wantErr: true},
+		{name: "minutes", kind: SessionModeMinutes, limit: 5, want: SessionMode{Kind: SessionModeMinutes, Limit: 5}},
+		{name: "too many minutes", kind: SessionModeMinutes, limit: MaxSessionMinutes + 1, wantErr: true},
+		{name: "words", kind: SessionModeWords, limit: 100, want: SessionMode{Kind: SessionModeWords, Limit: 100}},
+		{name: "lines without limit", kind: SessionModeLines, wantErr: true},
+		{name: "unknown kind", kind: "sprint", limit: 5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSessionMode(tt.kind, tt.limit)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSession) {
+					t.Errorf("NewSessionMode() error = %v, want %v", err, ErrInvalidSession)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSessionMode() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NewSessionMode() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionMode_String(t *testing.T) {
+	tests := []struct {
+		mode SessionMode
+		want string
+	}{
+		{mode: SessionMode{}, want: "Full text"},
+		{mode: SessionMode{Kind: SessionModeMinutes, Limit: 1}, want: "1 minute"},
+		{mode: SessionMode{Kind: SessionModeWords, Limit: 250}, want: "250 words"},
+		{mode: SessionMode{Kind: SessionModeLines, Limit: 20}, want: "20 lines"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.mode.String(); got != tt.want {
+			t.Errorf("SessionMode.String() = %q, want %q", got, tt.want)
+		}
+	}
+}
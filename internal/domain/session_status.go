@@ -0,0 +1,35 @@
+package domain
+
+// SessionStatus is the state of a session. A session starts active and may be
+// paused and resumed any number of times; completed and abandoned are final:
+//
+//	active -> paused     Pause
+//	paused -> active     Resume
+//	active -> completed  RecordLineCompleted (last line or limit), MarkCompleted
+//	paused -> completed  MarkCompleted
+//	active -> abandoned  Abandon
+//	paused -> abandoned  Abandon
+type SessionStatus string
+
+// Session statuses.
+const (
+	SessionActive    SessionStatus = "active"
+	SessionPaused    SessionStatus = "paused"
+	SessionCompleted SessionStatus = "completed"
+	SessionAbandoned SessionStatus = "abandoned"
+)
+
+// ParseSessionStatus validates a status name.
+// Returns ErrInvalidSession for an unknown status.
+func ParseSessionStatus(s string) (SessionStatus, error) {
+	switch status := SessionStatus(s); status {
+	case SessionActive, SessionPaused, SessionCompleted, SessionAbandoned:
+		return status, nil
+	}
+	return "", NewFieldError(ErrInvalidSession, "status", "must be one of active, paused, completed, abandoned")
+}
+
+// IsFinal reports whether no further transitions are allowed.
+func (s SessionStatus) IsFinal() bool {
+	return s == SessionCompleted || s == SessionAbandoned
+}
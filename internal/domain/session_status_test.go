@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSessionStatus(t *testing.T) {
+	for _, s := range []string{"active", "paused", "completed", "abandoned"} {
+		got, err := ParseSessionStatus(s)
+		if err != nil || string(got) != s {
+			t.Errorf("ParseSessionStatus(%q) = %v, %v, want %v", s, got, err, s)
+		}
+	}
+	for _, s := range []string{"", "Active", "done"} {
+		if _, err := ParseSessionStatus(s); !errors.Is(err, ErrInvalidSession) {
+			t.Errorf("ParseSessionStatus(%q) error = %v, want %v", s, err, ErrInvalidSession)
+		}
+	}
+}
+
+func TestSessionStatus_IsFinal(t *testing.T) {
+	want := map[SessionStatus]bool{
+		SessionActive:    false,
+		SessionPaused:    false,
+		SessionCompleted: true,
+		SessionAbandoned: true,
+	}
+	for status, final := range want {
+		if status.IsFinal() != final {
+			t.Errorf("%v.IsFinal() = %v, want %v", status, status.IsFinal(), final)
+		}
+	}
+}
@@ -74,7 +74,7 @@ func TestNewSession(t *testing.T) {
 				if session.CompletedLines != 0 {
 					t.Errorf("NewSession() CompletedLines = %v, want 0", session.CompletedLines)
 				}
-				if session.IsCompleted {
+				if session.IsCompleted() {
 					t.Error("NewSession() IsCompleted = true, want false")
 				}
 			}
@@ -149,7 +149,7 @@ func TestSession_RecordLineCompleted(t *testing.T) {
 			accuracyPercent: 95.0,
 			wpm:             45.0,
 			setup: func(s *Session) {
-				s.IsCompleted = true
+				s.Status = SessionCompleted
 			},
 			wantErr: ErrInvalidSessionOp,
 		},
@@ -186,13 +186,13 @@ func TestSession_RecordLineCompleted(t *testing.T) {
 				CompletedLines:     0,
 				TotalAccuracyPercent: 0,
 				AverageWPM:         0,
-				IsCompleted:        false,
+				Status:             SessionActive,
 				CreatedAt:          now,
 				UpdatedAt:          now,
 			}
 			tt.setup(s)
 
-			err := s.RecordLineCompleted(tt.accuracyPercent, tt.wpm, now.Add(time.Second))
+			err := s.RecordLineCompleted(tt.accuracyPercent, tt.wpm, 0, 0, now.Add(time.Second))
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("RecordLineCompleted() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -213,7 +213,7 @@ func TestSession_RecordLineCompleted(t *testing.T) {
 
 	// Test nil session
 	var nilSession *Session
-	err = nilSession.RecordLineCompleted(95.0, 45.0, now)
+	err = nilSession.RecordLineCompleted(95.0, 45.0, 0, 0, now)
 	if !errors.Is(err, ErrInvalidSessionOp) {
 		t.Errorf("RecordLineCompleted() on nil session error = %v, wantErr %v", err, ErrInvalidSessionOp)
 	}
@@ -239,7 +239,7 @@ func TestSession_MarkCompleted(t *testing.T) {
 		{
 			name: "already completed",
 			setup: func(s *Session) {
-				s.IsCompleted = true
+				s.Status = SessionCompleted
 			},
 			wantErr: ErrInvalidSessionOp,
 		},
@@ -256,7 +256,7 @@ func TestSession_MarkCompleted(t *testing.T) {
 				CompletedLines:     0,
 				TotalAccuracyPercent: 0,
 				AverageWPM:         0,
-				IsCompleted:        false,
+				Status:             SessionActive,
 				CreatedAt:          now,
 				UpdatedAt:          now,
 			}
@@ -268,7 +268,7 @@ func TestSession_MarkCompleted(t *testing.T) {
 				return
 			}
 			if tt.wantErr == nil {
-				if !s.IsCompleted {
+				if !s.IsCompleted() {
 					t.Error("MarkCompleted() IsCompleted = false, want true")
 				}
 			}
@@ -282,3 +282,213 @@ func TestSession_MarkCompleted(t *testing.T) {
 		t.Errorf("MarkCompleted() on nil session error = %v, wantErr %v", err, ErrInvalidSessionOp)
 	}
 }
+
+func TestSession_SetLayout(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		completed    int
+		totalLines   int
+		fragmentSize int
+		wantErr      error
+		wantFragment int
+		wantLine     int
+	}{
+		{name: "fresh session", completed: 0, totalLines: 7, fragmentSize: 3, wantFragment: 0, wantLine: 0},
+		{name: "resumes after completed lines", completed: 4, totalLines: 7, fragmentSize: 3, wantFragment: 1, wantLine: 1},
+		{name: "all lines completed", completed: 7, totalLines: 7, fragmentSize: 3, wantFragment: 2, wantLine: 0},
+		{name: "zero total lines", totalLines: 0, fragmentSize: 3, wantErr: ErrInvalidSession},
+		{name: "zero fragment size", totalLines: 7, fragmentSize: 0, wantErr: ErrInvalidSession},
+		{name: "more completed than total", completed: 8, totalLines: 7, fragmentSize: 3, wantErr: ErrInvalidSession},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSession("session_1", "user_1", "text_1", now)
+			if err != nil {
+				t.Fatalf("NewSession() error = %v", err)
+			}
+			s.CompletedLines = tt.completed
+
+			err = s.SetLayout(tt.totalLines, tt.fragmentSize)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SetLayout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil {
+				if s.CurrentFragmentIdx != tt.wantFragment || s.CurrentLineIdx != tt.wantLine {
+					t.Errorf("SetLayout() cursor = (%v, %v), want (%v, %v)",
+						s.CurrentFragmentIdx, s.CurrentLineIdx, tt.wantFragment, tt.wantLine)
+				}
+				if want := tt.wantFragment*tt.fragmentSize + tt.wantLine; s.CurrentLine() != want {
+					t.Errorf("CurrentLine() = %v, want %v", s.CurrentLine(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestSession_RecordLineCompleted_AdvancesAndCompletes(t *testing.T) {
+	now := time.Now()
+	s, err := NewSession("session_1", "user_1", "text_1", now)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	if err := s.SetLayout(5, 2); err != nil {
+		t.Fatalf("SetLayout() error = %v", err)
+	}
+
+	wantCursors := [][2]int{{0, 1}, {1, 0}, {1, 1}, {2, 0}, {2, 0}}
+	for i, want := range wantCursors {
+		if err := s.RecordLineCompleted(90, 40, 0, 0, now); err != nil {
+			t.Fatalf("RecordLineCompleted() line %d error = %v", i, err)
+		}
+		if s.CurrentFragmentIdx != want[0] || s.CurrentLineIdx != want[1] {
+			t.Errorf("RecordLineCompleted() line %d cursor = (%v, %v), want (%v, %v)",
+				i, s.CurrentFragmentIdx, s.CurrentLineIdx, want[0], want[1])
+		}
+		if wantDone := i == len(wantCursors)-1; s.IsCompleted() != wantDone {
+			t.Errorf("RecordLineCompleted() line %d IsCompleted = %v, want %v", i, s.IsCompleted(), wantDone)
+		}
+	}
+
+	if err := s.RecordLineCompleted(90, 40, 0, 0, now); !errors.Is(err, ErrInvalidSessionOp) {
+		t.Errorf("RecordLineCompleted() past the end error = %v, wantErr %v", err, ErrInvalidSessionOp)
+	}
+	if s.CompletedLines != 5 {
+		t.Errorf("RecordLineCompleted() CompletedLines = %v, want 5", s.CompletedLines)
+	}
+}
+
+func TestSession_ModeLimits(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		mode      SessionMode
+		words     int
+		elapsed   time.Duration
+		wantLines int // lines completed when the session completes
+	}{
+		{name: "full text", mode: SessionMode{Kind: SessionModeFullText}, words: 3, elapsed: time.Minute, wantLines: 10},
+		{name: "lines", mode: SessionMode{Kind: SessionModeLines, Limit: 4}, words: 3, elapsed: time.Second, wantLines: 4},
+		{name: "words", mode: SessionMode{Kind: SessionModeWords, Limit: 10}, words: 3, elapsed: time.Second, wantLines: 4},
+		{name: "minutes", mode: SessionMode{Kind: SessionModeMinutes, Limit: 1}, words: 3, elapsed: 25 * time.Second, wantLines: 3},
+		{name: "limit past the end of the text", mode: SessionMode{Kind: SessionModeLines, Limit: 50}, words: 3, elapsed: time.Second, wantLines: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSession("session_1", "user_1", "text_1", now)
+			if err != nil {
+				t.Fatalf("NewSession() error = %v", err)
+			}
+			if err := s.SetLayout(10, 5); err != nil {
+				t.Fatalf("SetLayout() error = %v", err)
+			}
+			if err := s.SetMode(tt.mode); err != nil {
+				t.Fatalf("SetMode() error = %v", err)
+			}
+			for !s.IsCompleted() {
+				if err := s.RecordLineCompleted(100, 40, tt.words, tt.elapsed, now); err != nil {
+					t.Fatalf("RecordLineCompleted() line %d error = %v", s.CompletedLines, err)
+				}
+			}
+			if s.CompletedLines != tt.wantLines {
+				t.Errorf("RecordLineCompleted() completed after %v lines, want %v", s.CompletedLines, tt.wantLines)
+			}
+			if s.CompletedWords != tt.words*tt.wantLines {
+				t.Errorf("RecordLineCompleted() CompletedWords = %v, want %v", s.CompletedWords, tt.words*tt.wantLines)
+			}
+			if err := s.RecordLineCompleted(100, 40, tt.words, tt.elapsed, now); !errors.Is(err, ErrInvalidSessionOp) {
+				t.Errorf("RecordLineCompleted() after the limit error = %v, wantErr %v", err, ErrInvalidSessionOp)
+			}
+		})
+	}
+}
+
+func TestSession_SetMode(t *testing.T) {
+	now := time.Now()
+	s, err := NewSession("session_1", "user_1", "text_1", now)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	mode := SessionMode{Kind: SessionModeMinutes, Limit: 2}
+	if err := s.SetMode(mode); err != nil {
+		t.Fatalf("SetMode() error = %v", err)
+	}
+	if s.RemainingTime() != 2*time.Minute {
+		t.Errorf("RemainingTime() = %v, want 2m", s.RemainingTime())
+	}
+	if err := s.RecordLineCompleted(100, 40, 2, 50*time.Second, now); err != nil {
+		t.Fatalf("RecordLineCompleted() error = %v", err)
+	}
+	if s.RemainingTime() != 70*time.Second {
+		t.Errorf("RemainingTime() = %v, want 1m10s", s.RemainingTime())
+	}
+	if err := s.SetMode(SessionMode{Kind: SessionModeFullText}); !errors.Is(err, ErrInvalidSessionOp) {
+		t.Errorf("SetMode() after progress error = %v, wantErr %v", err, ErrInvalidSessionOp)
+	}
+}
+
+func TestSession_StatusTransitions(t *testing.T) {
+	start := time.Date(2024, 3, 6, 9, 0, 0, 0, time.UTC)
+	s, err := NewSession("session_1", "user_1", "text_1", start)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	if s.Status != SessionActive {
+		t.Fatalf("NewSession() Status = %v, want %v", s.Status, SessionActive)
+	}
+
+	if err := s.Resume(start); !errors.Is(err, ErrInvalidSessionOp) {
+		t.Errorf("Resume() on active session error = %v, wantErr %v", err, ErrInvalidSessionOp)
+	}
+	if err := s.Pause(start.Add(time.Minute)); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if err := s.Pause(start.Add(2 * time.Minute)); !errors.Is(err, ErrInvalidSessionOp) {
+		t.Errorf("Pause() on paused session error = %v, wantErr %v", err, ErrInvalidSessionOp)
+	}
+	if err := s.RecordLineCompleted(100, 40, 2, time.Second, start.Add(2*time.Minute)); !errors.Is(err, ErrInvalidSessionOp) {
+		t.Errorf("RecordLineCompleted() on paused session error = %v, wantErr %v", err, ErrInvalidSessionOp)
+	}
+	// Paused time does not count, even while the pause lasts.
+	if got := s.ActiveTime(start.Add(time.Hour)); got != time.Minute {
+		t.Errorf("ActiveTime() while paused = %v, want 1m", got)
+	}
+	if err := s.Resume(start.Add(11 * time.Minute)); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if s.PausedTime != 10*time.Minute || s.PausedAt != nil {
+		t.Errorf("Resume() PausedTime = %v, PausedAt = %v, want 10m, nil", s.PausedTime, s.PausedAt)
+	}
+	if got := s.ActiveTime(start.Add(12 * time.Minute)); got != 2*time.Minute {
+		t.Errorf("ActiveTime() = %v, want 2m", got)
+	}
+
+	if err := s.Pause(start.Add(13 * time.Minute)); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if err := s.Abandon(start.Add(20 * time.Minute)); err != nil {
+		t.Fatalf("Abandon() error = %v", err)
+	}
+	if s.Status != SessionAbandoned || s.IsOpen() || s.IsCompleted() {
+		t.Errorf("Abandon() Status = %v, want %v", s.Status, SessionAbandoned)
+	}
+	// Time is frozen once the session ends.
+	if got := s.ActiveTime(start.Add(time.Hour)); got != 3*time.Minute {
+		t.Errorf("ActiveTime() after Abandon() = %v, want 3m", got)
+	}
+	for name, transition := range map[string]func(time.Time) error{
+		"Pause":         s.Pause,
+		"Resume":        s.Resume,
+		"MarkCompleted": s.MarkCompleted,
+		"Abandon":       s.Abandon,
+	} {
+		if err := transition(start.Add(time.Hour)); !errors.Is(err, ErrInvalidSessionOp) {
+			t.Errorf("%s() on abandoned session error = %v, wantErr %v", name, err, ErrInvalidSessionOp)
+		}
+	}
+}
@@ -14,16 +14,40 @@ type (
 // TextInfo holds metadata for a single text: ownership, title, and layout
 // (total lines, fragment size and count). The actual content is stored as
 // one or more TextFragment values referenced by TextID.
+// Author and Chapters are set for imported books (see SetChapters).
+// Normalization records the steps the content was cleaned up with before it was
+// split into lines, and Wrap how long paragraphs were broken into lines.
+// Kind tells the user's own texts from texts generated for them.
 type TextInfo struct {
 	ID            TextID
 	UserID        UserID
+	Kind          TextKind
 	Title         string
+	Author        string
 	TotalLines    int
 	FragmentSize  int
 	FragmentCount int
+	Chapters      []TextChapter
+	Normalization []Normalization
+	Wrap          TextWrap
 	CreatedAt     time.Time
 }
 
+// TextKind is where a text came from.
+type TextKind string
+
+const (
+	TextKindUser  TextKind = "user"  // added or imported by the user
+	TextKindDrill TextKind = "drill" // generated from the user's weak keys
+)
+
+// TextChapter marks the line a chapter of a book starts at. Line is zero-based
+// across the whole text.
+type TextChapter struct {
+	Title string
+	Line  int
+}
+
 // NewTextInfo creates a TextInfo after validating IDs, title, and line/fragment counts.
 // Returns ErrInvalidTextInfo if any field is invalid.
 func NewTextInfo(id TextID, userID UserID, title string, totalLines, fragmentSize, fragmentCount int, createdAt time.Time) (*TextInfo, error) {
@@ -34,7 +58,7 @@ func NewTextInfo(id TextID, userID UserID, title string, totalLines, fragmentSiz
 		return nil, err
 	}
 	if strings.TrimSpace(title) == "" {
-		return nil, ErrInvalidTextInfo
+		return nil, NewFieldError(ErrInvalidTextInfo, "title", "must not be empty")
 	}
 	if totalLines <= 0 || fragmentSize <= 0 || fragmentCount <= 0 {
 		return nil, ErrInvalidTextInfo
@@ -42,6 +66,7 @@ func NewTextInfo(id TextID, userID UserID, title string, totalLines, fragmentSiz
 	return &TextInfo{
 		ID:            id,
 		UserID:        userID,
+		Kind:          TextKindUser,
 		Title:         strings.TrimSpace(title),
 		TotalLines:    totalLines,
 		FragmentSize:  fragmentSize,
@@ -50,6 +75,26 @@ func NewTextInfo(id TextID, userID UserID, title string, totalLines, fragmentSiz
 	}, nil
 }
 
+// IsDrill reports whether the text is a generated drill.
+func (t *TextInfo) IsDrill() bool {
+	return t.Kind == TextKindDrill
+}
+
+// SetChapters sets the chapter markers of the text. Chapters must have a title and
+// start at increasing lines within the text; returns ErrInvalidTextInfo otherwise.
+func (t *TextInfo) SetChapters(chapters []TextChapter) error {
+	for i, c := range chapters {
+		if strings.TrimSpace(c.Title) == "" {
+			return NewFieldError(ErrInvalidTextInfo, "chapters", "must have a title")
+		}
+		if c.Line < 0 || c.Line >= t.TotalLines || (i > 0 && c.Line <= chapters[i-1].Line) {
+			return NewFieldError(ErrInvalidTextInfo, "chapters", "must start at increasing lines of the text")
+		}
+	}
+	t.Chapters = append([]TextChapter(nil), chapters...)
+	return nil
+}
+
 // TextFragment is one chunk of a text's content (Lines), with FragmentIdx
 // indicating its order. The full text is the ordered set of fragments for a given TextID.
 type TextFragment struct {
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Normalization is one step of the chain that cleans up a text's content before it
+// is split into lines. The steps always run in the order of AllNormalizations,
+// whatever order they are chosen in.
+type Normalization string
+
+// Normalization steps.
+const (
+	NormalizeLineEndings Normalization = "line_endings" // \r\n and lone \r end a line like \n
+	NormalizeUnicode     Normalization = "nfc"          // Unicode NFC, so й is one character
+	NormalizeTypography  Normalization = "typography"   // “”«», dashes, … and special spaces to ASCII
+	NormalizeYo          Normalization = "yo"           // ё to е
+	NormalizeWhitespace  Normalization = "whitespace"   // runs of spaces and tabs to one space, lines trimmed
+	NormalizeBlankLines  Normalization = "blank_lines"  // blank lines removed
+)
+
+// AllNormalizations returns every step in the order they run.
+func AllNormalizations() []Normalization {
+	return []Normalization{
+		NormalizeLineEndings, NormalizeUnicode, NormalizeTypography,
+		NormalizeYo, NormalizeWhitespace, NormalizeBlankLines,
+	}
+}
+
+// DefaultNormalization returns the steps applied when none are chosen: all but
+// the ё replacement, which changes the spelling.
+func DefaultNormalization() []Normalization {
+	return []Normalization{
+		NormalizeLineEndings, NormalizeUnicode, NormalizeTypography,
+		NormalizeWhitespace, NormalizeBlankLines,
+	}
+}
+
+// ParseNormalization validates step names, ignoring blanks and repeats, and returns
+// the steps in the order they run. The result is empty, not nil, if names is.
+// Returns ErrInvalidTextInfo for an unknown name.
+func ParseNormalization(names []string) ([]Normalization, error) {
+	chosen := make(map[Normalization]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		n := Normalization(name)
+		if !n.valid() {
+			return nil, NewFieldError(ErrInvalidTextInfo, "normalize", fmt.Sprintf("unknown option %q", name))
+		}
+		chosen[n] = true
+	}
+	steps := []Normalization{}
+	for _, n := range AllNormalizations() {
+		if chosen[n] {
+			steps = append(steps, n)
+		}
+	}
+	return steps, nil
+}
+
+func (n Normalization) valid() bool {
+	for _, known := range AllNormalizations() {
+		if n == known {
+			return true
+		}
+	}
+	return false
+}
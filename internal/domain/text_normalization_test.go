@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseNormalization(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []Normalization
+		wantErr bool
+	}{
+		{name: "none", names: nil, want: []Normalization{}},
+		{name: "blanks ignored", names: []string{"", " "}, want: []Normalization{}},
+		{name: "run order and repeats", names: []string{"yo", "line_endings", " yo "}, want: []Normalization{NormalizeLineEndings, NormalizeYo}},
+		{name: "all", names: []string{"blank_lines", "whitespace", "yo", "typography", "nfc", "line_endings"}, want: AllNormalizations()},
+		{name: "unknown", names: []string{"nfc", "lowercase"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNormalization(tt.names)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTextInfo) {
+					t.Errorf("ParseNormalization() error = %v, want %v", err, ErrInvalidTextInfo)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseNormalization() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseNormalization() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
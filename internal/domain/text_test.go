@@ -262,3 +262,43 @@ func TestTextFragment_Lines(t *testing.T) {
 		t.Error("nil fragment Lines() should return nil")
 	}
 }
+
+func TestTextInfo_SetChapters(t *testing.T) {
+	tests := []struct {
+		name     string
+		chapters []TextChapter
+		wantErr  bool
+	}{
+		{name: "none", chapters: nil},
+		{name: "increasing", chapters: []TextChapter{{Title: "One", Line: 0}, {Title: "Two", Line: 4}}},
+		{name: "untitled", chapters: []TextChapter{{Title: " ", Line: 0}}, wantErr: true},
+		{name: "out of range", chapters: []TextChapter{{Title: "One", Line: 10}}, wantErr: true},
+		{name: "negative", chapters: []TextChapter{{Title: "One", Line: -1}}, wantErr: true},
+		{name: "not increasing", chapters: []TextChapter{{Title: "One", Line: 3}, {Title: "Two", Line: 3}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := NewTextInfo("text_1", "user_1", "Book", 10, 5, 2, time.Now())
+			if err != nil {
+				t.Fatalf("NewTextInfo() error = %v", err)
+			}
+			err = info.SetChapters(tt.chapters)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTextInfo) {
+					t.Fatalf("SetChapters() error = %v, want ErrInvalidTextInfo", err)
+				}
+				if info.Chapters != nil {
+					t.Errorf("SetChapters() changed chapters on error: %v", info.Chapters)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetChapters() error = %v", err)
+			}
+			if len(info.Chapters) != len(tt.chapters) {
+				t.Errorf("SetChapters() chapters = %v, want %v", info.Chapters, tt.chapters)
+			}
+		})
+	}
+}
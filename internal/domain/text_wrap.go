@@ -0,0 +1,45 @@
+package domain
+
+import "fmt"
+
+// Bounds of TextWrap.Width when wrapping is on.
+const (
+	MinWrapWidth = 20
+	MaxWrapWidth = 500
+)
+
+// TextWrap is how long paragraphs are broken into typeable lines: at word boundaries
+// so that lines are at most Width characters (runes) long, and, with Sentences, at
+// the end of every sentence. The zero value keeps every paragraph on one line, which
+// is what texts stored before wrapping existed decode to.
+type TextWrap struct {
+	Width     int
+	Sentences bool
+}
+
+// NewTextWrap validates the wrap width; zero means no width limit.
+// Returns ErrInvalidTextInfo for a width out of range.
+func NewTextWrap(width int, sentences bool) (TextWrap, error) {
+	if width != 0 && (width < MinWrapWidth || width > MaxWrapWidth) {
+		return TextWrap{}, NewFieldError(ErrInvalidTextInfo, "wrap_width", fmt.Sprintf("must be between %d and %d", MinWrapWidth, MaxWrapWidth))
+	}
+	return TextWrap{Width: width, Sentences: sentences}, nil
+}
+
+// IsZero reports whether paragraphs are kept whole.
+func (w TextWrap) IsZero() bool {
+	return w.Width == 0 && !w.Sentences
+}
+
+// String describes the wrapping for display, e.g. "Wrapped at 80 characters".
+func (w TextWrap) String() string {
+	switch {
+	case w.IsZero():
+		return "Not wrapped"
+	case w.Width == 0:
+		return "One sentence per line"
+	case w.Sentences:
+		return fmt.Sprintf("Wrapped at %d characters, one sentence per line", w.Width)
+	}
+	return fmt.Sprintf("Wrapped at %d characters", w.Width)
+}
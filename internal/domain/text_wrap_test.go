@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewTextWrap(t *testing.T) {
+	tests := []struct {
+		name      string
+		width     int
+		sentences bool
+		want      TextWrap
+		wantStr   string
+		wantErr   bool
+	}{
+		{name: "off", want: TextWrap{}, wantStr: "Not wrapped"},
+		{name: "sentences only", sentences: true, want: TextWrap{Sentences: true}, wantStr: "One sentence per line"},
+		{name: "width", width: 80, want: TextWrap{Width: 80}, wantStr: "Wrapped at 80 characters"},
+		{name: "width and sentences", width: MinWrapWidth, sentences: true, want: TextWrap{Width: MinWrapWidth, Sentences: true}, wantStr: "Wrapped at 20 characters, one sentence per line"},
+		{name: "too narrow", width: MinWrapWidth - 1, wantErr: true},
+		{name: "too wide", width: MaxWrapWidth + 1, wantErr: true},
+		{name: "negative", width: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTextWrap(tt.width, tt.sentences)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTextInfo) {
+					t.Errorf("NewTextWrap() error = %v, want %v", err, ErrInvalidTextInfo)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewTextWrap() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NewTextWrap() = %+v, want %+v", got, tt.want)
+			}
+			if got.String() != tt.wantStr {
+				t.Errorf("String() = %q, want %q", got.String(), tt.wantStr)
+			}
+		})
+	}
+}
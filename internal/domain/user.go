@@ -12,11 +12,14 @@ import (
 type UserID string
 
 // User is the aggregate for an account. Email and Username must be valid and non-empty.
+// PasswordHash is the salted hash used for password login; it is empty for
+// accounts that cannot log in with a password.
 type User struct {
-	ID        UserID
-	Email     string
-	Username  string
-	CreatedAt time.Time
+	ID           UserID
+	Email        string
+	Username     string
+	PasswordHash string
+	CreatedAt    time.Time
 }
 
 // Minimal email pattern: non-empty local part, @, non-empty domain with at least one dot.
@@ -31,10 +34,10 @@ func NewUser(id UserID, email, username string, createdAt time.Time) (*User, err
 	email = strings.TrimSpace(email)
 	username = strings.TrimSpace(username)
 	if email == "" || !emailRx.MatchString(email) {
-		return nil, ErrInvalidUser
+		return nil, NewFieldError(ErrInvalidUser, "email", "must be a valid email address")
 	}
 	if username == "" {
-		return nil, ErrInvalidUser
+		return nil, NewFieldError(ErrInvalidUser, "username", "must not be empty")
 	}
 	return &User{
 		ID:        id,
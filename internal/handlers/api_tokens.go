@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"typeten/internal/domain"
+	"typeten/internal/usecases"
+)
+
+// CreateAPIToken handles POST /api/tokens
+func (h *Handlers) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var req CreateAPITokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+	if req.Scope == "" {
+		req.Scope = string(domain.ScopeRead)
+	}
+
+	output, err := h.createAPITokenUseCase.Execute(r.Context(), usecases.CreateAPITokenInput{
+		UserID: userID,
+		Name:   req.Name,
+		Scope:  domain.TokenScope(req.Scope),
+	})
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	resp := CreateAPITokenResponse{
+		APITokenResponse: apiTokenToResponse(output.Token),
+		Token:            output.Secret,
+	}
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// ListAPITokens handles GET /api/tokens
+func (h *Handlers) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	output, err := h.listAPITokensUseCase.Execute(r.Context(), usecases.ListAPITokensInput{UserID: userID})
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	tokens := make([]APITokenResponse, len(output.Tokens))
+	for i, token := range output.Tokens {
+		tokens[i] = apiTokenToResponse(token)
+	}
+
+	respondJSON(w, http.StatusOK, ListAPITokensResponse{Tokens: tokens})
+}
+
+// RevokeAPIToken handles DELETE /api/tokens/:id
+func (h *Handlers) RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	tokenID := strings.TrimPrefix(r.URL.Path, "/api/tokens/")
+
+	_, err := h.revokeAPITokenUseCase.Execute(r.Context(), usecases.RevokeAPITokenInput{
+		UserID:  userID,
+		TokenID: domain.APITokenID(tokenID),
+	})
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlers_APITokens(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := handlers.RequireLogin(NewRouter(handlers))
+
+	createToken := func(body string) CreateAPITokenResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/tokens", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("CreateAPIToken() status = %v, want %v", w.Code, http.StatusCreated)
+		}
+		var resp CreateAPITokenResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+	readOnly := createToken(`{"name":"reports","scope":"read"}`)
+	readWrite := createToken(`{"name":"importer","scope":"read_write"}`)
+
+	// From here on only the bearer token authenticates requests.
+	handlers.defaultUserID = ""
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		token      string
+		wantStatus int
+	}{
+		{name: "read-only token can read", method: http.MethodGet, path: "/api/texts", token: readOnly.Token, wantStatus: http.StatusOK},
+		{name: "read-only token cannot write", method: http.MethodPost, path: "/api/texts", body: `{"title":"T","content":"line1"}`, token: readOnly.Token, wantStatus: http.StatusForbidden},
+		{name: "read-write token can write", method: http.MethodPost, path: "/api/texts", body: `{"title":"T","content":"line1"}`, token: readWrite.Token, wantStatus: http.StatusCreated},
+		{name: "unknown token", method: http.MethodGet, path: "/api/texts", token: "tt_unknown", wantStatus: http.StatusUnauthorized},
+		{name: "token cannot manage tokens", method: http.MethodGet, path: "/api/tokens", token: readWrite.Token, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("%s %s status = %v, want %v", tt.method, tt.path, w.Code, tt.wantStatus)
+			}
+		})
+	}
+
+	t.Run("list and revoke", func(t *testing.T) {
+		cookie := issueCookie(t, handlers.cookieSessions, "user_1")
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/tokens/"+readOnly.ID, nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("RevokeAPIToken() status = %v, want %v", w.Code, http.StatusNoContent)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/api/tokens", nil)
+		req.AddCookie(cookie)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var list ListAPITokensResponse
+		if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(list.Tokens) != 2 || list.Tokens[0].RevokedAt == "" || list.Tokens[1].LastUsedAt == "" {
+			t.Errorf("ListAPITokens() = %+v, want first revoked and second used", list.Tokens)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/api/texts", nil)
+		req.Header.Set("Authorization", "Bearer "+readOnly.Token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("revoked token status = %v, want %v", w.Code, http.StatusUnauthorized)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"typeten/internal/domain"
+	"typeten/internal/usecases"
+)
+
+// currentUser resolves the user making the request: the user injected into the
+// request context by RequireLogin, or the default user when one is configured.
+func (h *Handlers) currentUser(r *http.Request) (domain.UserID, bool) {
+	if userID, ok := UserIDFromContext(r.Context()); ok {
+		return userID, true
+	}
+	if h.defaultUserID != "" {
+		return h.defaultUserID, true
+	}
+	return "", false
+}
+
+// authenticate resolves the user from the session cookie, falling back to the default user.
+func (h *Handlers) authenticate(r *http.Request) (domain.UserID, bool) {
+	if userID, ok := h.cookieSessions.Read(r); ok {
+		return userID, true
+	}
+	if h.defaultUserID != "" {
+		return h.defaultUserID, true
+	}
+	return "", false
+}
+
+// Register handles POST /api/auth/register
+func (h *Handlers) Register(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+
+	output, err := h.registerUserUseCase.Execute(r.Context(), usecases.RegisterUserInput{
+		Email:    req.Email,
+		Username: req.Username,
+		Password: req.Password,
+	})
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	h.cookieSessions.Issue(w, r, output.User.ID)
+
+	respondJSON(w, http.StatusCreated, userToResponse(output.User))
+}
+
+// Login handles POST /api/auth/login
+func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+
+	output, err := h.loginUseCase.Execute(r.Context(), usecases.LoginInput{
+		Email:    req.Email,
+		Password: req.Password,
+	})
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	h.cookieSessions.Issue(w, r, output.User.ID)
+
+	respondJSON(w, http.StatusOK, userToResponse(output.User))
+}
+
+// Logout handles POST /api/auth/logout
+func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	h.cookieSessions.Clear(w, r)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+	"typeten/internal/usecases"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHandlers_Register(t *testing.T) {
+	handlers := setupTestHandlers(t)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCookie bool
+	}{
+		{
+			name:       "valid registration",
+			body:       `{"email":"new@example.com","username":"newuser","password":"correct horse"}`,
+			wantStatus: http.StatusCreated,
+			wantCookie: true,
+		},
+		{
+			name:       "short password",
+			body:       `{"email":"short@example.com","username":"short","password":"short"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "duplicate email",
+			body:       `{"email":"test@example.com","username":"dup","password":"correct horse"}`,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "invalid JSON",
+			body:       `invalid json`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/register", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handlers.Register(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Register() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if gotCookie := len(w.Result().Cookies()) > 0; gotCookie != tt.wantCookie {
+				t.Errorf("Register() sets cookie = %v, want %v", gotCookie, tt.wantCookie)
+			}
+		})
+	}
+}
+
+func TestHandlers_LoginResolvesUserPerRequest(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := handlers.RequireLogin(NewRouter(handlers))
+
+	register := httptest.NewRequest(http.MethodPost, "/api/auth/register",
+		strings.NewReader(`{"email":"alice@example.com","username":"alice","password":"correct horse"}`))
+	router.ServeHTTP(httptest.NewRecorder(), register)
+
+	t.Run("wrong password", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/login",
+			strings.NewReader(`{"email":"alice@example.com","password":"wrong horse"}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Login() status = %v, want %v", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login",
+		strings.NewReader(`{"email":"alice@example.com","password":"correct horse"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Login() status = %v, want %v", w.Code, http.StatusOK)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) == 0 || !cookies[0].HttpOnly {
+		t.Fatalf("Login() cookies = %v, want an HttpOnly session cookie", cookies)
+	}
+
+	// A text created with the login cookie belongs to alice, not the default user.
+	create := httptest.NewRequest(http.MethodPost, "/api/texts",
+		strings.NewReader(`{"title":"Alice's text","content":"line1"}`))
+	create.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, create)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("CreateText() status = %v, want %v", w.Code, http.StatusCreated)
+	}
+
+	listTexts := func(withCookie bool) ListTextsResponse {
+		req := httptest.NewRequest(http.MethodGet, "/api/texts", nil)
+		if withCookie {
+			req.AddCookie(cookies[0])
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var resp ListTextsResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+	if got := listTexts(true); len(got.Texts) != 1 {
+		t.Errorf("ListTexts() as alice length = %v, want 1", len(got.Texts))
+	}
+	if got := listTexts(false); len(got.Texts) != 0 {
+		t.Errorf("ListTexts() as default user length = %v, want 0", len(got.Texts))
+	}
+}
+
+func TestHandlers_LoginHTML(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	form := url.Values{"email": {"bob@example.com"}, "username": {"bob"}, "password": {"correct horse"}}
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("RegisterHTML() status = %v, want %v", w.Code, http.StatusSeeOther)
+	}
+
+	form = url.Values{"email": {"bob@example.com"}, "password": {"wrong horse"}}
+	req = httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("LoginHTML() wrong password status = %v, want %v", w.Code, http.StatusUnauthorized)
+	}
+	if !strings.Contains(w.Body.String(), "Invalid email or password") {
+		t.Error("LoginHTML() did not render error message")
+	}
+
+	// A storage failure is a server error, not a failed login.
+	handlers.loginUseCase = usecases.NewLoginUseCase(brokenUserRepository{}, usecases.NewBcryptPasswordHasher(bcrypt.MinCost))
+	form = url.Values{"email": {"bob@example.com"}, "password": {"correct horse"}}
+	req = httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("LoginHTML() storage failure status = %v, want %v", w.Code, http.StatusInternalServerError)
+	}
+	if strings.Contains(w.Body.String(), "Invalid email or password") {
+		t.Error("LoginHTML() storage failure rendered the wrong-password message")
+	}
+}
+
+// brokenUserRepository fails every read, as a database that went away.
+type brokenUserRepository struct {
+	repository.UserRepository
+}
+
+func (brokenUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return nil, errors.New("database is locked")
+}
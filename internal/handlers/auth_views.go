@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+	"typeten/internal/domain"
+	"typeten/internal/usecases"
+)
+
+var (
+	loginTpl    = template.Must(template.New("login").Parse(loginHTML))
+	registerTpl = template.Must(template.New("register").Parse(registerHTML))
+)
+
+type authViewModel struct {
+	Error    string
+	Email    string
+	Username string
+	Next     string
+}
+
+// LoginPage renders the login form.
+func (h *Handlers) LoginPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	renderAuthPage(w, loginTpl, http.StatusOK, authViewModel{Next: r.URL.Query().Get("next")})
+}
+
+// LoginHTML handles login form submission and redirects to the index on success.
+func (h *Handlers) LoginHTML(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	email := r.FormValue("email")
+	out, err := h.loginUseCase.Execute(r.Context(), usecases.LoginInput{
+		Email:    email,
+		Password: r.FormValue("password"),
+	})
+	if errors.Is(err, domain.ErrInvalidCredentials) {
+		renderAuthPage(w, loginTpl, http.StatusUnauthorized, authViewModel{
+			Error: "Invalid email or password",
+			Email: email,
+			Next:  r.FormValue("next"),
+		})
+		return
+	}
+	if err != nil {
+		respondPageError(w, err)
+		return
+	}
+
+	h.cookieSessions.Issue(w, r, out.User.ID)
+	http.Redirect(w, r, safeRedirectPath(r.FormValue("next")), http.StatusSeeOther)
+}
+
+// RegisterPage renders the sign-up form.
+func (h *Handlers) RegisterPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	renderAuthPage(w, registerTpl, http.StatusOK, authViewModel{})
+}
+
+// RegisterHTML handles sign-up form submission, logs the new user in and redirects to the index.
+func (h *Handlers) RegisterHTML(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	email := r.FormValue("email")
+	username := r.FormValue("username")
+	out, err := h.registerUserUseCase.Execute(r.Context(), usecases.RegisterUserInput{
+		Email:    email,
+		Username: username,
+		Password: r.FormValue("password"),
+	})
+	if err != nil {
+		apiErr := translateError(err)
+		renderAuthPage(w, registerTpl, apiErr.Status, authViewModel{
+			Error:    apiErr.summary(),
+			Email:    email,
+			Username: username,
+		})
+		return
+	}
+
+	h.cookieSessions.Issue(w, r, out.User.ID)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// LogoutHTML handles the logout form: it clears the session cookie and redirects to the login page.
+func (h *Handlers) LogoutHTML(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.cookieSessions.Clear(w, r)
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+// safeRedirectPath returns next if it is a local absolute path, or "/" otherwise,
+// so the login form cannot be used as an open redirect.
+func safeRedirectPath(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+	return next
+}
+
+func renderAuthPage(w http.ResponseWriter, tpl *template.Template, status int, vm authViewModel) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := tpl.Execute(w, vm); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
+const authStyles = `
+    body {
+      font-family: system-ui, -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif;
+      margin: 0;
+      padding: 0;
+      background: #0f172a;
+      color: #e5e7eb;
+    }
+    main {
+      max-width: 420px;
+      margin: 4rem auto;
+      padding: 0 1.5rem;
+    }
+    .card {
+      background: #020617;
+      border-radius: 0.75rem;
+      border: 1px solid #1f2937;
+      padding: 1.5rem 1.75rem;
+      box-shadow: 0 18px 40px rgba(15, 23, 42, 0.6);
+    }
+    h1 {
+      margin: 0 0 1rem;
+      font-size: 1.3rem;
+    }
+    label {
+      display: block;
+      font-size: 0.85rem;
+      color: #9ca3af;
+      margin-bottom: 0.25rem;
+    }
+    input {
+      width: 100%;
+      box-sizing: border-box;
+      border-radius: 0.5rem;
+      border: 1px solid #1f2937;
+      background: #020617;
+      color: #e5e7eb;
+      padding: 0.6rem 0.75rem;
+      font-size: 0.9rem;
+      margin-bottom: 0.75rem;
+    }
+    input:focus {
+      outline: none;
+      border-color: #4f46e5;
+      box-shadow: 0 0 0 1px #4f46e5;
+    }
+    button {
+      border: none;
+      border-radius: 999px;
+      padding: 0.55rem 1.2rem;
+      background: linear-gradient(135deg, #4f46e5, #7c3aed);
+      color: white;
+      font-size: 0.9rem;
+      font-weight: 500;
+      cursor: pointer;
+      margin-top: 0.25rem;
+    }
+    button:hover {
+      filter: brightness(1.1);
+    }
+    .error {
+      background: #450a0a;
+      border: 1px solid #7f1d1d;
+      color: #fecaca;
+      border-radius: 0.5rem;
+      padding: 0.5rem 0.75rem;
+      font-size: 0.85rem;
+      margin-bottom: 0.75rem;
+    }
+    .alt {
+      margin-top: 1rem;
+      font-size: 0.85rem;
+      color: #9ca3af;
+    }
+    .alt a {
+      color: #a5b4fc;
+    }
+`
+
+const loginHTML = `<!doctype html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Log in · TypeTen</title>
+  <style>` + authStyles + `</style>
+</head>
+<body>
+  <main>
+    <section class="card">
+      <h1>Log in to TypeTen</h1>
+      {{if .Error}}<div class="error">{{.Error}}</div>{{end}}
+      <form method="post" action="/login">
+        <input type="hidden" name="next" value="{{.Next}}">
+        <label for="email">Email</label>
+        <input id="email" name="email" type="email" required autocomplete="email" value="{{.Email}}">
+        <label for="password">Password</label>
+        <input id="password" name="password" type="password" required autocomplete="current-password">
+        <button type="submit">Log in</button>
+      </form>
+      <p class="alt">No account yet? <a href="/register">Sign up</a></p>
+    </section>
+  </main>
+</body>
+</html>`
+
+const registerHTML = `<!doctype html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Sign up · TypeTen</title>
+  <style>` + authStyles + `</style>
+</head>
+<body>
+  <main>
+    <section class="card">
+      <h1>Create an account</h1>
+      {{if .Error}}<div class="error">{{.Error}}</div>{{end}}
+      <form method="post" action="/register">
+        <label for="email">Email</label>
+        <input id="email" name="email" type="email" required autocomplete="email" value="{{.Email}}">
+        <label for="username">Username</label>
+        <input id="username" name="username" type="text" required autocomplete="username" value="{{.Username}}">
+        <label for="password">Password</label>
+        <input id="password" name="password" type="password" required minlength="8" autocomplete="new-password">
+        <button type="submit">Sign up</button>
+      </form>
+      <p class="alt">Already registered? <a href="/login">Log in</a></p>
+    </section>
+  </main>
+</body>
+</html>`
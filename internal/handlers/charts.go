@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"strings"
+)
+
+// Chart geometry in SVG user units; the SVG scales to its container.
+const (
+	chartWidth   = 640
+	chartHeight  = 220
+	chartPadLeft = 44
+	chartPadTop  = 12
+	chartPadEnd  = 12
+	chartPadBot  = 26
+)
+
+// lineChart describes a line chart rendered on the server as inline SVG.
+// Labels name the x positions; a NaN value leaves a gap in its series.
+// YMax fixes the top of the y axis; zero picks a round value above the data.
+type lineChart struct {
+	Title  string
+	Labels []string
+	Series []chartSeries
+	YMax   float64
+}
+
+type chartSeries struct {
+	Name   string
+	Color  string
+	Values []float64
+}
+
+// SVG renders the chart. All text is escaped, so the result is safe to embed.
+func (c lineChart) SVG() template.HTML {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg class="chart" viewBox="0 0 %d %d" role="img" aria-label="%s" xmlns="http://www.w3.org/2000/svg">`,
+		chartWidth, chartHeight, html.EscapeString(c.Title))
+
+	plotW := float64(chartWidth - chartPadLeft - chartPadEnd)
+	plotH := float64(chartHeight - chartPadTop - chartPadBot)
+	yMax := c.YMax
+	if yMax <= 0 {
+		yMax = niceCeil(c.maxValue())
+	}
+	x := func(i int) float64 {
+		if len(c.Labels) <= 1 {
+			return chartPadLeft + plotW/2
+		}
+		return chartPadLeft + plotW*float64(i)/float64(len(c.Labels)-1)
+	}
+	y := func(v float64) float64 {
+		return chartPadTop + plotH*(1-v/yMax)
+	}
+
+	// Horizontal grid with y labels
+	for _, v := range []float64{0, yMax / 2, yMax} {
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#1f2937"/>`,
+			chartPadLeft, y(v), chartWidth-chartPadEnd, y(v))
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" fill="#9ca3af" font-size="10" text-anchor="end">%s</text>`,
+			chartPadLeft-6, y(v)+3, formatChartValue(v))
+	}
+
+	// x labels: first, middle and last
+	if n := len(c.Labels); n > 0 {
+		for _, i := range uniqueInts(0, n/2, n-1) {
+			fmt.Fprintf(&b, `<text x="%.1f" y="%d" fill="#9ca3af" font-size="10" text-anchor="middle">%s</text>`,
+				x(i), chartHeight-8, html.EscapeString(c.Labels[i]))
+		}
+	}
+
+	for _, s := range c.Series {
+		color := html.EscapeString(s.Color)
+		var run []string
+		flush := func() {
+			if len(run) > 1 {
+				fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`, color, strings.Join(run, " "))
+			}
+			run = run[:0]
+		}
+		for i, v := range s.Values {
+			if math.IsNaN(v) || i >= len(c.Labels) {
+				flush()
+				continue
+			}
+			run = append(run, fmt.Sprintf("%.1f,%.1f", x(i), y(v)))
+			fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="2.5" fill="%s"><title>%s %s: %s</title></circle>`,
+				x(i), y(v), color, html.EscapeString(s.Name), html.EscapeString(c.Labels[i]), formatChartValue(v))
+		}
+		flush()
+	}
+
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+func (c lineChart) maxValue() float64 {
+	max := 0.0
+	for _, s := range c.Series {
+		for _, v := range s.Values {
+			if !math.IsNaN(v) && v > max {
+				max = v
+			}
+		}
+	}
+	return max
+}
+
+// niceCeil rounds v up to 1, 2 or 5 times a power of ten; it returns 1 for v <= 0.
+func niceCeil(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, step := range []float64{1, 2, 5, 10} {
+		if v <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+func formatChartValue(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+// uniqueInts returns the values with adjacent duplicates removed.
+func uniqueInts(values ...int) []int {
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		if len(result) == 0 || result[len(result)-1] != v {
+			result = append(result, v)
+		}
+	}
+	return result
+}
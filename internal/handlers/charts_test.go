@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNiceCeil(t *testing.T) {
+	tests := []struct {
+		v    float64
+		want float64
+	}{
+		{v: 0, want: 1},
+		{v: 0.7, want: 1},
+		{v: 7, want: 10},
+		{v: 12, want: 20},
+		{v: 37, want: 50},
+		{v: 64, want: 100},
+		{v: 100, want: 100},
+		{v: 180, want: 200},
+	}
+
+	for _, tt := range tests {
+		if got := niceCeil(tt.v); got != tt.want {
+			t.Errorf("niceCeil(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestLineChart_SVG(t *testing.T) {
+	chart := lineChart{
+		Title:  "WPM <script>",
+		Labels: []string{"Mar 1", "Mar 2", "Mar 3", "Mar 4"},
+		Series: []chartSeries{{Name: "Average", Color: "#818cf8", Values: []float64{30, math.NaN(), 40, 45}}},
+	}
+
+	svg := string(chart.SVG())
+
+	if strings.Contains(svg, "<script>") {
+		t.Errorf("SVG() does not escape the title: %s", svg)
+	}
+	// The NaN splits the series into a single point and a two-point line.
+	if got := strings.Count(svg, "<polyline"); got != 1 {
+		t.Errorf("SVG() rendered %d polylines, want 1", got)
+	}
+	if got := strings.Count(svg, "<circle"); got != 3 {
+		t.Errorf("SVG() rendered %d points, want 3", got)
+	}
+}
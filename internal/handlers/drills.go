@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"typeten/internal/usecases"
+)
+
+// CreateDrill handles POST /api/drills
+func (h *Handlers) CreateDrill(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	output, err := h.createDrillUseCase.Execute(r.Context(), usecases.CreateDrillInput{UserID: userID})
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	resp := CreateDrillResponse{
+		Text:    textInfoToResponse(output.TextInfo),
+		Session: sessionToResponse(output.Session),
+		Keys:    append([]string{}, output.Keys...),
+		Bigrams: append([]string{}, output.Bigrams...),
+	}
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// CreateDrillHTML handles the drill button on the stats page and redirects to the new session.
+func (h *Handlers) CreateDrillHTML(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	out, err := h.createDrillUseCase.Execute(r.Context(), usecases.CreateDrillInput{UserID: userID})
+	if err != nil {
+		respondPageError(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/sessions/"+string(out.Session.ID), http.StatusSeeOther)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"typeten/internal/usecases"
+)
+
+func TestHandlers_CreateDrill(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/drills", nil))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("CreateDrill() without typing status = %v, want %v", w.Code, http.StatusConflict)
+	}
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Drill Source",
+		Content: "quick zebra\nplain text",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+		UserID: handlers.defaultUserID,
+		TextID: textOutput.TextInfo.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+	if _, err := handlers.recordProgressUseCase.Execute(ctx, usecases.RecordProgressInput{
+		UserID:    handlers.defaultUserID,
+		SessionID: string(sessionOutput.Session.ID),
+		Typed:     "quick xebra",
+		Elapsed:   time.Second,
+	}); err != nil {
+		t.Fatalf("Failed to record progress: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/drills", nil))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("CreateDrill() status = %v, want %v", w.Code, http.StatusCreated)
+	}
+	var resp CreateDrillResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Keys) != 1 || resp.Keys[0] != "z" {
+		t.Errorf("CreateDrill() Keys = %v, want [z]", resp.Keys)
+	}
+	if resp.Session.TextID != resp.Text.ID || resp.Text.TotalLines != 2 {
+		t.Errorf("CreateDrill() = %+v, want a session on a two-line drill", resp)
+	}
+
+	t.Run("stats page button", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats", nil))
+		if !strings.Contains(w.Body.String(), `action="/drills"`) {
+			t.Errorf("StatsPage() body does not offer a drill")
+		}
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/drills", nil))
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("CreateDrillHTML() status = %v, want %v", w.Code, http.StatusSeeOther)
+		}
+		if location := w.Header().Get("Location"); !strings.HasPrefix(location, "/sessions/") {
+			t.Errorf("CreateDrillHTML() Location = %q, want a session page", location)
+		}
+	})
+}
@@ -1,26 +1,44 @@
 package handlers
 
-import "typeten/internal/domain"
+import (
+	"time"
+
+	"typeten/internal/domain"
+)
 
 // CreateTextRequest represents the HTTP request for creating a text.
+// Normalize names the normalization steps to apply; omitted applies the default steps.
+// WrapWidth and WrapSentences break long paragraphs into lines; zero and false keep them whole.
 type CreateTextRequest struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title         string   `json:"title"`
+	Content       string   `json:"content"`
+	Normalize     []string `json:"normalize"`
+	WrapWidth     int      `json:"wrap_width"`
+	WrapSentences bool     `json:"wrap_sentences"`
 }
 
 // CreateTextResponse represents the HTTP response for creating a text.
 type CreateTextResponse struct {
-	ID            string `json:"id"`
-	Title         string `json:"title"`
-	TotalLines    int    `json:"total_lines"`
-	FragmentSize  int    `json:"fragment_size"`
-	FragmentCount int    `json:"fragment_count"`
-	CreatedAt     string `json:"created_at"`
+	ID            string            `json:"id"`
+	Title         string            `json:"title"`
+	Author        string            `json:"author,omitempty"`
+	TotalLines    int               `json:"total_lines"`
+	FragmentSize  int               `json:"fragment_size"`
+	FragmentCount int               `json:"fragment_count"`
+	Chapters      []ChapterResponse `json:"chapters,omitempty"`
+	Normalization []string          `json:"normalization"`
+	WrapWidth     int               `json:"wrap_width"`
+	WrapSentences bool              `json:"wrap_sentences"`
+	Encoding      string            `json:"encoding,omitempty"` // source encoding of an uploaded file
+	CreatedAt     string            `json:"created_at"`
 }
 
 // CreateSessionRequest represents the HTTP request for creating a session.
+// Mode is full_text (default), minutes, words or lines; ModeLimit is the limit for the last three.
 type CreateSessionRequest struct {
-	TextID string `json:"text_id"`
+	TextID    string `json:"text_id"`
+	Mode      string `json:"mode"`
+	ModeLimit int    `json:"mode_limit"`
 }
 
 // CreateSessionResponse represents the HTTP response for creating a session.
@@ -28,29 +46,75 @@ type CreateSessionResponse struct {
 	ID                   string  `json:"id"`
 	UserID               string  `json:"user_id"`
 	TextID               string  `json:"text_id"`
+	TotalLines           int     `json:"total_lines"`
+	FragmentSize         int     `json:"fragment_size"`
 	CurrentFragmentIdx   int     `json:"current_fragment_idx"`
 	CurrentLineIdx       int     `json:"current_line_idx"`
+	CurrentLine          int     `json:"current_line"`
 	CompletedLines       int     `json:"completed_lines"`
 	TotalAccuracyPercent float64 `json:"total_accuracy_percent"`
 	AverageWPM           float64 `json:"average_wpm"`
+	Mode                 string  `json:"mode"`
+	ModeLimit            int     `json:"mode_limit,omitempty"`
+	CompletedWords       int     `json:"completed_words"`
+	PracticeMs           int64   `json:"practice_ms"`
+	RemainingMs          int64   `json:"remaining_ms,omitempty"`
+	Status               string  `json:"status"`
+	ActiveMs             int64   `json:"active_ms"`
 	IsCompleted          bool    `json:"is_completed"`
 	CreatedAt            string  `json:"created_at"`
 	UpdatedAt            string  `json:"updated_at"`
 }
 
 // RecordProgressRequest represents the HTTP request for recording progress.
+// Typed is the text typed for the current line; the server scores it.
 type RecordProgressRequest struct {
-	AccuracyPercent float64 `json:"accuracy_percent"`
-	WPM             float64 `json:"wpm"`
+	Typed     string `json:"typed"`
+	ElapsedMs int64  `json:"elapsed_ms"`
 }
 
 // RecordProgressResponse represents the HTTP response for recording progress.
 type RecordProgressResponse struct {
-	ID                   string  `json:"id"`
-	CompletedLines       int     `json:"completed_lines"`
-	TotalAccuracyPercent float64 `json:"total_accuracy_percent"`
-	AverageWPM           float64 `json:"average_wpm"`
-	IsCompleted          bool    `json:"is_completed"`
+	ID                   string            `json:"id"`
+	CurrentFragmentIdx   int               `json:"current_fragment_idx"`
+	CurrentLineIdx       int               `json:"current_line_idx"`
+	CurrentLine          int               `json:"current_line"`
+	CompletedLines       int               `json:"completed_lines"`
+	TotalAccuracyPercent float64           `json:"total_accuracy_percent"`
+	AverageWPM           float64           `json:"average_wpm"`
+	CompletedWords       int               `json:"completed_words"`
+	RemainingMs          int64             `json:"remaining_ms,omitempty"`
+	Status               string            `json:"status"`
+	IsCompleted          bool              `json:"is_completed"`
+	Line                 LineScoreResponse `json:"line"`
+}
+
+// LineScoreResponse represents the server-computed score of a single line.
+type LineScoreResponse struct {
+	AccuracyPercent float64 `json:"accuracy_percent"`
+	GrossWPM        float64 `json:"gross_wpm"`
+	NetWPM          float64 `json:"net_wpm"`
+	Errors          int     `json:"errors"`
+}
+
+// SessionLinesResponse represents the HTTP response for listing a session's line results.
+type SessionLinesResponse struct {
+	SessionID string               `json:"session_id"`
+	Lines     []LineResultResponse `json:"lines"`
+}
+
+// LineResultResponse represents a stored line result in responses.
+// Line is the zero-based index of the line in the whole text.
+type LineResultResponse struct {
+	FragmentIdx     int     `json:"fragment_idx"`
+	LineIdx         int     `json:"line_idx"`
+	Line            int     `json:"line"`
+	Typed           string  `json:"typed"`
+	AccuracyPercent float64 `json:"accuracy_percent"`
+	WPM             float64 `json:"wpm"`
+	DurationMs      int64   `json:"duration_ms"`
+	Errors          int     `json:"errors"`
+	RecordedAt      string  `json:"recorded_at"`
 }
 
 // GetSessionResponse represents the HTTP response for getting a session.
@@ -58,16 +122,82 @@ type GetSessionResponse struct {
 	ID                   string  `json:"id"`
 	UserID               string  `json:"user_id"`
 	TextID               string  `json:"text_id"`
+	TotalLines           int     `json:"total_lines"`
+	FragmentSize         int     `json:"fragment_size"`
 	CurrentFragmentIdx   int     `json:"current_fragment_idx"`
 	CurrentLineIdx       int     `json:"current_line_idx"`
+	CurrentLine          int     `json:"current_line"`
 	CompletedLines       int     `json:"completed_lines"`
 	TotalAccuracyPercent float64 `json:"total_accuracy_percent"`
 	AverageWPM           float64 `json:"average_wpm"`
+	Mode                 string  `json:"mode"`
+	ModeLimit            int     `json:"mode_limit,omitempty"`
+	CompletedWords       int     `json:"completed_words"`
+	PracticeMs           int64   `json:"practice_ms"`
+	RemainingMs          int64   `json:"remaining_ms,omitempty"`
+	Status               string  `json:"status"`
+	ActiveMs             int64   `json:"active_ms"`
 	IsCompleted          bool    `json:"is_completed"`
 	CreatedAt            string  `json:"created_at"`
 	UpdatedAt            string  `json:"updated_at"`
 }
 
+// ListSessionsResponse represents one page of sessions.
+// NextCursor is passed as cursor to fetch the next page; it is omitted on the last page.
+type ListSessionsResponse struct {
+	Sessions   []GetSessionResponse `json:"sessions"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+// ProgressStatsResponse represents the HTTP response for progress statistics.
+type ProgressStatsResponse struct {
+	Period  string                   `json:"period"`
+	Buckets []ProgressBucketResponse `json:"buckets"`
+	Total   ProgressBucketResponse   `json:"total"`
+}
+
+// ProgressBucketResponse represents the statistics of one day or week.
+// Start is the first day of the bucket (UTC, YYYY-MM-DD).
+type ProgressBucketResponse struct {
+	Start           string  `json:"start"`
+	Sessions        int     `json:"sessions"`
+	Lines           int     `json:"lines"`
+	AverageWPM      float64 `json:"average_wpm"`
+	BestWPM         float64 `json:"best_wpm"`
+	AccuracyPercent float64 `json:"accuracy_percent"`
+	PracticeMinutes float64 `json:"practice_minutes"`
+}
+
+// KeyStatsResponse represents the HTTP response for key statistics.
+type KeyStatsResponse struct {
+	Keys    []KeyStatResponse    `json:"keys"`
+	Bigrams []BigramStatResponse `json:"bigrams"`
+}
+
+// KeyStatResponse represents the error rate of one character.
+type KeyStatResponse struct {
+	Char         string  `json:"char"`
+	Attempts     int     `json:"attempts"`
+	Errors       int     `json:"errors"`
+	ErrorPercent float64 `json:"error_percent"`
+}
+
+// BigramStatResponse represents the average latency of one letter pair.
+type BigramStatResponse struct {
+	Bigram           string  `json:"bigram"`
+	Count            int     `json:"count"`
+	AverageLatencyMs float64 `json:"average_latency_ms"`
+}
+
+// CreateDrillResponse represents the HTTP response for creating a drill.
+// Keys and Bigrams are the weaknesses the drill text was built for.
+type CreateDrillResponse struct {
+	Text    TextInfoResponse   `json:"text"`
+	Session GetSessionResponse `json:"session"`
+	Keys    []string           `json:"keys"`
+	Bigrams []string           `json:"bigrams"`
+}
+
 // ListTextsResponse represents the HTTP response for listing texts.
 type ListTextsResponse struct {
 	Texts []TextInfoResponse `json:"texts"`
@@ -75,12 +205,24 @@ type ListTextsResponse struct {
 
 // TextInfoResponse represents a text info in responses.
 type TextInfoResponse struct {
-	ID            string `json:"id"`
-	Title         string `json:"title"`
-	TotalLines    int    `json:"total_lines"`
-	FragmentSize  int    `json:"fragment_size"`
-	FragmentCount int    `json:"fragment_count"`
-	CreatedAt     string `json:"created_at"`
+	ID            string            `json:"id"`
+	Kind          string            `json:"kind"`
+	Title         string            `json:"title"`
+	Author        string            `json:"author,omitempty"`
+	TotalLines    int               `json:"total_lines"`
+	FragmentSize  int               `json:"fragment_size"`
+	FragmentCount int               `json:"fragment_count"`
+	Chapters      []ChapterResponse `json:"chapters,omitempty"`
+	Normalization []string          `json:"normalization"`
+	WrapWidth     int               `json:"wrap_width"`
+	WrapSentences bool              `json:"wrap_sentences"`
+	CreatedAt     string            `json:"created_at"`
+}
+
+// ChapterResponse represents a chapter of a book; Line is the zero-based index of its first line.
+type ChapterResponse struct {
+	Title string `json:"title"`
+	Line  int    `json:"line"`
 }
 
 // GetTextFragmentsResponse represents the HTTP response for getting fragments.
@@ -95,35 +237,197 @@ type FragmentResponse struct {
 	Lines       []string `json:"lines"`
 }
 
-// ErrorResponse represents an error response.
+// RegisterRequest represents the HTTP request for registering a user.
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoginRequest represents the HTTP request for logging in.
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// UserResponse represents a user in responses. The password hash is never exposed.
+type UserResponse struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Username  string `json:"username"`
+	CreatedAt string `json:"created_at"`
+}
+
+// CreateAPITokenRequest represents the HTTP request for creating an API token.
+type CreateAPITokenRequest struct {
+	Name  string `json:"name"`
+	Scope string `json:"scope"`
+}
+
+// APITokenResponse represents an API token in responses. The secret is never included.
+type APITokenResponse struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Scope      string `json:"scope"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	RevokedAt  string `json:"revoked_at,omitempty"`
+}
+
+// CreateAPITokenResponse represents the HTTP response for creating an API token.
+// Token is the bearer secret; it is shown only once.
+type CreateAPITokenResponse struct {
+	APITokenResponse
+	Token string `json:"token"`
+}
+
+// ListAPITokensResponse represents the HTTP response for listing API tokens.
+type ListAPITokensResponse struct {
+	Tokens []APITokenResponse `json:"tokens"`
+}
+
+// RecordKeystrokesRequest represents the HTTP request for recording the keystrokes of one line.
+// LineIdx is the zero-based line index into the whole text; it is required.
+type RecordKeystrokesRequest struct {
+	LineIdx *int                    `json:"line_idx"`
+	Events  []KeystrokeEventRequest `json:"events"`
+}
+
+// KeystrokeEventRequest represents one captured key press.
+type KeystrokeEventRequest struct {
+	Key        string `json:"key"`
+	Expected   string `json:"expected"`
+	OffsetMs   int64  `json:"offset_ms"`
+	Correction bool   `json:"correction"`
+}
+
+// RecordKeystrokesResponse represents the HTTP response for recording keystrokes.
+type RecordKeystrokesResponse struct {
+	SessionID string `json:"session_id"`
+	LineIdx   int    `json:"line_idx"`
+	Recorded  int    `json:"recorded"`
+}
+
+// ErrorResponse represents an error response. Code is a stable machine-readable
+// error code; Details lists invalid input fields for validation errors.
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error   string               `json:"error"`
+	Code    string               `json:"code"`
+	Details []FieldErrorResponse `json:"details,omitempty"`
+}
+
+// FieldErrorResponse describes one invalid input field.
+type FieldErrorResponse struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // Helper functions to convert domain models to DTOs
 func textInfoToResponse(info *domain.TextInfo) TextInfoResponse {
 	return TextInfoResponse{
 		ID:            string(info.ID),
+		Kind:          string(textKind(info)),
 		Title:         info.Title,
+		Author:        info.Author,
 		TotalLines:    info.TotalLines,
 		FragmentSize:  info.FragmentSize,
 		FragmentCount: info.FragmentCount,
+		Chapters:      chaptersToResponse(info.Chapters),
+		Normalization: normalizationToResponse(info.Normalization),
+		WrapWidth:     info.Wrap.Width,
+		WrapSentences: info.Wrap.Sentences,
 		CreatedAt:     info.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
+// textKind returns the kind of info; texts stored before kinds existed are the user's.
+func textKind(info *domain.TextInfo) domain.TextKind {
+	if info.Kind == "" {
+		return domain.TextKindUser
+	}
+	return info.Kind
+}
+
+func chaptersToResponse(chapters []domain.TextChapter) []ChapterResponse {
+	if len(chapters) == 0 {
+		return nil
+	}
+	resp := make([]ChapterResponse, len(chapters))
+	for i, c := range chapters {
+		resp[i] = ChapterResponse{Title: c.Title, Line: c.Line}
+	}
+	return resp
+}
+
 func sessionToResponse(session *domain.Session) GetSessionResponse {
 	return GetSessionResponse{
 		ID:                   string(session.ID),
 		UserID:               string(session.UserID),
 		TextID:               string(session.TextID),
+		TotalLines:           session.TotalLines,
+		FragmentSize:         session.FragmentSize,
 		CurrentFragmentIdx:   session.CurrentFragmentIdx,
 		CurrentLineIdx:       session.CurrentLineIdx,
+		CurrentLine:          session.CurrentLine(),
 		CompletedLines:       session.CompletedLines,
 		TotalAccuracyPercent: session.TotalAccuracyPercent,
 		AverageWPM:           session.AverageWPM,
-		IsCompleted:          session.IsCompleted,
+		Mode:                 sessionModeKind(session.Mode),
+		ModeLimit:            session.Mode.Limit,
+		CompletedWords:       session.CompletedWords,
+		PracticeMs:           session.PracticeTime.Milliseconds(),
+		RemainingMs:          session.RemainingTime().Milliseconds(),
+		Status:               string(session.Status),
+		ActiveMs:             session.ActiveTime(time.Now()).Milliseconds(),
+		IsCompleted:          session.IsCompleted(),
 		CreatedAt:            session.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:            session.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
+
+// sessionModeKind returns the mode name sent to clients; the zero mode is full text.
+func sessionModeKind(mode domain.SessionMode) string {
+	if mode.IsFullText() {
+		return string(domain.SessionModeFullText)
+	}
+	return string(mode.Kind)
+}
+
+func lineResultToResponse(line *domain.LineResult, fragmentSize int) LineResultResponse {
+	return LineResultResponse{
+		FragmentIdx:     line.FragmentIdx,
+		LineIdx:         line.LineIdx,
+		Line:            line.TextLine(fragmentSize),
+		Typed:           line.Typed,
+		AccuracyPercent: line.AccuracyPercent,
+		WPM:             line.WPM,
+		DurationMs:      line.Duration.Milliseconds(),
+		Errors:          line.Errors,
+		RecordedAt:      line.RecordedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func userToResponse(user *domain.User) UserResponse {
+	return UserResponse{
+		ID:        string(user.ID),
+		Email:     user.Email,
+		Username:  user.Username,
+		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func apiTokenToResponse(token *domain.APIToken) APITokenResponse {
+	resp := APITokenResponse{
+		ID:        string(token.ID),
+		Name:      token.Name,
+		Scope:     string(token.Scope),
+		CreatedAt: token.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if token.LastUsedAt != nil {
+		resp.LastUsedAt = token.LastUsedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if token.RevokedAt != nil {
+		resp.RevokedAt = token.RevokedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return resp
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// Error codes returned in ErrorResponse.Code. Clients should branch on these
+// rather than on the human-readable message.
+const (
+	codeInvalidRequest   = "invalid_request"
+	codeValidationFailed = "validation_failed"
+	codeUnauthorized     = "unauthorized"
+	codeForbidden        = "forbidden"
+	codeNotFound         = "not_found"
+	codeConflict         = "conflict"
+	codeMethodNotAllowed = "method_not_allowed"
+	codeInternal         = "internal_error"
+)
+
+// apiError is the HTTP form of an error returned by a use case.
+type apiError struct {
+	Status  int
+	Code    string
+	Message string
+	Details []FieldErrorResponse
+}
+
+// validationMessages are the client-facing messages for domain validation errors
+// that do not carry field details.
+var validationMessages = []struct {
+	err     error
+	message string
+}{
+	{domain.ErrInvalidUser, "Invalid user"},
+	{domain.ErrInvalidTextInfo, "Invalid text"},
+	{domain.ErrInvalidFragment, "Invalid text fragment"},
+	{domain.ErrInvalidSession, "Invalid session"},
+	{domain.ErrWeakPassword, "Password does not meet requirements"},
+	{domain.ErrInvalidAPIToken, "Invalid API token"},
+	{domain.ErrInvalidKeystroke, "Invalid keystroke event"},
+	{domain.ErrInvalidQuery, "Invalid query"},
+}
+
+// translateError maps an error from a use case to an HTTP status, error code and
+// client-safe message, using errors.Is on the domain and repository sentinel errors.
+// A domain.FieldError adds field details. Unrecognised errors become a 500 whose
+// message does not expose internals; the original error is logged.
+func translateError(err error) apiError {
+	var fieldErr *domain.FieldError
+	if errors.As(err, &fieldErr) {
+		return apiError{
+			Status:  http.StatusUnprocessableEntity,
+			Code:    codeValidationFailed,
+			Message: "Validation failed",
+			Details: []FieldErrorResponse{{Field: fieldErr.Field, Message: fieldErr.Message}},
+		}
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return apiError{Status: http.StatusUnauthorized, Code: codeUnauthorized, Message: "Invalid email or password"}
+	case errors.Is(err, domain.ErrForbidden):
+		return apiError{Status: http.StatusForbidden, Code: codeForbidden, Message: "Resource belongs to another user"}
+	case errors.Is(err, repository.ErrNotFound):
+		return apiError{Status: http.StatusNotFound, Code: codeNotFound, Message: "Resource not found"}
+	case errors.Is(err, domain.ErrEmailTaken):
+		return apiError{Status: http.StatusConflict, Code: codeConflict, Message: "Email is already registered"}
+	case errors.Is(err, repository.ErrAlreadyExists):
+		return apiError{Status: http.StatusConflict, Code: codeConflict, Message: "Resource already exists"}
+	case errors.Is(err, domain.ErrInvalidSessionOp):
+		return apiError{Status: http.StatusConflict, Code: codeConflict, Message: "Session does not accept this operation"}
+	case errors.Is(err, domain.ErrNotEnoughData):
+		return apiError{Status: http.StatusConflict, Code: codeConflict, Message: "Not enough typing data yet"}
+	case errors.Is(err, domain.ErrInvalidID):
+		return apiError{Status: http.StatusBadRequest, Code: codeInvalidRequest, Message: "Invalid ID"}
+	}
+
+	for _, v := range validationMessages {
+		if errors.Is(err, v.err) {
+			return apiError{Status: http.StatusUnprocessableEntity, Code: codeValidationFailed, Message: v.message}
+		}
+	}
+
+	log.Printf("Unhandled error: %v", err)
+	return apiError{Status: http.StatusInternalServerError, Code: codeInternal, Message: "Internal server error"}
+}
+
+// respondUseCaseError writes err as a JSON ErrorResponse using translateError.
+func respondUseCaseError(w http.ResponseWriter, err error) {
+	apiErr := translateError(err)
+	respondJSON(w, apiErr.Status, ErrorResponse{
+		Error:   apiErr.Message,
+		Code:    apiErr.Code,
+		Details: apiErr.Details,
+	})
+}
+
+// respondPageError writes err as a plain-text error for HTML form handlers.
+func respondPageError(w http.ResponseWriter, err error) {
+	apiErr := translateError(err)
+	http.Error(w, apiErr.summary(), apiErr.Status)
+}
+
+// summary returns the message with any field details appended, for display in pages.
+func (e apiError) summary() string {
+	message := e.Message
+	for _, d := range e.Details {
+		message += ": " + d.Field + " " + d.Message
+	}
+	return message
+}
+
+// errorCode returns the default error code for an HTTP status.
+func errorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return codeInvalidRequest
+	case http.StatusUnauthorized:
+		return codeUnauthorized
+	case http.StatusForbidden:
+		return codeForbidden
+	case http.StatusNotFound:
+		return codeNotFound
+	case http.StatusMethodNotAllowed:
+		return codeMethodNotAllowed
+	case http.StatusConflict:
+		return codeConflict
+	case http.StatusUnprocessableEntity:
+		return codeValidationFailed
+	default:
+		return codeInternal
+	}
+}
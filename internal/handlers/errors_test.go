@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantField  string
+	}{
+		{
+			name:       "field error",
+			err:        fmt.Errorf("create: %w", domain.NewFieldError(domain.ErrInvalidTextInfo, "title", "must not be empty")),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeValidationFailed,
+			wantField:  "title",
+		},
+		{
+			name:       "validation error without field",
+			err:        domain.ErrInvalidFragment,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeValidationFailed,
+		},
+		{
+			name:       "invalid ID",
+			err:        domain.ErrInvalidID,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+		},
+		{
+			name:       "not found",
+			err:        fmt.Errorf("session not found: %w", repository.ErrNotFound),
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeNotFound,
+		},
+		{
+			name:       "already exists",
+			err:        fmt.Errorf("failed to create: %w", repository.ErrAlreadyExists),
+			wantStatus: http.StatusConflict,
+			wantCode:   codeConflict,
+		},
+		{
+			name:       "email taken",
+			err:        domain.ErrEmailTaken,
+			wantStatus: http.StatusConflict,
+			wantCode:   codeConflict,
+		},
+		{
+			name:       "completed session",
+			err:        domain.ErrInvalidSessionOp,
+			wantStatus: http.StatusConflict,
+			wantCode:   codeConflict,
+		},
+		{
+			name:       "not enough data",
+			err:        domain.ErrNotEnoughData,
+			wantStatus: http.StatusConflict,
+			wantCode:   codeConflict,
+		},
+		{
+			name:       "forbidden",
+			err:        domain.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+			wantCode:   codeForbidden,
+		},
+		{
+			name:       "unknown error",
+			err:        errors.New("disk on fire"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   codeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := translateError(tt.err)
+			if got.Status != tt.wantStatus || got.Code != tt.wantCode {
+				t.Errorf("translateError() = %v %v, want %v %v", got.Status, got.Code, tt.wantStatus, tt.wantCode)
+			}
+			if tt.wantField != "" && (len(got.Details) != 1 || got.Details[0].Field != tt.wantField) {
+				t.Errorf("translateError() Details = %v, want field %v", got.Details, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestRespondUseCaseError_HidesInternals(t *testing.T) {
+	w := httptest.NewRecorder()
+	respondUseCaseError(w, errors.New("failed to insert: disk I/O error at /var/lib/typeten.db"))
+
+	var resp ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Error != "Internal server error" || resp.Code != codeInternal {
+		t.Errorf("respondUseCaseError() body = %+v, want generic internal error", resp)
+	}
+}
@@ -2,10 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 	"typeten/internal/domain"
 	"typeten/internal/usecases"
 )
@@ -13,57 +13,119 @@ import (
 // Handlers holds all HTTP handlers and their dependencies.
 type Handlers struct {
 	createTextUseCase        *usecases.CreateTextUseCase
+	importTextUseCase        *usecases.ImportTextUseCase
 	createSessionUseCase     *usecases.CreateSessionUseCase
 	recordProgressUseCase    *usecases.RecordProgressUseCase
 	getSessionUseCase        *usecases.GetSessionUseCase
 	listTextsUseCase         *usecases.ListTextsUseCase
 	getTextFragmentsUseCase  *usecases.GetTextFragmentsUseCase
-	currentUserID            domain.UserID // MVP: single user, will be replaced with auth
+	registerUserUseCase      *usecases.RegisterUserUseCase
+	loginUseCase             *usecases.LoginUseCase
+	createAPITokenUseCase    *usecases.CreateAPITokenUseCase
+	listAPITokensUseCase     *usecases.ListAPITokensUseCase
+	revokeAPITokenUseCase    *usecases.RevokeAPITokenUseCase
+	authAPITokenUseCase      *usecases.AuthenticateAPITokenUseCase
+	listUnfinishedUseCase    *usecases.ListUnfinishedSessionsUseCase
+	recordKeystrokesUseCase  *usecases.RecordKeystrokesUseCase
+	listLineResultsUseCase   *usecases.ListLineResultsUseCase
+	listSessionsUseCase      *usecases.ListSessionsUseCase
+	progressStatsUseCase     *usecases.GetProgressStatsUseCase
+	keyStatsUseCase          *usecases.GetKeyStatsUseCase
+	createDrillUseCase       *usecases.CreateDrillUseCase
+	changeStatusUseCase      *usecases.ChangeSessionStatusUseCase
+	cookieSessions           *CookieSessions
+	defaultUserID            domain.UserID // used for requests without a login; empty disables the fallback
 }
 
 // NewHandlers creates a new Handlers instance.
 func NewHandlers(
 	createTextUseCase *usecases.CreateTextUseCase,
+	importTextUseCase *usecases.ImportTextUseCase,
 	createSessionUseCase *usecases.CreateSessionUseCase,
 	recordProgressUseCase *usecases.RecordProgressUseCase,
 	getSessionUseCase *usecases.GetSessionUseCase,
 	listTextsUseCase *usecases.ListTextsUseCase,
 	getTextFragmentsUseCase *usecases.GetTextFragmentsUseCase,
-	currentUserID domain.UserID,
+	registerUserUseCase *usecases.RegisterUserUseCase,
+	loginUseCase *usecases.LoginUseCase,
+	createAPITokenUseCase *usecases.CreateAPITokenUseCase,
+	listAPITokensUseCase *usecases.ListAPITokensUseCase,
+	revokeAPITokenUseCase *usecases.RevokeAPITokenUseCase,
+	authAPITokenUseCase *usecases.AuthenticateAPITokenUseCase,
+	listUnfinishedUseCase *usecases.ListUnfinishedSessionsUseCase,
+	recordKeystrokesUseCase *usecases.RecordKeystrokesUseCase,
+	listLineResultsUseCase *usecases.ListLineResultsUseCase,
+	listSessionsUseCase *usecases.ListSessionsUseCase,
+	progressStatsUseCase *usecases.GetProgressStatsUseCase,
+	keyStatsUseCase *usecases.GetKeyStatsUseCase,
+	createDrillUseCase *usecases.CreateDrillUseCase,
+	changeStatusUseCase *usecases.ChangeSessionStatusUseCase,
+	cookieSessions *CookieSessions,
+	defaultUserID domain.UserID,
 ) *Handlers {
 	return &Handlers{
 		createTextUseCase:       createTextUseCase,
+		importTextUseCase:       importTextUseCase,
 		createSessionUseCase:    createSessionUseCase,
 		recordProgressUseCase:   recordProgressUseCase,
 		getSessionUseCase:       getSessionUseCase,
 		listTextsUseCase:        listTextsUseCase,
 		getTextFragmentsUseCase: getTextFragmentsUseCase,
-		currentUserID:           currentUserID,
+		registerUserUseCase:     registerUserUseCase,
+		loginUseCase:            loginUseCase,
+		createAPITokenUseCase:   createAPITokenUseCase,
+		listAPITokensUseCase:    listAPITokensUseCase,
+		revokeAPITokenUseCase:   revokeAPITokenUseCase,
+		authAPITokenUseCase:     authAPITokenUseCase,
+		listUnfinishedUseCase:   listUnfinishedUseCase,
+		recordKeystrokesUseCase: recordKeystrokesUseCase,
+		listLineResultsUseCase:  listLineResultsUseCase,
+		listSessionsUseCase:     listSessionsUseCase,
+		progressStatsUseCase:    progressStatsUseCase,
+		keyStatsUseCase:         keyStatsUseCase,
+		createDrillUseCase:      createDrillUseCase,
+		changeStatusUseCase:     changeStatusUseCase,
+		cookieSessions:          cookieSessions,
+		defaultUserID:           defaultUserID,
 	}
 }
 
-// CreateText handles POST /api/texts
+// CreateText handles POST /api/texts with a JSON body or a multipart file upload
 func (h *Handlers) CreateText(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	if isMultipart(r) {
+		h.importText(w, r, userID)
 		return
 	}
 
 	var req CreateTextRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request: %v", err))
+		respondError(w, http.StatusBadRequest, "Invalid JSON request body")
 		return
 	}
 
 	input := usecases.CreateTextInput{
-		UserID:  h.currentUserID,
-		Title:   req.Title,
-		Content: req.Content,
+		UserID:        userID,
+		Title:         req.Title,
+		Content:       req.Content,
+		Normalization: req.Normalize,
+		WrapWidth:     req.WrapWidth,
+		WrapSentences: req.WrapSentences,
 	}
 
 	output, err := h.createTextUseCase.Execute(r.Context(), input)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create text: %v", err))
+		respondUseCaseError(w, err)
 		return
 	}
 
@@ -73,6 +135,9 @@ func (h *Handlers) CreateText(w http.ResponseWriter, r *http.Request) {
 		TotalLines:    output.TextInfo.TotalLines,
 		FragmentSize:  output.TextInfo.FragmentSize,
 		FragmentCount: output.TextInfo.FragmentCount,
+		Normalization: normalizationToResponse(output.TextInfo.Normalization),
+		WrapWidth:     output.TextInfo.Wrap.Width,
+		WrapSentences: output.TextInfo.Wrap.Sentences,
 		CreatedAt:     output.TextInfo.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
@@ -82,24 +147,32 @@ func (h *Handlers) CreateText(w http.ResponseWriter, r *http.Request) {
 // CreateSession handles POST /api/sessions
 func (h *Handlers) CreateSession(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
 		return
 	}
 
 	var req CreateSessionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request: %v", err))
+		respondError(w, http.StatusBadRequest, "Invalid JSON request body")
 		return
 	}
 
 	input := usecases.CreateSessionInput{
-		UserID: h.currentUserID,
-		TextID: domain.TextID(req.TextID),
+		UserID:    userID,
+		TextID:    domain.TextID(req.TextID),
+		Mode:      domain.SessionModeKind(req.Mode),
+		ModeLimit: req.ModeLimit,
 	}
 
 	output, err := h.createSessionUseCase.Execute(r.Context(), input)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create session: %v", err))
+		respondUseCaseError(w, err)
 		return
 	}
 
@@ -110,7 +183,13 @@ func (h *Handlers) CreateSession(w http.ResponseWriter, r *http.Request) {
 // RecordProgress handles POST /api/sessions/:id/progress
 func (h *Handlers) RecordProgress(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
 		return
 	}
 
@@ -121,28 +200,41 @@ func (h *Handlers) RecordProgress(w http.ResponseWriter, r *http.Request) {
 
 	var req RecordProgressRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request: %v", err))
+		respondError(w, http.StatusBadRequest, "Invalid JSON request body")
 		return
 	}
 
 	input := usecases.RecordProgressInput{
-		SessionID:       sessionID,
-		AccuracyPercent: req.AccuracyPercent,
-		WPM:             req.WPM,
+		UserID:    userID,
+		SessionID: sessionID,
+		Typed:     req.Typed,
+		Elapsed:   time.Duration(req.ElapsedMs) * time.Millisecond,
 	}
 
 	output, err := h.recordProgressUseCase.Execute(r.Context(), input)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to record progress: %v", err))
+		respondUseCaseError(w, err)
 		return
 	}
 
 	resp := RecordProgressResponse{
 		ID:                   string(output.Session.ID),
+		CurrentFragmentIdx:   output.Session.CurrentFragmentIdx,
+		CurrentLineIdx:       output.Session.CurrentLineIdx,
+		CurrentLine:          output.Session.CurrentLine(),
 		CompletedLines:       output.Session.CompletedLines,
 		TotalAccuracyPercent: output.Session.TotalAccuracyPercent,
 		AverageWPM:           output.Session.AverageWPM,
-		IsCompleted:          output.Session.IsCompleted,
+		CompletedWords:       output.Session.CompletedWords,
+		RemainingMs:          output.Session.RemainingTime().Milliseconds(),
+		Status:               string(output.Session.Status),
+		IsCompleted:          output.Session.IsCompleted(),
+		Line: LineScoreResponse{
+			AccuracyPercent: output.Score.AccuracyPercent,
+			GrossWPM:        output.Score.GrossWPM,
+			NetWPM:          output.Score.NetWPM,
+			Errors:          output.Score.Errors,
+		},
 	}
 
 	respondJSON(w, http.StatusOK, resp)
@@ -151,19 +243,26 @@ func (h *Handlers) RecordProgress(w http.ResponseWriter, r *http.Request) {
 // GetSession handles GET /api/sessions/:id
 func (h *Handlers) GetSession(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
 		return
 	}
 
 	sessionID := r.URL.Path[len("/api/sessions/"):]
 
 	input := usecases.GetSessionInput{
+		UserID:    userID,
 		SessionID: domain.SessionID(sessionID),
 	}
 
 	output, err := h.getSessionUseCase.Execute(r.Context(), input)
 	if err != nil {
-		respondError(w, http.StatusNotFound, fmt.Sprintf("Session not found: %v", err))
+		respondUseCaseError(w, err)
 		return
 	}
 
@@ -174,17 +273,23 @@ func (h *Handlers) GetSession(w http.ResponseWriter, r *http.Request) {
 // ListTexts handles GET /api/texts
 func (h *Handlers) ListTexts(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
 		return
 	}
 
 	input := usecases.ListTextsInput{
-		UserID: h.currentUserID,
+		UserID: userID,
 	}
 
 	output, err := h.listTextsUseCase.Execute(r.Context(), input)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to list texts: %v", err))
+		respondUseCaseError(w, err)
 		return
 	}
 
@@ -200,7 +305,13 @@ func (h *Handlers) ListTexts(w http.ResponseWriter, r *http.Request) {
 // GetTextFragments handles GET /api/texts/:id/fragments
 func (h *Handlers) GetTextFragments(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
 		return
 	}
 
@@ -210,12 +321,13 @@ func (h *Handlers) GetTextFragments(w http.ResponseWriter, r *http.Request) {
 	textID = strings.TrimSuffix(textID, "/fragments")
 
 	input := usecases.GetTextFragmentsInput{
+		UserID: userID,
 		TextID: domain.TextID(textID),
 	}
 
 	output, err := h.getTextFragmentsUseCase.Execute(r.Context(), input)
 	if err != nil {
-		respondError(w, http.StatusNotFound, fmt.Sprintf("Failed to get fragments: %v", err))
+		respondUseCaseError(w, err)
 		return
 	}
 
@@ -242,5 +354,5 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 }
 
 func respondError(w http.ResponseWriter, status int, message string) {
-	respondJSON(w, status, ErrorResponse{Error: message})
+	respondJSON(w, status, ErrorResponse{Error: message, Code: errorCode(status)})
 }
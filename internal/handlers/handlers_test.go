@@ -12,12 +12,17 @@ import (
 	"time"
 	"typeten/internal/domain"
 	"typeten/internal/usecases"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 func setupTestHandlers(t *testing.T) *Handlers {
 	userRepo := usecases.NewMockUserRepository()
 	textRepo := usecases.NewMockTextRepository()
 	sessionRepo := usecases.NewMockSessionRepository()
+	tokenRepo := usecases.NewMockAPITokenRepository()
+	keystrokeRepo := usecases.NewMockKeystrokeRepository(sessionRepo)
+	lineResultRepo := usecases.NewMockLineResultRepository(sessionRepo)
 
 	now := time.Now()
 	user, err := domain.NewUser("user_1", "test@example.com", "testuser", now)
@@ -29,19 +34,51 @@ func setupTestHandlers(t *testing.T) *Handlers {
 	}
 
 	createTextUseCase := usecases.NewCreateTextUseCase(textRepo, userRepo, 5)
+	importTextUseCase := usecases.NewImportTextUseCase(textRepo, userRepo, usecases.NewDefaultImporterRegistry(), 5)
 	createSessionUseCase := usecases.NewCreateSessionUseCase(sessionRepo, textRepo, userRepo)
-	recordProgressUseCase := usecases.NewRecordProgressUseCase(sessionRepo)
+	recordProgressUseCase := usecases.NewRecordProgressUseCase(sessionRepo, textRepo, lineResultRepo)
 	getSessionUseCase := usecases.NewGetSessionUseCase(sessionRepo)
 	listTextsUseCase := usecases.NewListTextsUseCase(textRepo, userRepo)
 	getTextFragmentsUseCase := usecases.NewGetTextFragmentsUseCase(textRepo)
+	hasher := usecases.NewBcryptPasswordHasher(bcrypt.MinCost)
+	registerUserUseCase := usecases.NewRegisterUserUseCase(userRepo, hasher)
+	loginUseCase := usecases.NewLoginUseCase(userRepo, hasher)
+	createAPITokenUseCase := usecases.NewCreateAPITokenUseCase(tokenRepo, userRepo)
+	listAPITokensUseCase := usecases.NewListAPITokensUseCase(tokenRepo)
+	revokeAPITokenUseCase := usecases.NewRevokeAPITokenUseCase(tokenRepo)
+	authAPITokenUseCase := usecases.NewAuthenticateAPITokenUseCase(tokenRepo)
+	listUnfinishedUseCase := usecases.NewListUnfinishedSessionsUseCase(sessionRepo)
+	recordKeystrokesUseCase := usecases.NewRecordKeystrokesUseCase(sessionRepo, keystrokeRepo)
+	listLineResultsUseCase := usecases.NewListLineResultsUseCase(sessionRepo, lineResultRepo)
+	listSessionsUseCase := usecases.NewListSessionsUseCase(sessionRepo)
+	progressStatsUseCase := usecases.NewGetProgressStatsUseCase(sessionRepo)
+	keyStatsUseCase := usecases.NewGetKeyStatsUseCase(sessionRepo, textRepo, keystrokeRepo, lineResultRepo)
+	createDrillUseCase := usecases.NewCreateDrillUseCase(textRepo, sessionRepo, userRepo, keyStatsUseCase, 5)
+	changeSessionStatusUseCase := usecases.NewChangeSessionStatusUseCase(sessionRepo)
 
 	return NewHandlers(
 		createTextUseCase,
+		importTextUseCase,
 		createSessionUseCase,
 		recordProgressUseCase,
 		getSessionUseCase,
 		listTextsUseCase,
 		getTextFragmentsUseCase,
+		registerUserUseCase,
+		loginUseCase,
+		createAPITokenUseCase,
+		listAPITokensUseCase,
+		revokeAPITokenUseCase,
+		authAPITokenUseCase,
+		listUnfinishedUseCase,
+		recordKeystrokesUseCase,
+		listLineResultsUseCase,
+		listSessionsUseCase,
+		progressStatsUseCase,
+		keyStatsUseCase,
+		createDrillUseCase,
+		changeSessionStatusUseCase,
+		NewCookieSessions([]byte("test-secret"), time.Hour),
 		user.ID,
 	)
 }
@@ -67,6 +104,18 @@ func TestHandlers_CreateText(t *testing.T) {
 			body:       `invalid json`,
 			wantStatus: http.StatusBadRequest,
 		},
+		{
+			name:       "empty title",
+			method:     http.MethodPost,
+			body:       `{"title":"","content":"line1"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "empty content",
+			method:     http.MethodPost,
+			body:       `{"title":"Test","content":"\n\n"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
 		{
 			name:       "wrong method",
 			method:     http.MethodGet,
@@ -96,7 +145,7 @@ func TestHandlers_ListTexts(t *testing.T) {
 	// Create a text first using the handlers' use case
 	ctx := context.Background()
 	_, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
-		UserID:  handlers.currentUserID,
+		UserID:  handlers.defaultUserID,
 		Title:   "Test Text",
 		Content: "line1\nline2",
 	})
@@ -128,7 +177,7 @@ func TestHandlers_CreateSession(t *testing.T) {
 	// Create a text first using the handlers' use case
 	ctx := context.Background()
 	output, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
-		UserID:  handlers.currentUserID,
+		UserID:  handlers.defaultUserID,
 		Title:   "Test Text",
 		Content: "line1\nline2",
 	})
@@ -162,7 +211,7 @@ func TestHandlers_RecordProgress(t *testing.T) {
 	// Create a text and session first
 	ctx := context.Background()
 	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
-		UserID:  handlers.currentUserID,
+		UserID:  handlers.defaultUserID,
 		Title:   "Test Text",
 		Content: "line1\nline2",
 	})
@@ -171,14 +220,14 @@ func TestHandlers_RecordProgress(t *testing.T) {
 	}
 
 	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
-		UserID: handlers.currentUserID,
+		UserID: handlers.defaultUserID,
 		TextID: textOutput.TextInfo.ID,
 	})
 	if err != nil {
 		t.Fatalf("Failed to create test session: %v", err)
 	}
 
-	body := `{"accuracy_percent":95.5,"wpm":45.2}`
+	body := `{"typed":"line1","elapsed_ms":2000}`
 	req := httptest.NewRequest(http.MethodPost, "/api/sessions/"+string(sessionOutput.Session.ID)+"/progress", strings.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
@@ -196,6 +245,187 @@ func TestHandlers_RecordProgress(t *testing.T) {
 	if resp.CompletedLines != 1 {
 		t.Errorf("RecordProgress() CompletedLines = %v, want 1", resp.CompletedLines)
 	}
+	if resp.Line.AccuracyPercent != 100 || resp.Line.GrossWPM != 30 || resp.Line.Errors != 0 {
+		t.Errorf("RecordProgress() Line = %+v, want 100%% accuracy at 30 WPM", resp.Line)
+	}
+}
+
+func TestHandlers_RecordProgress_InvalidElapsed(t *testing.T) {
+	handlers := setupTestHandlers(t)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Test Text",
+		Content: "line1\nline2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+		UserID: handlers.defaultUserID,
+		TextID: textOutput.TextInfo.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+
+	path := "/api/sessions/" + string(sessionOutput.Session.ID) + "/progress"
+	body := `{"typed":"line1","elapsed_ms":-5}`
+	w := httptest.NewRecorder()
+	handlers.RecordProgress(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("RecordProgress() status = %v, want %v", w.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestHandlers_RecordProgress_CompletesAtLastLine(t *testing.T) {
+	handlers := setupTestHandlers(t)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Test Text",
+		Content: "line1\nline2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+		UserID: handlers.defaultUserID,
+		TextID: textOutput.TextInfo.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+
+	path := "/api/sessions/" + string(sessionOutput.Session.ID) + "/progress"
+	body := `{"typed":"line1","elapsed_ms":2000}`
+	for i, wantCompleted := range []bool{false, true} {
+		w := httptest.NewRecorder()
+		handlers.RecordProgress(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("RecordProgress() line %d status = %v, want %v", i, w.Code, http.StatusOK)
+		}
+		var resp RecordProgressResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.IsCompleted != wantCompleted {
+			t.Errorf("RecordProgress() line %d IsCompleted = %v, want %v", i, resp.IsCompleted, wantCompleted)
+		}
+		if resp.CurrentLineIdx != 1 {
+			t.Errorf("RecordProgress() line %d CurrentLineIdx = %v, want 1", i, resp.CurrentLineIdx)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handlers.RecordProgress(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	if w.Code != http.StatusConflict {
+		t.Errorf("RecordProgress() past the end status = %v, want %v", w.Code, http.StatusConflict)
+	}
+}
+
+func TestHandlers_SessionModes(t *testing.T) {
+	handlers := setupTestHandlers(t)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Test Text",
+		Content: "one two three\nfour five\nsix",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	textID := string(textOutput.TextInfo.ID)
+
+	t.Run("word limit completes the session", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := `{"text_id":"` + textID + `","mode":"words","mode_limit":4}`
+		handlers.CreateSession(w, httptest.NewRequest(http.MethodPost, "/api/sessions", strings.NewReader(body)))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("CreateSession() status = %v, want %v", w.Code, http.StatusCreated)
+		}
+		var created CreateSessionResponse
+		if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if created.Mode != "words" || created.ModeLimit != 4 {
+			t.Errorf("CreateSession() mode = %v/%v, want words/4", created.Mode, created.ModeLimit)
+		}
+
+		path := "/api/sessions/" + created.ID + "/progress"
+		for i, step := range []struct {
+			typed         string
+			wantWords     int
+			wantCompleted bool
+		}{
+			{typed: "one two three", wantWords: 3},
+			{typed: "four five", wantWords: 5, wantCompleted: true},
+		} {
+			w := httptest.NewRecorder()
+			body := `{"typed":"` + step.typed + `","elapsed_ms":2000}`
+			handlers.RecordProgress(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+			if w.Code != http.StatusOK {
+				t.Fatalf("RecordProgress() line %d status = %v, want %v", i, w.Code, http.StatusOK)
+			}
+			var resp RecordProgressResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.CompletedWords != step.wantWords || resp.IsCompleted != step.wantCompleted {
+				t.Errorf("RecordProgress() line %d = %v words, completed %v, want %v, %v",
+					i, resp.CompletedWords, resp.IsCompleted, step.wantWords, step.wantCompleted)
+			}
+		}
+
+		w = httptest.NewRecorder()
+		handlers.RecordProgress(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"typed":"six","elapsed_ms":2000}`)))
+		if w.Code != http.StatusConflict {
+			t.Errorf("RecordProgress() past the limit status = %v, want %v", w.Code, http.StatusConflict)
+		}
+	})
+
+	t.Run("minutes report the time left", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := `{"text_id":"` + textID + `","mode":"minutes","mode_limit":1}`
+		handlers.CreateSession(w, httptest.NewRequest(http.MethodPost, "/api/sessions", strings.NewReader(body)))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("CreateSession() status = %v, want %v", w.Code, http.StatusCreated)
+		}
+		var created CreateSessionResponse
+		if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if created.RemainingMs != 60000 {
+			t.Errorf("CreateSession() RemainingMs = %v, want 60000", created.RemainingMs)
+		}
+
+		w = httptest.NewRecorder()
+		path := "/api/sessions/" + created.ID + "/progress"
+		handlers.RecordProgress(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"typed":"one two three","elapsed_ms":15000}`)))
+		var resp RecordProgressResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.RemainingMs != 45000 || resp.IsCompleted {
+			t.Errorf("RecordProgress() = %v ms left, completed %v, want 45000, false", resp.RemainingMs, resp.IsCompleted)
+		}
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		for _, body := range []string{
+			`{"text_id":"` + textID + `","mode":"hours","mode_limit":1}`,
+			`{"text_id":"` + textID + `","mode":"lines"}`,
+		} {
+			w := httptest.NewRecorder()
+			handlers.CreateSession(w, httptest.NewRequest(http.MethodPost, "/api/sessions", strings.NewReader(body)))
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Errorf("CreateSession(%s) status = %v, want %v", body, w.Code, http.StatusUnprocessableEntity)
+			}
+		}
+	})
 }
 
 func TestHandlers_GetSession(t *testing.T) {
@@ -204,7 +434,7 @@ func TestHandlers_GetSession(t *testing.T) {
 	// Create a text and session first
 	ctx := context.Background()
 	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
-		UserID:  handlers.currentUserID,
+		UserID:  handlers.defaultUserID,
 		Title:   "Test Text",
 		Content: "line1\nline2",
 	})
@@ -213,7 +443,7 @@ func TestHandlers_GetSession(t *testing.T) {
 	}
 
 	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
-		UserID: handlers.currentUserID,
+		UserID: handlers.defaultUserID,
 		TextID: textOutput.TextInfo.ID,
 	})
 	if err != nil {
@@ -244,7 +474,7 @@ func TestHandlers_GetTextFragments(t *testing.T) {
 	// Create a text with fragments first using the handlers' use case
 	ctx := context.Background()
 	output, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
-		UserID:  handlers.currentUserID,
+		UserID:  handlers.defaultUserID,
 		Title:   "Test Text",
 		Content: "line1\nline2\nline3",
 	})
@@ -270,6 +500,103 @@ func TestHandlers_GetTextFragments(t *testing.T) {
 	}
 }
 
+func TestHandlers_OtherUsersResourcesForbidden(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Test Text",
+		Content: "line1\nline2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+		UserID: handlers.defaultUserID,
+		TextID: textOutput.TextInfo.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+	intruder, err := handlers.registerUserUseCase.Execute(ctx, usecases.RegisterUserInput{
+		Email:    "intruder@example.com",
+		Username: "intruder",
+		Password: "correct horse",
+	})
+	if err != nil {
+		t.Fatalf("Failed to register intruder: %v", err)
+	}
+
+	textID := string(textOutput.TextInfo.ID)
+	sessionID := string(sessionOutput.Session.ID)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "get session", method: http.MethodGet, path: "/api/sessions/" + sessionID},
+		{name: "record progress", method: http.MethodPost, path: "/api/sessions/" + sessionID + "/progress", body: `{"typed":"line1","elapsed_ms":2000}`},
+		{name: "get session lines", method: http.MethodGet, path: "/api/sessions/" + sessionID + "/lines"},
+		{name: "get fragments", method: http.MethodGet, path: "/api/texts/" + textID + "/fragments"},
+		{name: "create session", method: http.MethodPost, path: "/api/sessions", body: `{"text_id":"` + textID + `"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req = req.WithContext(WithUserID(req.Context(), intruder.User.ID))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusForbidden {
+				t.Errorf("%s %s status = %v, want %v", tt.method, tt.path, w.Code, http.StatusForbidden)
+			}
+		})
+	}
+}
+
+func TestHandlers_UnknownResourcesNotFound(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "get session", method: http.MethodGet, path: "/api/sessions/nonexistent"},
+		{name: "record progress", method: http.MethodPost, path: "/api/sessions/nonexistent/progress", body: `{"typed":"line1","elapsed_ms":2000}`},
+		{name: "get fragments", method: http.MethodGet, path: "/api/texts/nonexistent/fragments"},
+		{name: "create session", method: http.MethodPost, path: "/api/sessions", body: `{"text_id":"nonexistent"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusNotFound {
+				t.Errorf("%s %s status = %v, want %v", tt.method, tt.path, w.Code, http.StatusNotFound)
+			}
+			var resp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Code != codeNotFound {
+				t.Errorf("%s %s code = %v, want %v", tt.method, tt.path, resp.Code, codeNotFound)
+			}
+		})
+	}
+}
+
 func TestRouter(t *testing.T) {
 	handlers := setupTestHandlers(t)
 	router := NewRouter(handlers)
@@ -0,0 +1,394 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"typeten/internal/domain"
+	"typeten/internal/usecases"
+)
+
+var historyTpl = template.Must(template.New("history").Parse(historyHTML))
+
+// dateLayout is the date-only form accepted by the from/to filters and produced by date inputs.
+const dateLayout = "2006-01-02"
+
+type historyViewModel struct {
+	Sessions []historySessionView
+	Texts    []*domain.TextInfo
+	Filter   historyFilterView
+	NextURL  string // empty on the last page
+}
+
+// historySessionView pairs a session with the title of its text.
+type historySessionView struct {
+	Session *domain.Session
+	Title   string
+}
+
+// StatusLabel names the session status for the history table.
+func (v historySessionView) StatusLabel() string {
+	switch v.Session.Status {
+	case domain.SessionPaused:
+		return "Paused"
+	case domain.SessionCompleted:
+		return "Completed"
+	case domain.SessionAbandoned:
+		return "Abandoned"
+	default:
+		return "In progress"
+	}
+}
+
+// historyFilterView holds the raw filter values to refill the form.
+type historyFilterView struct {
+	TextID string
+	Status string
+	From   string
+	To     string
+}
+
+// parseSessionFilters reads the session list filters from the query string.
+// from and to accept RFC 3339 timestamps or dates; a date in to includes the whole day.
+func parseSessionFilters(r *http.Request, userID domain.UserID) (usecases.ListSessionsInput, error) {
+	q := r.URL.Query()
+	input := usecases.ListSessionsInput{
+		UserID: userID,
+		TextID: domain.TextID(q.Get("text_id")),
+		Cursor: q.Get("cursor"),
+	}
+	if v := q.Get("completed"); v != "" {
+		completed, err := strconv.ParseBool(v)
+		if err != nil {
+			return input, domain.NewFieldError(domain.ErrInvalidQuery, "completed", "must be true or false")
+		}
+		input.Completed = &completed
+	}
+	if v := q.Get("status"); v != "" {
+		status, err := domain.ParseSessionStatus(v)
+		if err != nil {
+			return input, domain.NewFieldError(domain.ErrInvalidQuery, "status", "must be one of active, paused, completed, abandoned")
+		}
+		input.Status = status
+	}
+	from, to, err := parseTimeRange(q)
+	if err != nil {
+		return input, err
+	}
+	input.From, input.To = from, to
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return input, domain.NewFieldError(domain.ErrInvalidQuery, "limit", "must be a positive number")
+		}
+		input.Limit = limit
+	}
+	return input, nil
+}
+
+// parseTimeRange reads the optional from and to query parameters.
+// Both accept RFC 3339 timestamps or dates; a date in to includes the whole day.
+func parseTimeRange(q url.Values) (time.Time, time.Time, error) {
+	var from, to time.Time
+	if v := q.Get("from"); v != "" {
+		t, _, err := parseTimeFilter(v)
+		if err != nil {
+			return from, to, domain.NewFieldError(domain.ErrInvalidQuery, "from", "must be a date or an RFC 3339 time")
+		}
+		from = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, dateOnly, err := parseTimeFilter(v)
+		if err != nil {
+			return from, to, domain.NewFieldError(domain.ErrInvalidQuery, "to", "must be a date or an RFC 3339 time")
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		to = t
+	}
+	return from, to, nil
+}
+
+// parseTimeFilter parses an RFC 3339 time or a UTC date and reports which one it was.
+func parseTimeFilter(v string) (time.Time, bool, error) {
+	if t, err := time.Parse(dateLayout, v); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	return t, false, err
+}
+
+// ListSessions handles GET /api/sessions
+func (h *Handlers) ListSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	input, err := parseSessionFilters(r, userID)
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	output, err := h.listSessionsUseCase.Execute(r.Context(), input)
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	resp := ListSessionsResponse{
+		Sessions:   make([]GetSessionResponse, len(output.Sessions)),
+		NextCursor: output.NextCursor,
+	}
+	for i, session := range output.Sessions {
+		resp.Sessions[i] = sessionToResponse(session)
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// HistoryPage renders the user's past sessions with filters and pagination.
+func (h *Handlers) HistoryPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	input, err := parseSessionFilters(r, userID)
+	if err != nil {
+		respondPageError(w, err)
+		return
+	}
+	out, err := h.listSessionsUseCase.Execute(r.Context(), input)
+	if err != nil {
+		respondPageError(w, err)
+		return
+	}
+	texts, err := h.listTextsUseCase.Execute(r.Context(), usecases.ListTextsInput{UserID: userID})
+	if err != nil {
+		respondPageError(w, err)
+		return
+	}
+
+	titles := make(map[domain.TextID]string, len(texts.Texts))
+	for _, t := range texts.Texts {
+		titles[t.ID] = t.Title
+	}
+	q := r.URL.Query()
+	vm := historyViewModel{
+		Texts: texts.Texts,
+		Filter: historyFilterView{
+			TextID: q.Get("text_id"),
+			Status: q.Get("status"),
+			From:   q.Get("from"),
+			To:     q.Get("to"),
+		},
+	}
+	for _, session := range out.Sessions {
+		vm.Sessions = append(vm.Sessions, historySessionView{Session: session, Title: titles[session.TextID]})
+	}
+	if out.NextCursor != "" {
+		q.Set("cursor", out.NextCursor)
+		vm.NextURL = (&url.URL{Path: "/history", RawQuery: q.Encode()}).String()
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := historyTpl.Execute(w, vm); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
+const historyHTML = `<!doctype html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>History · TypeTen</title>
+  <style>
+    body {
+      font-family: system-ui, -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif;
+      margin: 0;
+      padding: 0;
+      background: #0f172a;
+      color: #e5e7eb;
+    }
+    header {
+      padding: 1.25rem 2rem;
+      background: #020617;
+      border-bottom: 1px solid #1f2937;
+      display: flex;
+      justify-content: space-between;
+      align-items: center;
+    }
+    header a {
+      color: #9ca3af;
+      text-decoration: none;
+      font-size: 0.85rem;
+    }
+    header a:hover {
+      color: #e5e7eb;
+    }
+    main {
+      max-width: 960px;
+      margin: 2rem auto;
+      padding: 0 1.5rem 3rem;
+    }
+    .card {
+      background: #020617;
+      border-radius: 0.75rem;
+      border: 1px solid #1f2937;
+      padding: 1.25rem 1.5rem;
+      box-shadow: 0 18px 40px rgba(15, 23, 42, 0.6);
+    }
+    h1 {
+      margin: 0 0 1rem;
+      font-size: 1.3rem;
+    }
+    form.filters {
+      display: flex;
+      flex-wrap: wrap;
+      gap: 0.75rem;
+      align-items: flex-end;
+      margin-bottom: 1rem;
+    }
+    label {
+      display: block;
+      font-size: 0.75rem;
+      color: #9ca3af;
+      margin-bottom: 0.25rem;
+    }
+    select, input[type="date"] {
+      border-radius: 0.5rem;
+      border: 1px solid #1f2937;
+      background: #020617;
+      color: #e5e7eb;
+      padding: 0.4rem 0.6rem;
+      font-size: 0.85rem;
+    }
+    button {
+      border: none;
+      border-radius: 999px;
+      padding: 0.45rem 1.1rem;
+      background: linear-gradient(135deg, #4f46e5, #7c3aed);
+      color: white;
+      font-size: 0.85rem;
+      font-weight: 500;
+      cursor: pointer;
+    }
+    table {
+      width: 100%;
+      border-collapse: collapse;
+      font-size: 0.85rem;
+    }
+    th, td {
+      text-align: left;
+      padding: 0.5rem;
+      border-bottom: 1px solid #1f2937;
+    }
+    th {
+      color: #9ca3af;
+      font-weight: 500;
+    }
+    td a {
+      color: #e5e7eb;
+      text-decoration: none;
+    }
+    td a:hover {
+      color: #a5b4fc;
+    }
+    .empty {
+      font-size: 0.85rem;
+      color: #6b7280;
+    }
+    .pager {
+      margin-top: 1rem;
+      text-align: right;
+    }
+    .pager a {
+      color: #a5b4fc;
+      text-decoration: none;
+      font-size: 0.85rem;
+    }
+  </style>
+</head>
+<body>
+  <header>
+    <a href="/">&larr; Back to texts</a>
+    <span></span>
+  </header>
+  <main>
+    <section class="card">
+      <h1>Session history</h1>
+      <form class="filters" method="get" action="/history">
+        <div>
+          <label for="text_id">Text</label>
+          <select id="text_id" name="text_id">
+            <option value="">All texts</option>
+            {{range .Texts}}
+            <option value="{{.ID}}"{{if eq (print .ID) $.Filter.TextID}} selected{{end}}>{{.Title}}</option>
+            {{end}}
+          </select>
+        </div>
+        <div>
+          <label for="status">Status</label>
+          <select id="status" name="status">
+            <option value="">Any</option>
+            <option value="active"{{if eq .Filter.Status "active"}} selected{{end}}>In progress</option>
+            <option value="paused"{{if eq .Filter.Status "paused"}} selected{{end}}>Paused</option>
+            <option value="completed"{{if eq .Filter.Status "completed"}} selected{{end}}>Completed</option>
+            <option value="abandoned"{{if eq .Filter.Status "abandoned"}} selected{{end}}>Abandoned</option>
+          </select>
+        </div>
+        <div>
+          <label for="from">From</label>
+          <input id="from" name="from" type="date" value="{{.Filter.From}}">
+        </div>
+        <div>
+          <label for="to">To</label>
+          <input id="to" name="to" type="date" value="{{.Filter.To}}">
+        </div>
+        <button type="submit">Filter</button>
+      </form>
+      {{if .Sessions}}
+      <table>
+        <thead>
+          <tr><th>Date</th><th>Text</th><th>Lines</th><th>Accuracy</th><th>WPM</th><th>Status</th></tr>
+        </thead>
+        <tbody>
+          {{range .Sessions}}
+          <tr>
+            <td>{{.Session.CreatedAt.Format "Jan 2, 2006 15:04"}}</td>
+            <td><a href="/sessions/{{.Session.ID}}">{{if .Title}}{{.Title}}{{else}}Untitled text{{end}}</a></td>
+            <td>{{.Session.CompletedLines}}{{if .Session.TotalLines}} / {{.Session.TotalLines}}{{end}}</td>
+            <td>{{printf "%.1f" .Session.TotalAccuracyPercent}}%</td>
+            <td>{{printf "%.1f" .Session.AverageWPM}}</td>
+            <td>{{.StatusLabel}}</td>
+          </tr>
+          {{end}}
+        </tbody>
+      </table>
+      {{else}}
+      <p class="empty">No sessions match these filters.</p>
+      {{end}}
+      {{if .NextURL}}
+      <div class="pager"><a href="{{.NextURL}}">Older sessions &rarr;</a></div>
+      {{end}}
+    </section>
+  </main>
+</body>
+</html>`
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"typeten/internal/usecases"
+)
+
+func TestHandlers_ListSessions(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "History Text",
+		Content: "line1",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	textID := string(textOutput.TextInfo.ID)
+	var sessionIDs []string
+	for i := 0; i < 3; i++ {
+		sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+			UserID: handlers.defaultUserID,
+			TextID: textOutput.TextInfo.ID,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test session: %v", err)
+		}
+		sessionIDs = append(sessionIDs, string(sessionOutput.Session.ID))
+	}
+	// Finish the first session
+	if _, err := handlers.recordProgressUseCase.Execute(ctx, usecases.RecordProgressInput{
+		UserID:    handlers.defaultUserID,
+		SessionID: sessionIDs[0],
+		Typed:     "line1",
+		Elapsed:   time.Second,
+	}); err != nil {
+		t.Fatalf("Failed to record progress: %v", err)
+	}
+
+	list := func(t *testing.T, query string) (int, ListSessionsResponse) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/sessions"+query, nil))
+		var resp ListSessionsResponse
+		if w.Code == http.StatusOK {
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return w.Code, resp
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantCount  int
+	}{
+		{name: "all", query: "", wantStatus: http.StatusOK, wantCount: 3},
+		{name: "by text", query: "?text_id=" + textID, wantStatus: http.StatusOK, wantCount: 3},
+		{name: "unknown text", query: "?text_id=other", wantStatus: http.StatusOK, wantCount: 0},
+		{name: "completed", query: "?completed=true", wantStatus: http.StatusOK, wantCount: 1},
+		{name: "in progress", query: "?completed=false", wantStatus: http.StatusOK, wantCount: 2},
+		{name: "active", query: "?status=active", wantStatus: http.StatusOK, wantCount: 2},
+		{name: "abandoned", query: "?status=abandoned", wantStatus: http.StatusOK, wantCount: 0},
+		{name: "future range", query: "?from=2999-01-01", wantStatus: http.StatusOK, wantCount: 0},
+		{name: "invalid completed", query: "?completed=maybe", wantStatus: http.StatusUnprocessableEntity},
+		{name: "invalid status", query: "?status=done", wantStatus: http.StatusUnprocessableEntity},
+		{name: "invalid date", query: "?from=yesterday", wantStatus: http.StatusUnprocessableEntity},
+		{name: "invalid limit", query: "?limit=0", wantStatus: http.StatusUnprocessableEntity},
+		{name: "invalid cursor", query: "?cursor=%21%21", wantStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, resp := list(t, tt.query)
+			if status != tt.wantStatus {
+				t.Fatalf("ListSessions() status = %v, want %v", status, tt.wantStatus)
+			}
+			if status == http.StatusOK && len(resp.Sessions) != tt.wantCount {
+				t.Errorf("ListSessions() returned %d sessions, want %d", len(resp.Sessions), tt.wantCount)
+			}
+		})
+	}
+
+	t.Run("cursor pagination", func(t *testing.T) {
+		seen := map[string]bool{}
+		query := "?limit=2"
+		for pages := 0; ; pages++ {
+			if pages > 2 {
+				t.Fatal("ListSessions() did not stop paging")
+			}
+			status, resp := list(t, query)
+			if status != http.StatusOK {
+				t.Fatalf("ListSessions() status = %v, want %v", status, http.StatusOK)
+			}
+			for _, s := range resp.Sessions {
+				if seen[s.ID] {
+					t.Errorf("ListSessions() returned session %s twice", s.ID)
+				}
+				seen[s.ID] = true
+			}
+			if resp.NextCursor == "" {
+				break
+			}
+			query = "?limit=2&cursor=" + resp.NextCursor
+		}
+		if len(seen) != len(sessionIDs) {
+			t.Errorf("ListSessions() paged through %d sessions, want %d", len(seen), len(sessionIDs))
+		}
+	})
+
+	t.Run("history page", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/history?completed=true", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("HistoryPage() status = %v, want %v", w.Code, http.StatusOK)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, `href="/sessions/`+sessionIDs[0]+`">History Text</a>`) {
+			t.Errorf("HistoryPage() body does not link the completed session")
+		}
+		if strings.Contains(body, "/sessions/"+sessionIDs[1]) {
+			t.Errorf("HistoryPage() body lists a session excluded by the filter")
+		}
+	})
+}
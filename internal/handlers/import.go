@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"typeten/internal/domain"
+	"typeten/internal/usecases"
+)
+
+// maxUploadBodyBytes bounds a multipart upload: the file plus room for the other form fields.
+const maxUploadBodyBytes = usecases.MaxImportBytes + 1<<20
+
+// isMultipart reports whether r carries a multipart/form-data body.
+func isMultipart(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/form-data"
+}
+
+// readUpload parses a multipart upload with a "file" part and optional "title",
+// "column", "normalize", "wrap_width" and "wrap_sentences" fields. The returned input has no Data if the form has no file.
+func readUpload(w http.ResponseWriter, r *http.Request, userID domain.UserID) (usecases.ImportTextInput, error) {
+	input := usecases.ImportTextInput{UserID: userID}
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadBodyBytes)
+	if err := r.ParseMultipartForm(maxUploadBodyBytes); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return input, domain.NewFieldError(domain.ErrInvalidTextInfo, "file", fmt.Sprintf("must be at most %d MB", usecases.MaxImportBytes>>20))
+		}
+		return input, err
+	}
+	input.Title = r.FormValue("title")
+	input.Column = r.FormValue("column")
+	input.Normalization = formNormalization(r)
+	width, sentences, err := formWrap(r)
+	if err != nil {
+		return input, err
+	}
+	input.WrapWidth, input.WrapSentences = width, sentences
+
+	file, header, err := r.FormFile("file")
+	if errors.Is(err, http.ErrMissingFile) {
+		return input, nil
+	}
+	if err != nil {
+		return input, err
+	}
+	defer file.Close()
+
+	// Read one byte past the limit so the use case can reject oversized files.
+	data, err := io.ReadAll(io.LimitReader(file, usecases.MaxImportBytes+1))
+	if err != nil {
+		return input, err
+	}
+	input.Filename = header.Filename
+	input.Data = data
+	return input, nil
+}
+
+// isFieldError reports whether readUpload rejected the upload itself rather than
+// failing to parse the request.
+func isFieldError(err error) bool {
+	var fieldErr *domain.FieldError
+	return errors.As(err, &fieldErr)
+}
+
+// importText handles POST /api/texts with a multipart file upload.
+func (h *Handlers) importText(w http.ResponseWriter, r *http.Request, userID domain.UserID) {
+	input, err := readUpload(w, r, userID)
+	if err != nil {
+		if !isFieldError(err) {
+			respondError(w, http.StatusBadRequest, "Invalid multipart request body")
+			return
+		}
+		respondUseCaseError(w, err)
+		return
+	}
+	if input.Data == nil {
+		respondUseCaseError(w, domain.NewFieldError(domain.ErrInvalidTextInfo, "file", "is required"))
+		return
+	}
+
+	output, err := h.importTextUseCase.Execute(r.Context(), input)
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	resp := CreateTextResponse{
+		ID:            string(output.TextInfo.ID),
+		Title:         output.TextInfo.Title,
+		Author:        output.TextInfo.Author,
+		TotalLines:    output.TextInfo.TotalLines,
+		FragmentSize:  output.TextInfo.FragmentSize,
+		FragmentCount: output.TextInfo.FragmentCount,
+		Chapters:      chaptersToResponse(output.TextInfo.Chapters),
+		Normalization: normalizationToResponse(output.TextInfo.Normalization),
+		WrapWidth:     output.TextInfo.Wrap.Width,
+		WrapSentences: output.TextInfo.Wrap.Sentences,
+		Encoding:      output.Encoding,
+		CreatedAt:     output.TextInfo.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// uploadAccept returns the accept attribute of the upload form's file input.
+func (h *Handlers) uploadAccept() string {
+	return strings.Join(h.importTextUseCase.Extensions(), ",")
+}
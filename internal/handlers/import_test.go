@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"typeten/internal/usecases"
+)
+
+// multipartBody builds a multipart form with the given fields and an optional file part.
+func multipartBody(t *testing.T, fields map[string]string, filename string, data []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for name, value := range fields {
+		if err := mw.WriteField(name, value); err != nil {
+			t.Fatalf("Failed to write field: %v", err)
+		}
+	}
+	if filename != "" {
+		part, err := mw.CreateFormFile("file", filename)
+		if err != nil {
+			t.Fatalf("Failed to create file part: %v", err)
+		}
+		part.Write(data)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatalf("Failed to close multipart writer: %v", err)
+	}
+	return &buf, mw.FormDataContentType()
+}
+
+func TestHandlers_CreateText_Upload(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	tests := []struct {
+		name       string
+		title      string
+		filename   string
+		data       []byte
+		wantStatus int
+		wantTitle  string
+		wantLines  int
+		column     string
+	}{
+		{name: "markdown", filename: "notes.md", data: []byte("# Notes\n\nSome *text*."), wantStatus: http.StatusCreated, wantTitle: "Notes", wantLines: 2},
+		{name: "title field", title: "Custom", filename: "a.txt", data: []byte("line1\nline2"), wantStatus: http.StatusCreated, wantTitle: "Custom", wantLines: 2},
+		{name: "csv column", filename: "quotes.csv", column: "quote", data: []byte("quote,author\nFirst.,A\nSecond.,B\n"), wantStatus: http.StatusCreated, wantTitle: "quotes", wantLines: 2},
+		{name: "subtitles", filename: "film.srt", data: []byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n\n2\n00:00:02,000 --> 00:00:03,000\nworld.\n"), wantStatus: http.StatusCreated, wantTitle: "film", wantLines: 1},
+		{name: "unknown column", filename: "quotes.csv", column: "text", data: []byte("quote,author\nFirst.,A\n"), wantStatus: http.StatusUnprocessableEntity},
+		{name: "unsupported type", filename: "a.pdf", data: []byte("%PDF"), wantStatus: http.StatusUnprocessableEntity},
+		{name: "missing file", title: "No file", wantStatus: http.StatusUnprocessableEntity},
+		{name: "too large", filename: "big.txt", data: []byte(strings.Repeat("a", usecases.MaxImportBytes+1)), wantStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType := multipartBody(t, map[string]string{"title": tt.title, "column": tt.column}, tt.filename, tt.data)
+			req := httptest.NewRequest(http.MethodPost, "/api/texts", body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("CreateText() status = %v, want %v; body: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			var resp CreateTextResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Title != tt.wantTitle || resp.TotalLines != tt.wantLines || resp.Encoding != usecases.EncodingUTF8 {
+				t.Errorf("CreateText() = %+v, want title %q with %d lines", resp, tt.wantTitle, tt.wantLines)
+			}
+		})
+	}
+}
+
+func TestHandlers_CreateTextHTML_Upload(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	body, contentType := multipartBody(t, nil, "page.html", []byte("<title>Page</title><p>Hello</p>"))
+	req := httptest.NewRequest(http.MethodPost, "/texts", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("CreateTextHTML() status = %v, want %v; body: %s", w.Code, http.StatusSeeOther, w.Body.String())
+	}
+	out, err := handlers.listTextsUseCase.Execute(req.Context(), usecases.ListTextsInput{UserID: handlers.defaultUserID})
+	if err != nil {
+		t.Fatalf("ListTexts() error = %v", err)
+	}
+	if len(out.Texts) != 1 || out.Texts[0].Title != "Page" {
+		t.Errorf("ListTexts() = %+v, want one text titled Page", out.Texts)
+	}
+
+	// Without a file the pasted content is used.
+	body, contentType = multipartBody(t, map[string]string{"title": "Pasted", "content": "line1"}, "", nil)
+	req = httptest.NewRequest(http.MethodPost, "/texts", body)
+	req.Header.Set("Content-Type", contentType)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("CreateTextHTML() status = %v, want %v; body: %s", w.Code, http.StatusSeeOther, w.Body.String())
+	}
+}
+
+func TestHandlers_CreateText_UploadBook(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	book := `<?xml version="1.0" encoding="UTF-8"?>
+<FictionBook><description><title-info>
+  <author><first-name>Jane</first-name><last-name>Austen</last-name></author><book-title>Emma</book-title>
+</title-info></description><body>
+  <section><title><p>Chapter 1</p></title><p>Emma Woodhouse, handsome, clever, and rich.</p></section>
+  <section><title><p>Chapter 2</p></title><p>Mr. Weston was a native of Highbury.</p></section>
+</body></FictionBook>`
+	body, contentType := multipartBody(t, nil, "emma.fb2", []byte(book))
+	req := httptest.NewRequest(http.MethodPost, "/api/texts", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("CreateText() status = %v, want %v; body: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+	var resp CreateTextResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	wantChapters := []ChapterResponse{{Title: "Chapter 1", Line: 0}, {Title: "Chapter 2", Line: 2}}
+	if resp.Title != "Emma" || resp.Author != "Jane Austen" || !reflect.DeepEqual(resp.Chapters, wantChapters) {
+		t.Errorf("CreateText() = %+v, want Emma by Jane Austen with chapters %+v", resp, wantChapters)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/texts/"+resp.ID, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("TextDetailPage() status = %v, want %v", w.Code, http.StatusOK)
+	}
+	for _, want := range []string{"Jane Austen", "Chapter 2", "line 3"} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("TextDetailPage() body does not contain %q", want)
+		}
+	}
+}
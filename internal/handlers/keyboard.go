@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+
+	"typeten/internal/usecases"
+)
+
+// heatmapMaxErrorPercent is the error rate shown in full red; lower rates fade to green.
+const heatmapMaxErrorPercent = 20
+
+// keyboardLayout lists the unshifted characters of a keyboard, row by row.
+type keyboardLayout struct {
+	Name string
+	Rows []string
+}
+
+var keyboardLayouts = []keyboardLayout{
+	{Name: "QWERTY", Rows: []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}},
+	{Name: "ЙЦУКЕН", Rows: []string{"ё1234567890-=", "йцукенгшщзхъ\\", "фывапролджэ", "ячсмитьбю."}},
+}
+
+type keyboardView struct {
+	Name  string
+	Rows  [][]keyView
+	Space keyView
+}
+
+type keyView struct {
+	Label string
+	Title string
+	Color template.CSS
+}
+
+// buildKeyboards colors every key of the known layouts by its error rate.
+func buildKeyboards(keys []usecases.KeyStat) []keyboardView {
+	stats := make(map[string]usecases.KeyStat, len(keys))
+	for _, stat := range keys {
+		stats[stat.Char] = stat
+	}
+
+	keyboards := make([]keyboardView, len(keyboardLayouts))
+	for i, layout := range keyboardLayouts {
+		keyboard := keyboardView{Name: layout.Name, Rows: make([][]keyView, len(layout.Rows))}
+		for j, row := range layout.Rows {
+			for _, r := range row {
+				keyboard.Rows[j] = append(keyboard.Rows[j], newKeyView(string(r), string(r), stats))
+			}
+		}
+		keyboard.Space = newKeyView(" ", "space", stats)
+		keyboards[i] = keyboard
+	}
+	return keyboards
+}
+
+func newKeyView(char, label string, stats map[string]usecases.KeyStat) keyView {
+	stat, ok := stats[char]
+	if !ok {
+		return keyView{Label: label, Title: label + ": no data", Color: "#1f2937"}
+	}
+	return keyView{
+		Label: label,
+		Title: fmt.Sprintf("%s: %d of %d missed (%.1f%%)", label, stat.Errors, stat.Attempts, stat.ErrorPercent),
+		Color: heatColor(stat.ErrorPercent),
+	}
+}
+
+// heatColor maps an error rate to a hue from green (no errors) to red.
+func heatColor(errorPercent float64) template.CSS {
+	hue := 120 * (1 - math.Min(errorPercent/heatmapMaxErrorPercent, 1))
+	return template.CSS(fmt.Sprintf("hsl(%.0f, 65%%, 32%%)", hue))
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"testing"
+
+	"typeten/internal/usecases"
+)
+
+func TestBuildKeyboards(t *testing.T) {
+	keyboards := buildKeyboards([]usecases.KeyStat{
+		{Char: "f", Attempts: 10, Errors: 1, ErrorPercent: 10},
+		{Char: "ф", Attempts: 4, Errors: 4, ErrorPercent: 100},
+		{Char: " ", Attempts: 20},
+	})
+
+	if len(keyboards) != len(keyboardLayouts) {
+		t.Fatalf("buildKeyboards() returned %d keyboards, want %d", len(keyboards), len(keyboardLayouts))
+	}
+
+	tests := []struct {
+		keyboard  int
+		row, col  int
+		wantLabel string
+		wantColor string
+	}{
+		{keyboard: 0, row: 2, col: 3, wantLabel: "f", wantColor: "hsl(60, 65%, 32%)"},
+		{keyboard: 0, row: 2, col: 0, wantLabel: "a", wantColor: "#1f2937"},
+		{keyboard: 1, row: 2, col: 0, wantLabel: "ф", wantColor: "hsl(0, 65%, 32%)"},
+	}
+	for _, tt := range tests {
+		key := keyboards[tt.keyboard].Rows[tt.row][tt.col]
+		if key.Label != tt.wantLabel || string(key.Color) != tt.wantColor {
+			t.Errorf("buildKeyboards() key = %+v, want %s in %s", key, tt.wantLabel, tt.wantColor)
+		}
+	}
+	if space := keyboards[0].Space; string(space.Color) != "hsl(120, 65%, 32%)" {
+		t.Errorf("buildKeyboards() space color = %v, want green", space.Color)
+	}
+}
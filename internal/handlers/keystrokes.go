@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"typeten/internal/domain"
+	"typeten/internal/usecases"
+)
+
+// maxKeystrokesBodyBytes bounds the request body of RecordKeystrokes.
+const maxKeystrokesBodyBytes = 1 << 20
+
+// RecordKeystrokes handles POST /api/sessions/:id/keystrokes
+func (h *Handlers) RecordKeystrokes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	// Extract session ID from path like "/api/sessions/abc123/keystrokes"
+	sessionID := strings.TrimPrefix(r.URL.Path, "/api/sessions/")
+	sessionID = strings.TrimSuffix(sessionID, "/keystrokes")
+
+	var req RecordKeystrokesRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxKeystrokesBodyBytes)).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid JSON request body")
+		return
+	}
+	if req.LineIdx == nil {
+		respondUseCaseError(w, domain.NewFieldError(domain.ErrInvalidKeystroke, "line_idx", "is required"))
+		return
+	}
+
+	events := make([]usecases.KeystrokeInput, len(req.Events))
+	for i, e := range req.Events {
+		events[i] = usecases.KeystrokeInput{
+			Key:          e.Key,
+			Expected:     e.Expected,
+			OffsetMs:     e.OffsetMs,
+			IsCorrection: e.Correction,
+		}
+	}
+
+	output, err := h.recordKeystrokesUseCase.Execute(r.Context(), usecases.RecordKeystrokesInput{
+		UserID:    userID,
+		SessionID: domain.SessionID(sessionID),
+		LineIdx:   *req.LineIdx,
+		Events:    events,
+	})
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	resp := RecordKeystrokesResponse{
+		SessionID: sessionID,
+		LineIdx:   *req.LineIdx,
+		Recorded:  output.Recorded,
+	}
+	respondJSON(w, http.StatusCreated, resp)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"typeten/internal/usecases"
+)
+
+func TestHandlers_RecordKeystrokes(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Test Text",
+		Content: "line1\nline2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+		UserID: handlers.defaultUserID,
+		TextID: textOutput.TextInfo.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+	path := "/api/sessions/" + string(sessionOutput.Session.ID) + "/keystrokes"
+
+	tests := []struct {
+		name         string
+		path         string
+		body         string
+		wantStatus   int
+		wantRecorded int
+	}{
+		{
+			name:         "valid batch",
+			path:         path,
+			body:         `{"line_idx":0,"events":[{"key":"l","expected":"l","offset_ms":0},{"key":"Backspace","expected":"i","offset_ms":150,"correction":true}]}`,
+			wantStatus:   http.StatusCreated,
+			wantRecorded: 2,
+		},
+		{
+			name:       "missing line index",
+			path:       path,
+			body:       `{"events":[{"key":"l","offset_ms":0}]}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "line past the end",
+			path:       path,
+			body:       `{"line_idx":2,"events":[{"key":"l","offset_ms":0}]}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid JSON",
+			path:       path,
+			body:       `{"line_idx":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown session",
+			path:       "/api/sessions/nonexistent/keystrokes",
+			body:       `{"line_idx":0,"events":[{"key":"l","offset_ms":0}]}`,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("RecordKeystrokes() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			var resp RecordKeystrokesResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Recorded != tt.wantRecorded {
+				t.Errorf("RecordKeystrokes() Recorded = %v, want %v", resp.Recorded, tt.wantRecorded)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"typeten/internal/domain"
+	"typeten/internal/usecases"
+)
+
+// GetSessionLines handles GET /api/sessions/:id/lines
+func (h *Handlers) GetSessionLines(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	// Extract session ID from path like "/api/sessions/abc123/lines"
+	sessionID := strings.TrimPrefix(r.URL.Path, "/api/sessions/")
+	sessionID = strings.TrimSuffix(sessionID, "/lines")
+
+	output, err := h.listLineResultsUseCase.Execute(r.Context(), usecases.ListLineResultsInput{
+		UserID:    userID,
+		SessionID: domain.SessionID(sessionID),
+	})
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	resp := SessionLinesResponse{
+		SessionID: string(output.Session.ID),
+		Lines:     make([]LineResultResponse, len(output.Lines)),
+	}
+	for i, line := range output.Lines {
+		resp.Lines[i] = lineResultToResponse(line, output.Session.FragmentSize)
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"typeten/internal/usecases"
+)
+
+func TestHandlers_GetSessionLines(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Test Text",
+		Content: "line1\nline2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+		UserID: handlers.defaultUserID,
+		TextID: textOutput.TextInfo.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+	sessionID := string(sessionOutput.Session.ID)
+
+	getLines := func(t *testing.T) SessionLinesResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID+"/lines", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GetSessionLines() status = %v, want %v", w.Code, http.StatusOK)
+		}
+		var resp SessionLinesResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	t.Run("no lines yet", func(t *testing.T) {
+		if resp := getLines(t); resp.SessionID != sessionID || len(resp.Lines) != 0 {
+			t.Errorf("GetSessionLines() = %+v, want no lines", resp)
+		}
+	})
+
+	for _, body := range []string{`{"typed":"line1","elapsed_ms":2000}`, `{"typed":"lin2","elapsed_ms":1000}`} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/sessions/"+sessionID+"/progress", strings.NewReader(body)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("RecordProgress() status = %v, want %v", w.Code, http.StatusOK)
+		}
+	}
+
+	t.Run("lines in text order", func(t *testing.T) {
+		resp := getLines(t)
+		if len(resp.Lines) != 2 {
+			t.Fatalf("GetSessionLines() lines = %v, want 2", len(resp.Lines))
+		}
+		first, second := resp.Lines[0], resp.Lines[1]
+		if first.Line != 0 || first.Typed != "line1" || first.DurationMs != 2000 || first.Errors != 0 {
+			t.Errorf("GetSessionLines()[0] = %+v, want line 0 typed without errors in 2000ms", first)
+		}
+		if second.Line != 1 || second.Typed != "lin2" || second.Errors != 1 || second.AccuracyPercent != 80 {
+			t.Errorf("GetSessionLines()[1] = %+v, want line 1 with 1 error at 80%%", second)
+		}
+	})
+
+	t.Run("completed session page shows the breakdown", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sessions/"+sessionID, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("SessionPage() status = %v, want %v", w.Code, http.StatusOK)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, `<td class="typed">lin2</td>`) || !strings.Contains(body, "80.0%") {
+			t.Errorf("SessionPage() body does not contain the line breakdown")
+		}
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/sessions/nonexistent/lines", nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("GetSessionLines() status = %v, want %v", w.Code, http.StatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"typeten/internal/domain"
+	"typeten/internal/usecases"
+)
+
+// publicPaths are reachable without a login session.
+var publicPaths = map[string]bool{
+	"/login":             true,
+	"/register":          true,
+	"/logout":            true,
+	"/api/auth/register": true,
+	"/api/auth/login":    true,
+	"/api/auth/logout":   true,
+}
+
+// RequireLogin wraps next so that every request carries the authenticated user in
+// its context (see UserIDFromContext). Requests with an "Authorization: Bearer"
+// header are authenticated by API token; other requests use the session cookie.
+// Requests without a valid session are rejected with 401 on /api/ paths and
+// redirected to the login page otherwise, except for the public login,
+// registration and logout paths.
+func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if secret, ok := bearerToken(r); ok {
+			h.serveWithAPIToken(w, r, next, secret)
+			return
+		}
+
+		userID, ok := h.authenticate(r)
+		switch {
+		case ok:
+			r = r.WithContext(WithUserID(r.Context(), userID))
+		case publicPaths[r.URL.Path]:
+		case strings.HasPrefix(r.URL.Path, "/api/"):
+			respondError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		default:
+			http.Redirect(w, r, "/login?next="+url.QueryEscape(r.URL.RequestURI()), http.StatusSeeOther)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// serveWithAPIToken serves r as the owner of the API token, enforcing its scope.
+// Tokens cannot manage tokens, so a leaked token cannot mint or revoke others.
+func (h *Handlers) serveWithAPIToken(w http.ResponseWriter, r *http.Request, next http.Handler, secret string) {
+	output, err := h.authAPITokenUseCase.Execute(r.Context(), usecases.AuthenticateAPITokenInput{Secret: secret})
+	if errors.Is(err, domain.ErrInvalidCredentials) {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		respondError(w, http.StatusUnauthorized, "Invalid API token")
+		return
+	}
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+	if r.URL.Path == "/api/tokens" || strings.HasPrefix(r.URL.Path, "/api/tokens/") {
+		respondError(w, http.StatusForbidden, "API tokens cannot be managed with an API token")
+		return
+	}
+	if !output.Token.Allows(isWriteMethod(r.Method)) {
+		respondError(w, http.StatusForbidden, "API token scope does not allow this request")
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(WithUserID(r.Context(), output.Token.UserID)))
+}
+
+// bearerToken returns the token from an "Authorization: Bearer <token>" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// isWriteMethod reports whether requests with method may modify data.
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
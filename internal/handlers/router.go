@@ -22,6 +22,16 @@ func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch {
 	case path == "/" && r.Method == http.MethodGet:
 		rt.handlers.IndexPage(w, r)
+	case path == "/login" && r.Method == http.MethodGet:
+		rt.handlers.LoginPage(w, r)
+	case path == "/login" && r.Method == http.MethodPost:
+		rt.handlers.LoginHTML(w, r)
+	case path == "/register" && r.Method == http.MethodGet:
+		rt.handlers.RegisterPage(w, r)
+	case path == "/register" && r.Method == http.MethodPost:
+		rt.handlers.RegisterHTML(w, r)
+	case path == "/logout" && r.Method == http.MethodPost:
+		rt.handlers.LogoutHTML(w, r)
 	case path == "/texts" && r.Method == http.MethodPost:
 		rt.handlers.CreateTextHTML(w, r)
 	case strings.HasPrefix(path, "/texts/") && r.Method == http.MethodGet:
@@ -32,6 +42,12 @@ func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		rt.handlers.TextDetailPage(w, r, id)
+	case path == "/history" && r.Method == http.MethodGet:
+		rt.handlers.HistoryPage(w, r)
+	case path == "/stats" && r.Method == http.MethodGet:
+		rt.handlers.StatsPage(w, r)
+	case path == "/drills" && r.Method == http.MethodPost:
+		rt.handlers.CreateDrillHTML(w, r)
 	case path == "/sessions" && r.Method == http.MethodPost:
 		rt.handlers.CreateSessionHTML(w, r)
 	case strings.HasPrefix(path, "/sessions/") && r.Method == http.MethodGet:
@@ -42,6 +58,24 @@ func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		rt.handlers.SessionPage(w, r, id)
+	case path == "/api/auth/register" && r.Method == http.MethodPost:
+		rt.handlers.Register(w, r)
+	case path == "/api/auth/login" && r.Method == http.MethodPost:
+		rt.handlers.Login(w, r)
+	case path == "/api/auth/logout" && r.Method == http.MethodPost:
+		rt.handlers.Logout(w, r)
+	case path == "/api/tokens" && r.Method == http.MethodPost:
+		rt.handlers.CreateAPIToken(w, r)
+	case path == "/api/tokens" && r.Method == http.MethodGet:
+		rt.handlers.ListAPITokens(w, r)
+	case strings.HasPrefix(path, "/api/tokens/") && r.Method == http.MethodDelete:
+		rt.handlers.RevokeAPIToken(w, r)
+	case path == "/api/stats/progress" && r.Method == http.MethodGet:
+		rt.handlers.GetProgressStats(w, r)
+	case path == "/api/stats/keys" && r.Method == http.MethodGet:
+		rt.handlers.GetKeyStats(w, r)
+	case path == "/api/drills" && r.Method == http.MethodPost:
+		rt.handlers.CreateDrill(w, r)
 	case path == "/api/texts" && r.Method == http.MethodPost:
 		rt.handlers.CreateText(w, r)
 	case path == "/api/texts" && r.Method == http.MethodGet:
@@ -50,8 +84,16 @@ func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		rt.handlers.GetTextFragments(w, r)
 	case path == "/api/sessions" && r.Method == http.MethodPost:
 		rt.handlers.CreateSession(w, r)
+	case path == "/api/sessions" && r.Method == http.MethodGet:
+		rt.handlers.ListSessions(w, r)
 	case strings.HasPrefix(path, "/api/sessions/") && strings.HasSuffix(path, "/progress") && r.Method == http.MethodPost:
 		rt.handlers.RecordProgress(w, r)
+	case strings.HasPrefix(path, "/api/sessions/") && strings.HasSuffix(path, "/keystrokes") && r.Method == http.MethodPost:
+		rt.handlers.RecordKeystrokes(w, r)
+	case strings.HasPrefix(path, "/api/sessions/") && strings.HasSuffix(path, "/lines") && r.Method == http.MethodGet:
+		rt.handlers.GetSessionLines(w, r)
+	case strings.HasPrefix(path, "/api/sessions/") && isSessionTransition(path) && r.Method == http.MethodPost:
+		rt.handlers.ChangeSessionStatus(w, r)
 	case strings.HasPrefix(path, "/api/sessions/") && !strings.HasSuffix(path, "/progress") && r.Method == http.MethodGet:
 		rt.handlers.GetSession(w, r)
 	default:
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"typeten/internal/domain"
+)
+
+// sessionCookieName is the cookie that carries the signed login session.
+const sessionCookieName = "typeten_session"
+
+// CookieSessions issues and verifies login sessions stored entirely in a signed cookie.
+// The cookie value is "<base64 user id>.<unix expiry>.<base64 HMAC-SHA256>", so the
+// server keeps no session state and sessions survive restarts. Logging out clears the cookie.
+type CookieSessions struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewCookieSessions creates a CookieSessions signing with secret; sessions expire after ttl.
+func NewCookieSessions(secret []byte, ttl time.Duration) *CookieSessions {
+	return &CookieSessions{secret: secret, ttl: ttl, now: time.Now}
+}
+
+// Issue sets a session cookie for userID.
+func (s *CookieSessions) Issue(w http.ResponseWriter, r *http.Request, userID domain.UserID) {
+	expires := s.now().Add(s.ttl)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(userID)) + "." + strconv.FormatInt(expires.Unix(), 10)
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    payload + "." + s.sign(payload),
+		Path:     "/",
+		Expires:  expires,
+		MaxAge:   int(s.ttl.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// Read returns the user of a valid, unexpired session cookie on r.
+func (s *CookieSessions) Read(r *http.Request) (domain.UserID, bool) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return "", false
+	}
+	payload, signature, ok := cutLast(cookie.Value, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return "", false
+	}
+	encodedID, expiresStr, ok := strings.Cut(payload, ".")
+	if !ok {
+		return "", false
+	}
+	expires, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil || s.now().Unix() >= expires {
+		return "", false
+	}
+	id, err := base64.RawURLEncoding.DecodeString(encodedID)
+	if err != nil || len(id) == 0 {
+		return "", false
+	}
+	return domain.UserID(id), true
+}
+
+// Clear removes the session cookie.
+func (s *CookieSessions) Clear(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (s *CookieSessions) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+type userIDContextKey struct{}
+
+// WithUserID returns a copy of ctx carrying the authenticated user.
+func WithUserID(ctx context.Context, userID domain.UserID) context.Context {
+	return context.WithValue(ctx, userIDContextKey{}, userID)
+}
+
+// UserIDFromContext returns the authenticated user stored by WithUserID.
+func UserIDFromContext(ctx context.Context) (domain.UserID, bool) {
+	userID, ok := ctx.Value(userIDContextKey{}).(domain.UserID)
+	return userID, ok && userID != ""
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func issueCookie(t *testing.T, sessions *CookieSessions, userID domain.UserID) *http.Cookie {
+	t.Helper()
+	w := httptest.NewRecorder()
+	sessions.Issue(w, httptest.NewRequest(http.MethodGet, "/", nil), userID)
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("Issue() cookies = %v, want 1", cookies)
+	}
+	return cookies[0]
+}
+
+func TestCookieSessions(t *testing.T) {
+	now := time.Now()
+	sessions := NewCookieSessions([]byte("secret"), time.Hour)
+	sessions.now = func() time.Time { return now }
+	cookie := issueCookie(t, sessions, "user_1")
+
+	if !cookie.HttpOnly || cookie.SameSite != http.SameSiteLaxMode {
+		t.Errorf("Issue() HttpOnly = %v, SameSite = %v, want HttpOnly Lax cookie", cookie.HttpOnly, cookie.SameSite)
+	}
+
+	tampered := *cookie
+	tampered.Value = strings.Replace(cookie.Value, cookie.Value[:4], "AAAA", 1)
+
+	tests := []struct {
+		name   string
+		cookie *http.Cookie
+		now    time.Time
+		want   domain.UserID
+		wantOK bool
+	}{
+		{name: "valid", cookie: cookie, now: now, want: "user_1", wantOK: true},
+		{name: "expired", cookie: cookie, now: now.Add(2 * time.Hour)},
+		{name: "tampered", cookie: &tampered, now: now},
+		{name: "garbage", cookie: &http.Cookie{Name: sessionCookieName, Value: "garbage"}, now: now},
+		{name: "no cookie", now: now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions.now = func() time.Time { return tt.now }
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+			got, ok := sessions.Read(req)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Read() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	t.Run("other secret", func(t *testing.T) {
+		other := NewCookieSessions([]byte("other"), time.Hour)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(cookie)
+		if _, ok := other.Read(req); ok {
+			t.Error("Read() accepted a cookie signed with another secret")
+		}
+	})
+}
+
+func TestHandlers_RequireLogin(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	handlers.defaultUserID = ""
+	router := handlers.RequireLogin(NewRouter(handlers))
+	cookie := issueCookie(t, handlers.cookieSessions, "user_1")
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		cookie       *http.Cookie
+		wantStatus   int
+		wantLocation string
+	}{
+		{name: "page redirects to login", method: http.MethodGet, path: "/texts/text_1", wantStatus: http.StatusSeeOther, wantLocation: "/login?next=%2Ftexts%2Ftext_1"},
+		{name: "API returns 401", method: http.MethodGet, path: "/api/texts", wantStatus: http.StatusUnauthorized},
+		{name: "login page is public", method: http.MethodGet, path: "/login", wantStatus: http.StatusOK},
+		{name: "authenticated page", method: http.MethodGet, path: "/", cookie: cookie, wantStatus: http.StatusOK},
+		{name: "authenticated API", method: http.MethodGet, path: "/api/texts", cookie: cookie, wantStatus: http.StatusOK},
+		{name: "logout clears cookie", method: http.MethodPost, path: "/logout", cookie: cookie, wantStatus: http.StatusSeeOther, wantLocation: "/login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("RequireLogin() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if tt.wantLocation != "" && w.Header().Get("Location") != tt.wantLocation {
+				t.Errorf("RequireLogin() Location = %v, want %v", w.Header().Get("Location"), tt.wantLocation)
+			}
+		})
+	}
+}
+
+func TestSafeRedirectPath(t *testing.T) {
+	tests := []struct {
+		next string
+		want string
+	}{
+		{next: "/texts/text_1", want: "/texts/text_1"},
+		{next: "", want: "/"},
+		{next: "https://evil.example", want: "/"},
+		{next: "//evil.example", want: "/"},
+		{next: "/\\evil.example", want: "/"},
+	}
+
+	for _, tt := range tests {
+		if got := safeRedirectPath(tt.next); got != tt.want {
+			t.Errorf("safeRedirectPath(%q) = %v, want %v", tt.next, got, tt.want)
+		}
+	}
+}
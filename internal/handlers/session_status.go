@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"typeten/internal/domain"
+	"typeten/internal/usecases"
+)
+
+// sessionTransitions maps the action suffix of POST /api/sessions/:id/{action}
+// to the status the session moves to.
+var sessionTransitions = map[string]domain.SessionStatus{
+	"pause":    domain.SessionPaused,
+	"resume":   domain.SessionActive,
+	"complete": domain.SessionCompleted,
+	"abandon":  domain.SessionAbandoned,
+}
+
+// sessionTransition returns the action of a session transition path like
+// "/api/sessions/abc123/pause" and whether it is one.
+func sessionTransition(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, "/api/sessions/")
+	if !ok {
+		return "", false
+	}
+	i := strings.LastIndex(rest, "/")
+	if i <= 0 {
+		return "", false
+	}
+	action := rest[i+1:]
+	_, ok = sessionTransitions[action]
+	return action, ok
+}
+
+// isSessionTransition reports whether path is a session transition path.
+func isSessionTransition(path string) bool {
+	_, ok := sessionTransition(path)
+	return ok
+}
+
+// ChangeSessionStatus handles POST /api/sessions/:id/pause, /resume, /complete and /abandon
+func (h *Handlers) ChangeSessionStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	action, ok := sessionTransition(r.URL.Path)
+	if !ok {
+		respondError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	// Extract session ID from path like "/api/sessions/abc123/pause"
+	sessionID := strings.TrimPrefix(r.URL.Path, "/api/sessions/")
+	sessionID = strings.TrimSuffix(sessionID, "/"+action)
+
+	output, err := h.changeStatusUseCase.Execute(r.Context(), usecases.ChangeSessionStatusInput{
+		UserID:    userID,
+		SessionID: domain.SessionID(sessionID),
+		Status:    sessionTransitions[action],
+	})
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, sessionToResponse(output.Session))
+}
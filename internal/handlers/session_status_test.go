@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"typeten/internal/usecases"
+)
+
+func TestHandlers_ChangeSessionStatus(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Test Text",
+		Content: "line1\nline2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+		UserID: handlers.defaultUserID,
+		TextID: textOutput.TextInfo.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+	sessionID := string(sessionOutput.Session.ID)
+
+	// Steps run in order against the same session.
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantState  string
+	}{
+		{name: "resume active session", path: "/api/sessions/" + sessionID + "/resume", wantStatus: http.StatusConflict},
+		{name: "pause", path: "/api/sessions/" + sessionID + "/pause", wantStatus: http.StatusOK, wantState: "paused"},
+		{name: "pause again", path: "/api/sessions/" + sessionID + "/pause", wantStatus: http.StatusConflict},
+		{name: "progress while paused", path: "/api/sessions/" + sessionID + "/progress", body: `{"typed":"line1","elapsed_ms":1000}`, wantStatus: http.StatusConflict},
+		{name: "resume", path: "/api/sessions/" + sessionID + "/resume", wantStatus: http.StatusOK, wantState: "active"},
+		{name: "abandon", path: "/api/sessions/" + sessionID + "/abandon", wantStatus: http.StatusOK, wantState: "abandoned"},
+		{name: "complete abandoned session", path: "/api/sessions/" + sessionID + "/complete", wantStatus: http.StatusConflict},
+		{name: "unknown session", path: "/api/sessions/nonexistent/pause", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("ChangeSessionStatus() status = %v, want %v; body: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantState == "" {
+				return
+			}
+			var resp GetSessionResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Status != tt.wantState {
+				t.Errorf("ChangeSessionStatus() status = %q, want %q", resp.Status, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestHandlers_ChangeSessionStatus_Complete(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Test Text",
+		Content: "line1\nline2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+		UserID: handlers.defaultUserID,
+		TextID: textOutput.TextInfo.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/sessions/"+string(sessionOutput.Session.ID)+"/complete", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("ChangeSessionStatus() status = %v, want %v", w.Code, http.StatusOK)
+	}
+	var resp GetSessionResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Status != "completed" || !resp.IsCompleted {
+		t.Errorf("ChangeSessionStatus() = %+v, want a completed session", resp)
+	}
+}
@@ -0,0 +1,508 @@
+package handlers
+
+import (
+	"html/template"
+	"math"
+	"net/http"
+	"time"
+
+	"typeten/internal/domain"
+	"typeten/internal/usecases"
+)
+
+var statsTpl = template.Must(template.New("stats").Parse(statsHTML))
+
+type statsViewModel struct {
+	Period   string
+	From     string
+	To       string
+	Total    usecases.ProgressBucket
+	Buckets  []usecases.ProgressBucket
+	WPM      lineChart
+	Accuracy lineChart
+	Practice lineChart
+
+	Keyboards   []keyboardView
+	HeatmapMax  int
+	MissedKeys  []usecases.KeyStat
+	SlowBigrams []usecases.BigramStat
+}
+
+// statsTopN bounds the tables of most missed keys and slowest bigrams on the stats page.
+const statsTopN = 10
+
+// parseProgressStatsQuery reads period, from and to from the query string.
+func parseProgressStatsQuery(r *http.Request, userID domain.UserID) (usecases.GetProgressStatsInput, error) {
+	q := r.URL.Query()
+	from, to, err := parseTimeRange(q)
+	if err != nil {
+		return usecases.GetProgressStatsInput{}, err
+	}
+	return usecases.GetProgressStatsInput{
+		UserID: userID,
+		Period: usecases.StatsPeriod(q.Get("period")),
+		From:   from,
+		To:     to,
+	}, nil
+}
+
+// GetProgressStats handles GET /api/stats/progress
+func (h *Handlers) GetProgressStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	input, err := parseProgressStatsQuery(r, userID)
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	output, err := h.progressStatsUseCase.Execute(r.Context(), input)
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	resp := ProgressStatsResponse{
+		Period:  string(output.Period),
+		Buckets: make([]ProgressBucketResponse, len(output.Buckets)),
+		Total:   progressBucketToResponse(output.Total),
+	}
+	for i, bucket := range output.Buckets {
+		resp.Buckets[i] = progressBucketToResponse(bucket)
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetKeyStats handles GET /api/stats/keys
+func (h *Handlers) GetKeyStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	from, to, err := parseTimeRange(r.URL.Query())
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	output, err := h.keyStatsUseCase.Execute(r.Context(), usecases.GetKeyStatsInput{
+		UserID: userID,
+		From:   from,
+		To:     to,
+	})
+	if err != nil {
+		respondUseCaseError(w, err)
+		return
+	}
+
+	resp := KeyStatsResponse{
+		Keys:    make([]KeyStatResponse, len(output.Keys)),
+		Bigrams: make([]BigramStatResponse, len(output.Bigrams)),
+	}
+	for i, key := range output.Keys {
+		resp.Keys[i] = KeyStatResponse{
+			Char:         key.Char,
+			Attempts:     key.Attempts,
+			Errors:       key.Errors,
+			ErrorPercent: key.ErrorPercent,
+		}
+	}
+	for i, bigram := range output.Bigrams {
+		resp.Bigrams[i] = BigramStatResponse{
+			Bigram:           bigram.Bigram,
+			Count:            bigram.Count,
+			AverageLatencyMs: float64(bigram.AverageLatency) / float64(time.Millisecond),
+		}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// StatsPage renders progress charts and the key error heatmap for the user's sessions.
+func (h *Handlers) StatsPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := h.currentUser(r)
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	input, err := parseProgressStatsQuery(r, userID)
+	if err != nil {
+		respondPageError(w, err)
+		return
+	}
+	out, err := h.progressStatsUseCase.Execute(r.Context(), input)
+	if err != nil {
+		respondPageError(w, err)
+		return
+	}
+	keys, err := h.keyStatsUseCase.Execute(r.Context(), usecases.GetKeyStatsInput{
+		UserID: userID,
+		From:   input.From,
+		To:     input.To,
+	})
+	if err != nil {
+		respondPageError(w, err)
+		return
+	}
+
+	q := r.URL.Query()
+	vm := statsViewModel{
+		Period:  string(out.Period),
+		From:    q.Get("from"),
+		To:      q.Get("to"),
+		Total:   out.Total,
+		Buckets: out.Buckets,
+
+		Keyboards:  buildKeyboards(keys.Keys),
+		HeatmapMax: heatmapMaxErrorPercent,
+	}
+	for _, key := range keys.Keys {
+		if key.Errors == 0 || len(vm.MissedKeys) == statsTopN {
+			break
+		}
+		vm.MissedKeys = append(vm.MissedKeys, key)
+	}
+	vm.SlowBigrams = keys.Bigrams[:min(len(keys.Bigrams), statsTopN)]
+	labels := make([]string, len(out.Buckets))
+	avgWPM := make([]float64, len(out.Buckets))
+	bestWPM := make([]float64, len(out.Buckets))
+	accuracy := make([]float64, len(out.Buckets))
+	minutes := make([]float64, len(out.Buckets))
+	for i, bucket := range out.Buckets {
+		labels[i] = bucket.Start.Format("Jan 2")
+		minutes[i] = bucket.PracticeTime.Minutes()
+		if bucket.Sessions == 0 {
+			avgWPM[i], bestWPM[i], accuracy[i] = math.NaN(), math.NaN(), math.NaN()
+			continue
+		}
+		avgWPM[i], bestWPM[i], accuracy[i] = bucket.AverageWPM, bucket.BestWPM, bucket.AccuracyPercent
+	}
+	vm.WPM = lineChart{Title: "Words per minute", Labels: labels, Series: []chartSeries{
+		{Name: "Average", Color: "#818cf8", Values: avgWPM},
+		{Name: "Best", Color: "#22c55e", Values: bestWPM},
+	}}
+	vm.Accuracy = lineChart{Title: "Accuracy", Labels: labels, YMax: 100, Series: []chartSeries{
+		{Name: "Accuracy", Color: "#f59e0b", Values: accuracy},
+	}}
+	vm.Practice = lineChart{Title: "Minutes practiced", Labels: labels, Series: []chartSeries{
+		{Name: "Minutes", Color: "#38bdf8", Values: minutes},
+	}}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := statsTpl.Execute(w, vm); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
+func progressBucketToResponse(bucket usecases.ProgressBucket) ProgressBucketResponse {
+	return ProgressBucketResponse{
+		Start:           bucket.Start.Format(dateLayout),
+		Sessions:        bucket.Sessions,
+		Lines:           bucket.Lines,
+		AverageWPM:      bucket.AverageWPM,
+		BestWPM:         bucket.BestWPM,
+		AccuracyPercent: bucket.AccuracyPercent,
+		PracticeMinutes: bucket.PracticeTime.Round(time.Second).Minutes(),
+	}
+}
+
+const statsHTML = `<!doctype html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Statistics · TypeTen</title>
+  <style>
+    body {
+      font-family: system-ui, -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif;
+      margin: 0;
+      padding: 0;
+      background: #0f172a;
+      color: #e5e7eb;
+    }
+    header {
+      padding: 1.25rem 2rem;
+      background: #020617;
+      border-bottom: 1px solid #1f2937;
+      display: flex;
+      justify-content: space-between;
+      align-items: center;
+    }
+    header a {
+      color: #9ca3af;
+      text-decoration: none;
+      font-size: 0.85rem;
+    }
+    header a:hover {
+      color: #e5e7eb;
+    }
+    main {
+      max-width: 960px;
+      margin: 2rem auto;
+      padding: 0 1.5rem 3rem;
+      display: grid;
+      gap: 1.5rem;
+    }
+    .card {
+      background: #020617;
+      border-radius: 0.75rem;
+      border: 1px solid #1f2937;
+      padding: 1.25rem 1.5rem;
+      box-shadow: 0 18px 40px rgba(15, 23, 42, 0.6);
+    }
+    h1 {
+      margin: 0 0 1rem;
+      font-size: 1.3rem;
+    }
+    h2 {
+      margin: 0 0 0.5rem;
+      font-size: 1rem;
+    }
+    form.filters {
+      display: flex;
+      flex-wrap: wrap;
+      gap: 0.75rem;
+      align-items: flex-end;
+    }
+    label {
+      display: block;
+      font-size: 0.75rem;
+      color: #9ca3af;
+      margin-bottom: 0.25rem;
+    }
+    select, input[type="date"] {
+      border-radius: 0.5rem;
+      border: 1px solid #1f2937;
+      background: #020617;
+      color: #e5e7eb;
+      padding: 0.4rem 0.6rem;
+      font-size: 0.85rem;
+    }
+    button {
+      border: none;
+      border-radius: 999px;
+      padding: 0.45rem 1.1rem;
+      background: linear-gradient(135deg, #4f46e5, #7c3aed);
+      color: white;
+      font-size: 0.85rem;
+      font-weight: 500;
+      cursor: pointer;
+    }
+    .totals {
+      display: grid;
+      grid-template-columns: repeat(4, minmax(0, 1fr));
+      gap: 0.75rem 1.5rem;
+      margin-top: 1rem;
+    }
+    .stat-label {
+      font-size: 0.75rem;
+      color: #9ca3af;
+      text-transform: uppercase;
+      letter-spacing: 0.05em;
+    }
+    .stat-value {
+      font-size: 1.3rem;
+      font-weight: 600;
+    }
+    .chart {
+      width: 100%;
+      height: auto;
+    }
+    .legend {
+      font-size: 0.75rem;
+      color: #9ca3af;
+    }
+    .legend span {
+      margin-right: 1rem;
+    }
+    .keyboard {
+      display: grid;
+      gap: 0.3rem;
+      margin: 0.75rem 0 1.25rem;
+    }
+    .kb-row {
+      display: flex;
+      gap: 0.3rem;
+    }
+    .kb-row:nth-child(2) { margin-left: 1rem; }
+    .kb-row:nth-child(3) { margin-left: 1.6rem; }
+    .kb-row:nth-child(4) { margin-left: 2.4rem; }
+    .key {
+      width: 2.2rem;
+      height: 2.2rem;
+      border-radius: 0.35rem;
+      display: flex;
+      align-items: center;
+      justify-content: center;
+      font-family: "JetBrains Mono", "Fira Code", ui-monospace, monospace;
+      font-size: 0.85rem;
+    }
+    .key.space {
+      width: 14rem;
+      margin-left: 6rem;
+    }
+    table {
+      width: 100%;
+      border-collapse: collapse;
+      font-size: 0.85rem;
+    }
+    th, td {
+      text-align: left;
+      padding: 0.35rem 0.5rem;
+      border-bottom: 1px solid #1f2937;
+    }
+    th {
+      color: #9ca3af;
+      font-weight: 500;
+    }
+    .tables {
+      display: grid;
+      grid-template-columns: repeat(2, minmax(0, 1fr));
+      gap: 1.5rem;
+    }
+    .drill {
+      display: flex;
+      align-items: center;
+      gap: 0.75rem;
+      margin-bottom: 1.25rem;
+    }
+    .empty {
+      font-size: 0.85rem;
+      color: #6b7280;
+    }
+  </style>
+</head>
+<body>
+  <header>
+    <a href="/">&larr; Back to texts</a>
+    <a href="/history">History</a>
+  </header>
+  <main>
+    <section class="card">
+      <h1>Statistics</h1>
+      <form class="filters" method="get" action="/stats">
+        <div>
+          <label for="period">Group by</label>
+          <select id="period" name="period">
+            <option value="day"{{if eq .Period "day"}} selected{{end}}>Day</option>
+            <option value="week"{{if eq .Period "week"}} selected{{end}}>Week</option>
+          </select>
+        </div>
+        <div>
+          <label for="from">From</label>
+          <input id="from" name="from" type="date" value="{{.From}}">
+        </div>
+        <div>
+          <label for="to">To</label>
+          <input id="to" name="to" type="date" value="{{.To}}">
+        </div>
+        <button type="submit">Show</button>
+      </form>
+      <div class="totals">
+        <div>
+          <div class="stat-label">Sessions</div>
+          <div class="stat-value">{{.Total.Sessions}}</div>
+        </div>
+        <div>
+          <div class="stat-label">Lines typed</div>
+          <div class="stat-value">{{.Total.Lines}}</div>
+        </div>
+        <div>
+          <div class="stat-label">Average WPM</div>
+          <div class="stat-value">{{printf "%.1f" .Total.AverageWPM}}</div>
+        </div>
+        <div>
+          <div class="stat-label">Minutes practiced</div>
+          <div class="stat-value">{{printf "%.0f" .Total.PracticeTime.Minutes}}</div>
+        </div>
+      </div>
+    </section>
+    {{if .Buckets}}
+    <section class="card">
+      <h2>Words per minute</h2>
+      <div class="legend"><span style="color:#818cf8">&#9679; Average</span><span style="color:#22c55e">&#9679; Best</span></div>
+      {{.WPM.SVG}}
+    </section>
+    <section class="card">
+      <h2>Accuracy, %</h2>
+      {{.Accuracy.SVG}}
+    </section>
+    <section class="card">
+      <h2>Minutes practiced</h2>
+      {{.Practice.SVG}}
+    </section>
+    {{else}}
+    <section class="card">
+      <p class="empty">No completed lines in this period yet. Finish a few lines to see your progress.</p>
+    </section>
+    {{end}}
+    <section class="card">
+      <h2>Key errors</h2>
+      <div class="legend">Green keys are rarely missed, red keys are missed in {{.HeatmapMax}}% of presses or more. Hover a key for details.</div>
+      {{range .Keyboards}}
+      <div class="keyboard" aria-label="{{.Name}} layout">
+        <div class="stat-label">{{.Name}}</div>
+        {{range .Rows}}
+        <div class="kb-row">{{range .}}<span class="key" style="background: {{.Color}}" title="{{.Title}}">{{.Label}}</span>{{end}}</div>
+        {{end}}
+        <div class="kb-row"><span class="key space" style="background: {{.Space.Color}}" title="{{.Space.Title}}">{{.Space.Label}}</span></div>
+      </div>
+      {{end}}
+      {{if or .MissedKeys .SlowBigrams}}
+      <form class="drill" method="post" action="/drills">
+        <button type="submit">Practice these keys</button>
+        <span class="legend">Builds a short text from your own texts, dense in the keys and pairs below, and starts a session on it.</span>
+      </form>
+      {{end}}
+      <div class="tables">
+        <div>
+          <h2>Most missed keys</h2>
+          {{if .MissedKeys}}
+          <table>
+            <tr><th>Key</th><th>Missed</th><th>Error rate</th></tr>
+            {{range .MissedKeys}}
+            <tr><td>{{if eq .Char " "}}space{{else}}{{.Char}}{{end}}</td><td>{{.Errors}} of {{.Attempts}}</td><td>{{printf "%.1f" .ErrorPercent}}%</td></tr>
+            {{end}}
+          </table>
+          {{else}}
+          <p class="empty">No missed keys yet.</p>
+          {{end}}
+        </div>
+        <div>
+          <h2>Slowest letter pairs</h2>
+          {{if .SlowBigrams}}
+          <table>
+            <tr><th>Pair</th><th>Times</th><th>Average</th></tr>
+            {{range .SlowBigrams}}
+            <tr><td>{{.Bigram}}</td><td>{{.Count}}</td><td>{{.AverageLatency.Milliseconds}} ms</td></tr>
+            {{end}}
+          </table>
+          {{else}}
+          <p class="empty">Letter pair timing needs captured keystrokes.</p>
+          {{end}}
+        </div>
+      </div>
+    </section>
+  </main>
+</body>
+</html>`
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"typeten/internal/usecases"
+)
+
+func TestHandlers_GetProgressStats(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Stats Text",
+		Content: "line1\nline2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+		UserID: handlers.defaultUserID,
+		TextID: textOutput.TextInfo.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+	if _, err := handlers.recordProgressUseCase.Execute(ctx, usecases.RecordProgressInput{
+		UserID:    handlers.defaultUserID,
+		SessionID: string(sessionOutput.Session.ID),
+		Typed:     "line1",
+		Elapsed:   2 * time.Second,
+	}); err != nil {
+		t.Fatalf("Failed to record progress: %v", err)
+	}
+	today := time.Now().UTC().Format(dateLayout)
+
+	tests := []struct {
+		name        string
+		query       string
+		wantStatus  int
+		wantPeriod  string
+		wantBuckets int
+	}{
+		{name: "default period", query: "", wantStatus: http.StatusOK, wantPeriod: "day", wantBuckets: 1},
+		{name: "weekly", query: "?period=week", wantStatus: http.StatusOK, wantPeriod: "week", wantBuckets: 1},
+		{name: "future range", query: "?from=2999-01-01", wantStatus: http.StatusOK, wantPeriod: "day", wantBuckets: 0},
+		{name: "unknown period", query: "?period=month", wantStatus: http.StatusUnprocessableEntity},
+		{name: "invalid date", query: "?to=tomorrow", wantStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/stats/progress"+tt.query, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("GetProgressStats() status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp ProgressStatsResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Period != tt.wantPeriod {
+				t.Errorf("GetProgressStats() Period = %v, want %v", resp.Period, tt.wantPeriod)
+			}
+			if len(resp.Buckets) != tt.wantBuckets {
+				t.Fatalf("GetProgressStats() returned %d buckets, want %d", len(resp.Buckets), tt.wantBuckets)
+			}
+			if tt.wantBuckets == 0 {
+				return
+			}
+			if tt.wantPeriod == "day" && resp.Buckets[0].Start != today {
+				t.Errorf("GetProgressStats() bucket start = %v, want %v", resp.Buckets[0].Start, today)
+			}
+			if resp.Total.Sessions != 1 || resp.Total.Lines != 1 {
+				t.Errorf("GetProgressStats() Total = %+v, want 1 session and 1 line", resp.Total)
+			}
+			if resp.Total.AverageWPM != 30 || resp.Total.AccuracyPercent != 100 {
+				t.Errorf("GetProgressStats() Total = %+v, want 30 WPM at 100%% accuracy", resp.Total)
+			}
+		})
+	}
+
+	t.Run("stats page", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats?period=week", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("StatsPage() status = %v, want %v", w.Code, http.StatusOK)
+		}
+		body := w.Body.String()
+		if got := strings.Count(body, "<svg"); got != 3 {
+			t.Errorf("StatsPage() rendered %d charts, want 3", got)
+		}
+		if !strings.Contains(body, `<option value="week" selected>`) {
+			t.Errorf("StatsPage() body does not select the weekly period")
+		}
+	})
+}
+
+func TestHandlers_GetKeyStats(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Keys Text",
+		Content: "ab\nfff",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+		UserID: handlers.defaultUserID,
+		TextID: textOutput.TextInfo.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+	sessionID := string(sessionOutput.Session.ID)
+	if _, err := handlers.recordKeystrokesUseCase.Execute(ctx, usecases.RecordKeystrokesInput{
+		UserID:    handlers.defaultUserID,
+		SessionID: sessionOutput.Session.ID,
+		LineIdx:   0,
+		Events: []usecases.KeystrokeInput{
+			{Key: "a", Expected: "a", OffsetMs: 0},
+			{Key: "b", Expected: "b", OffsetMs: 150},
+		},
+	}); err != nil {
+		t.Fatalf("Failed to record keystrokes: %v", err)
+	}
+	for _, typed := range []string{"ab", "fgf"} {
+		if _, err := handlers.recordProgressUseCase.Execute(ctx, usecases.RecordProgressInput{
+			UserID:    handlers.defaultUserID,
+			SessionID: sessionID,
+			Typed:     typed,
+			Elapsed:   time.Second,
+		}); err != nil {
+			t.Fatalf("Failed to record progress: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/stats/keys", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GetKeyStats() status = %v, want %v", w.Code, http.StatusOK)
+	}
+	var resp KeyStatsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Keys) != 3 {
+		t.Fatalf("GetKeyStats() returned %d keys, want 3", len(resp.Keys))
+	}
+	if first := resp.Keys[0]; first.Char != "f" || first.Attempts != 3 || first.Errors != 1 {
+		t.Errorf("GetKeyStats() first key = %+v, want f missed 1 of 3", first)
+	}
+	if len(resp.Bigrams) != 1 || resp.Bigrams[0].Bigram != "ab" || resp.Bigrams[0].AverageLatencyMs != 150 {
+		t.Errorf("GetKeyStats() Bigrams = %+v, want ab at 150 ms", resp.Bigrams)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/stats/keys?from=soon", nil))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("GetKeyStats() invalid date status = %v, want %v", w.Code, http.StatusUnprocessableEntity)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("StatsPage() status = %v, want %v", w.Code, http.StatusOK)
+	}
+	body := w.Body.String()
+	for _, want := range []string{"QWERTY", "ЙЦУКЕН", `title="f: 1 of 3 missed (33.3%)"`, "<td>ab</td>"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("StatsPage() body does not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"typeten/internal/domain"
+)
+
+// normalizationLabels describe the normalization steps on the upload form.
+var normalizationLabels = map[domain.Normalization]string{
+	domain.NormalizeLineEndings: "Windows and old Mac line endings",
+	domain.NormalizeUnicode:     "Unicode NFC (combine accents with their letters)",
+	domain.NormalizeTypography:  "Typographic quotes, dashes, ellipses and special spaces to plain ones",
+	domain.NormalizeYo:          "Replace ё with е",
+	domain.NormalizeWhitespace:  "Collapse repeated spaces and tabs",
+	domain.NormalizeBlankLines:  "Remove blank lines",
+}
+
+// normalizationOption is a checkbox of the upload form.
+type normalizationOption struct {
+	Value   string
+	Label   string
+	Checked bool
+}
+
+// normalizationOptions returns a checkbox per normalization step, with the default steps checked.
+func normalizationOptions() []normalizationOption {
+	defaults := make(map[domain.Normalization]bool)
+	for _, step := range domain.DefaultNormalization() {
+		defaults[step] = true
+	}
+	var options []normalizationOption
+	for _, step := range domain.AllNormalizations() {
+		options = append(options, normalizationOption{Value: string(step), Label: normalizationLabels[step], Checked: defaults[step]})
+	}
+	return options
+}
+
+// formNormalization returns the "normalize" values of a parsed form, or nil if the
+// form has none so that the default steps apply. The upload form sends an empty
+// "normalize" value, so unchecking every box selects no steps.
+func formNormalization(r *http.Request) []string {
+	values, ok := r.Form["normalize"]
+	if !ok {
+		return nil
+	}
+	return append([]string{}, values...)
+}
+
+func normalizationToResponse(steps []domain.Normalization) []string {
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = string(step)
+	}
+	return names
+}
+
+// formWrap reads the "wrap_width" and "wrap_sentences" fields of a parsed form.
+// An empty width means no width limit.
+func formWrap(r *http.Request) (width int, sentences bool, err error) {
+	if v := strings.TrimSpace(r.FormValue("wrap_width")); v != "" {
+		width, err = strconv.Atoi(v)
+		if err != nil {
+			return 0, false, domain.NewFieldError(domain.ErrInvalidTextInfo, "wrap_width", "must be a number")
+		}
+	}
+	sentences, _ = strconv.ParseBool(r.FormValue("wrap_sentences"))
+	return width, sentences, nil
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"typeten/internal/usecases"
+)
+
+func TestHandlers_CreateText_Normalization(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantSteps  []string
+	}{
+		{
+			name:       "default steps",
+			body:       `{"title":"T","content":"a\r\n\r\nb"}`,
+			wantStatus: http.StatusCreated,
+			wantSteps:  []string{"line_endings", "nfc", "typography", "whitespace", "blank_lines"},
+		},
+		{
+			name:       "no steps",
+			body:       `{"title":"T","content":"a","normalize":[]}`,
+			wantStatus: http.StatusCreated,
+			wantSteps:  []string{},
+		},
+		{
+			name:       "chosen steps",
+			body:       `{"title":"T","content":"ёж","normalize":["yo","nfc"]}`,
+			wantStatus: http.StatusCreated,
+			wantSteps:  []string{"nfc", "yo"},
+		},
+		{
+			name:       "unknown step",
+			body:       `{"title":"T","content":"a","normalize":["lowercase"]}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/texts", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("CreateText() status = %v, want %v; body: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			var resp CreateTextResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !reflect.DeepEqual(resp.Normalization, tt.wantSteps) {
+				t.Errorf("CreateText() normalization = %v, want %v", resp.Normalization, tt.wantSteps)
+			}
+		})
+	}
+}
+
+func TestHandlers_CreateTextHTML_Normalization(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	// The form sends an empty value besides the checked boxes; here only ё is replaced.
+	form := url.Values{"title": {"Ёж"}, "content": {"«ёж»"}, "normalize": {"", "yo"}}
+	req := httptest.NewRequest(http.MethodPost, "/texts", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("CreateTextHTML() status = %v, want %v; body: %s", w.Code, http.StatusSeeOther, w.Body.String())
+	}
+
+	out, err := handlers.listTextsUseCase.Execute(req.Context(), usecases.ListTextsInput{UserID: handlers.defaultUserID})
+	if err != nil || len(out.Texts) != 1 {
+		t.Fatalf("ListTexts() = %+v, %v, want one text", out, err)
+	}
+	fragments, err := handlers.getTextFragmentsUseCase.Execute(req.Context(), usecases.GetTextFragmentsInput{TextID: out.Texts[0].ID, UserID: handlers.defaultUserID})
+	if err != nil {
+		t.Fatalf("GetTextFragments() error = %v", err)
+	}
+	if lines := fragments.Fragments[0].Lines(); !reflect.DeepEqual(lines, []string{"«еж»"}) {
+		t.Errorf("CreateTextHTML() lines = %q, want %q", lines, []string{"«еж»"})
+	}
+}
+
+func TestHandlers_CreateText_Wrap(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	body := `{"title":"T","content":"Short one. This sentence is a bit longer than twenty.","wrap_width":20,"wrap_sentences":true}`
+	req := httptest.NewRequest(http.MethodPost, "/api/texts", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("CreateText() status = %v, want %v; body: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+	var resp CreateTextResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.TotalLines != 4 || resp.WrapWidth != 20 || !resp.WrapSentences {
+		t.Errorf("CreateText() = %+v, want 4 lines wrapped at 20 by sentence", resp)
+	}
+
+	for name, form := range map[string]url.Values{
+		"width out of range": {"title": {"T"}, "content": {"text"}, "wrap_width": {"5"}},
+		"width not a number": {"title": {"T"}, "content": {"text"}, "wrap_width": {"wide"}},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/texts", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("%s: CreateTextHTML() status = %v, want %v", name, w.Code, http.StatusUnprocessableEntity)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"html/template"
 	"net/http"
+	"strconv"
 
 	"typeten/internal/domain"
 	"typeten/internal/usecases"
@@ -14,16 +15,54 @@ var (
 	sessionTpl = template.Must(template.New("session").Parse(sessionHTML))
 )
 
+// continueSessionsLimit caps the "continue" list on the index page.
+const continueSessionsLimit = 5
+
 type indexViewModel struct {
-	Texts []*domain.TextInfo
+	Texts         []*domain.TextInfo
+	Unfinished    []unfinishedSessionView
+	UploadAccept  string // file extensions accepted by the upload field
+	Normalization []normalizationOption
+	MinWrapWidth  int
+	MaxWrapWidth  int
+}
+
+// unfinishedSessionView pairs a resumable session with the title of its text.
+type unfinishedSessionView struct {
+	Session *domain.Session
+	Title   string
 }
 
 type textViewModel struct {
-	Text *domain.TextInfo
+	Text     *domain.TextInfo
+	Chapters []chapterView
+}
+
+// chapterView is a chapter of a book with the one-based number of its first line.
+type chapterView struct {
+	Title string
+	Line  int
+}
+
+func chapterViews(chapters []domain.TextChapter) []chapterView {
+	views := make([]chapterView, len(chapters))
+	for i, c := range chapters {
+		views[i] = chapterView{Title: c.Title, Line: c.Line + 1}
+	}
+	return views
 }
 
 type sessionViewModel struct {
-	Session *domain.Session
+	Session       *domain.Session
+	Lines         []lineResultView // shown once the session is completed
+	MaxKeystrokes int              // per-line batch limit of the keystrokes API
+	RemainingMs   int64            // typing time left in a minutes session
+}
+
+// lineResultView pairs a line result with its one-based line number in the text.
+type lineResultView struct {
+	Number int
+	Result *domain.LineResult
 }
 
 // IndexPage renders the main page with list of texts and a form to add a new one.
@@ -33,42 +72,105 @@ func (h *Handlers) IndexPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := h.currentUser(r)
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
 	out, err := h.listTextsUseCase.Execute(r.Context(), usecases.ListTextsInput{
-		UserID: h.currentUserID,
+		UserID: userID,
 	})
 	if err != nil {
-		http.Error(w, "Failed to load texts: "+err.Error(), http.StatusInternalServerError)
+		respondPageError(w, err)
 		return
 	}
 
+	unfinished, err := h.listUnfinishedUseCase.Execute(r.Context(), usecases.ListUnfinishedSessionsInput{
+		UserID: userID,
+		Limit:  continueSessionsLimit,
+	})
+	if err != nil {
+		respondPageError(w, err)
+		return
+	}
+
+	titles := make(map[domain.TextID]string, len(out.Texts))
+	for _, t := range out.Texts {
+		titles[t.ID] = t.Title
+	}
+	vm := indexViewModel{
+		Texts:         out.Texts,
+		UploadAccept:  h.uploadAccept(),
+		Normalization: normalizationOptions(),
+		MinWrapWidth:  domain.MinWrapWidth,
+		MaxWrapWidth:  domain.MaxWrapWidth,
+	}
+	for _, session := range unfinished.Sessions {
+		vm.Unfinished = append(vm.Unfinished, unfinishedSessionView{Session: session, Title: titles[session.TextID]})
+	}
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := indexTpl.Execute(w, indexViewModel{Texts: out.Texts}); err != nil {
+	if err := indexTpl.Execute(w, vm); err != nil {
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
 	}
 }
 
 // CreateTextHTML handles form submission for creating a text and redirects back to index.
+// An uploaded file takes precedence over the pasted content.
 func (h *Handlers) CreateTextHTML(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	if err := r.ParseForm(); err != nil {
+	userID, ok := h.currentUser(r)
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if isMultipart(r) {
+		input, err := readUpload(w, r, userID)
+		if err != nil {
+			if !isFieldError(err) {
+				http.Error(w, "Invalid form data", http.StatusBadRequest)
+				return
+			}
+			respondPageError(w, err)
+			return
+		}
+		if input.Data != nil {
+			if _, err := h.importTextUseCase.Execute(r.Context(), input); err != nil {
+				respondPageError(w, err)
+				return
+			}
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+	} else if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
 		return
 	}
 
 	title := r.FormValue("title")
 	content := r.FormValue("content")
+	wrapWidth, wrapSentences, err := formWrap(r)
+	if err != nil {
+		respondPageError(w, err)
+		return
+	}
 
-	_, err := h.createTextUseCase.Execute(r.Context(), usecases.CreateTextInput{
-		UserID:  h.currentUserID,
-		Title:   title,
-		Content: content,
+	_, err = h.createTextUseCase.Execute(r.Context(), usecases.CreateTextInput{
+		UserID:        userID,
+		Title:         title,
+		Content:       content,
+		Normalization: formNormalization(r),
+		WrapWidth:     wrapWidth,
+		WrapSentences: wrapSentences,
 	})
 	if err != nil {
-		http.Error(w, "Failed to create text: "+err.Error(), http.StatusInternalServerError)
+		respondPageError(w, err)
 		return
 	}
 
@@ -83,11 +185,17 @@ func (h *Handlers) TextDetailPage(w http.ResponseWriter, r *http.Request, textID
 	}
 
 	// For MVP, reuse ListTexts use case and filter by ID.
+	userID, ok := h.currentUser(r)
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
 	out, err := h.listTextsUseCase.Execute(r.Context(), usecases.ListTextsInput{
-		UserID: h.currentUserID,
+		UserID: userID,
 	})
 	if err != nil {
-		http.Error(w, "Failed to load texts: "+err.Error(), http.StatusInternalServerError)
+		respondPageError(w, err)
 		return
 	}
 
@@ -104,7 +212,7 @@ func (h *Handlers) TextDetailPage(w http.ResponseWriter, r *http.Request, textID
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := textTpl.Execute(w, textViewModel{Text: found}); err != nil {
+	if err := textTpl.Execute(w, textViewModel{Text: found, Chapters: chapterViews(found.Chapters)}); err != nil {
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
 	}
 }
@@ -116,19 +224,39 @@ func (h *Handlers) CreateSessionHTML(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := h.currentUser(r)
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
 		return
 	}
 
 	textID := r.FormValue("text_id")
+	mode := domain.SessionModeKind(r.FormValue("mode"))
+
+	// The limit field stays on the form for every mode; it only counts for limited ones.
+	limit := 0
+	if v := r.FormValue("mode_limit"); v != "" && mode != "" && mode != domain.SessionModeFullText {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			respondPageError(w, domain.NewFieldError(domain.ErrInvalidSession, "mode_limit", "must be a number"))
+			return
+		}
+		limit = n
+	}
 
 	out, err := h.createSessionUseCase.Execute(r.Context(), usecases.CreateSessionInput{
-		UserID: h.currentUserID,
-		TextID: domain.TextID(textID),
+		UserID:    userID,
+		TextID:    domain.TextID(textID),
+		Mode:      mode,
+		ModeLimit: limit,
 	})
 	if err != nil {
-		http.Error(w, "Failed to create session: "+err.Error(), http.StatusInternalServerError)
+		respondPageError(w, err)
 		return
 	}
 
@@ -142,16 +270,34 @@ func (h *Handlers) SessionPage(w http.ResponseWriter, r *http.Request, sessionID
 		return
 	}
 
-	out, err := h.getSessionUseCase.Execute(r.Context(), usecases.GetSessionInput{
+	userID, ok := h.currentUser(r)
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	out, err := h.listLineResultsUseCase.Execute(r.Context(), usecases.ListLineResultsInput{
+		UserID:    userID,
 		SessionID: domain.SessionID(sessionID),
 	})
 	if err != nil {
-		http.NotFound(w, r)
+		respondPageError(w, err)
 		return
 	}
 
+	vm := sessionViewModel{
+		Session:       out.Session,
+		MaxKeystrokes: usecases.MaxKeystrokesPerBatch,
+		RemainingMs:   out.Session.RemainingTime().Milliseconds(),
+	}
+	if out.Session.IsCompleted() {
+		for _, line := range out.Lines {
+			vm.Lines = append(vm.Lines, lineResultView{Number: line.TextLine(out.Session.FragmentSize) + 1, Result: line})
+		}
+	}
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := sessionTpl.Execute(w, sessionViewModel{Session: out.Session}); err != nil {
+	if err := sessionTpl.Execute(w, vm); err != nil {
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
 	}
 }
@@ -181,6 +327,32 @@ const indexHTML = `<!doctype html>
       margin: 0;
       font-size: 1.4rem;
     }
+    .logout-form button {
+      background: none;
+      border: 1px solid #1f2937;
+      border-radius: 999px;
+      color: #9ca3af;
+      cursor: pointer;
+      font-size: 0.85rem;
+      padding: 0.3rem 0.9rem;
+    }
+    .logout-form button:hover {
+      color: #e5e7eb;
+      border-color: #4b5563;
+    }
+    .nav {
+      display: flex;
+      align-items: center;
+      gap: 1rem;
+    }
+    .nav a {
+      color: #9ca3af;
+      text-decoration: none;
+      font-size: 0.85rem;
+    }
+    .nav a:hover {
+      color: #e5e7eb;
+    }
     main {
       max-width: 960px;
       margin: 2rem auto;
@@ -237,6 +409,25 @@ const indexHTML = `<!doctype html>
       color: #9ca3af;
       margin-bottom: 0.25rem;
     }
+    .wrap {
+      margin-top: 0.75rem;
+    }
+    .wrap input[type="number"] {
+      width: 5rem;
+    }
+    fieldset.normalize {
+      border: 1px solid #1f2937;
+      border-radius: 0.5rem;
+      margin: 0.75rem 0 0;
+      padding: 0.5rem 0.75rem;
+    }
+    fieldset.normalize legend {
+      font-size: 0.85rem;
+      color: #9ca3af;
+    }
+    fieldset.normalize label {
+      margin: 0.2rem 0;
+    }
     input[type="text"], textarea {
       width: 100%;
       border-radius: 0.5rem;
@@ -286,14 +477,40 @@ const indexHTML = `<!doctype html>
       font-size: 0.8rem;
       color: #6b7280;
     }
+    .continue {
+      grid-column: 1 / -1;
+    }
   </style>
 </head>
 <body>
   <header>
     <h1>TypeTen</h1>
     <span class="subtitle">Practice touch typing with your own texts</span>
+    <nav class="nav">
+      <a href="/stats">Statistics</a>
+      <a href="/history">History</a>
+      <form class="logout-form" method="post" action="/logout">
+        <button type="submit">Log out</button>
+      </form>
+    </nav>
   </header>
   <main>
+    {{if .Unfinished}}
+    <section class="card continue">
+      <h2>Continue where you left off</h2>
+      <ul class="texts-list">
+        {{range .Unfinished}}
+        <li>
+          <div>
+            <a href="/sessions/{{.Session.ID}}">{{if .Title}}{{.Title}}{{else}}Untitled text{{end}}</a>
+            <div class="subtitle">{{.Session.CompletedLines}}{{if .Session.TotalLines}} of {{.Session.TotalLines}}{{end}} lines{{if eq .Session.Status "paused"}} · paused{{end}} · last typed {{.Session.UpdatedAt.Format "Jan 2, 15:04"}}</div>
+          </div>
+          <a class="badge" href="/sessions/{{.Session.ID}}">Continue</a>
+        </li>
+        {{end}}
+      </ul>
+    </section>
+    {{end}}
     <section class="card">
       <h2>Your texts</h2>
       {{if .Texts}}
@@ -314,15 +531,37 @@ const indexHTML = `<!doctype html>
     </section>
     <section class="card">
       <h2>Add a new text</h2>
-      <form method="post" action="/texts">
+      <form method="post" action="/texts" enctype="multipart/form-data">
         <div style="margin-bottom:0.75rem;">
           <label for="title">Title</label>
-          <input id="title" name="title" type="text" required placeholder="e.g. The quick brown fox">
+          <input id="title" name="title" type="text" placeholder="e.g. The quick brown fox (taken from the file if empty)">
+        </div>
+        <div style="margin-bottom:0.75rem;">
+          <label for="file">Upload a file ({{.UploadAccept}}, up to 5 MB)</label>
+          <input id="file" name="file" type="file" accept="{{.UploadAccept}}">
+        </div>
+        <div style="margin-bottom:0.75rem;">
+          <label for="column">Column of a CSV/TSV file</label>
+          <input id="column" name="column" type="text" placeholder="number or header name, first column if empty">
         </div>
         <div>
-          <label for="content">Text content (one paragraph per line)</label>
-          <textarea id="content" name="content" required placeholder="Paste or type your text here...&#10;Each line will be used as a typing unit."></textarea>
+          <label for="content">Or paste text content (one paragraph per line)</label>
+          <textarea id="content" name="content" placeholder="Paste or type your text here...&#10;Each line will be used as a typing unit."></textarea>
+        </div>
+        <div class="wrap">
+          <label>
+            Wrap long paragraphs at
+            <input type="number" name="wrap_width" min="{{.MinWrapWidth}}" max="{{.MaxWrapWidth}}" placeholder="e.g. 80"> characters
+          </label>
+          <label><input type="checkbox" name="wrap_sentences" value="true"> One sentence per line</label>
         </div>
+        <fieldset class="normalize">
+          <legend>Clean up before saving</legend>
+          <input type="hidden" name="normalize" value="">
+          {{range .Normalization}}
+          <label><input type="checkbox" name="normalize" value="{{.Value}}"{{if .Checked}} checked{{end}}> {{.Label}}</label>
+          {{end}}
+        </fieldset>
         <button type="submit">Save text</button>
       </form>
     </section>
@@ -400,6 +639,34 @@ const textHTML = `<!doctype html>
     button:active {
       transform: translateY(1px);
     }
+    .mode {
+      display: flex;
+      gap: 1rem;
+      margin-top: 1.2rem;
+      font-size: 0.85rem;
+      color: #9ca3af;
+    }
+    .chapters {
+      margin-top: 1.5rem;
+    }
+    .chapters h2 {
+      margin: 0 0 0.75rem;
+      font-size: 1rem;
+    }
+    .chapters li {
+      margin-bottom: 0.3rem;
+    }
+    .mode select,
+    .mode input {
+      display: block;
+      margin-top: 0.3rem;
+      border-radius: 0.5rem;
+      border: 1px solid #1f2937;
+      background: #020617;
+      color: #e5e7eb;
+      padding: 0.4rem 0.6rem;
+      font-size: 0.9rem;
+    }
   </style>
 </head>
 <body>
@@ -411,13 +678,38 @@ const textHTML = `<!doctype html>
     <section class="card">
       <h1>{{.Text.Title}}</h1>
       <p class="meta">
-        {{.Text.TotalLines}} lines · {{.Text.FragmentCount}} fragments · ID: {{.Text.ID}}
+        {{if .Text.Author}}{{.Text.Author}} · {{end}}{{.Text.TotalLines}} lines · {{.Text.FragmentCount}} fragments{{if not .Text.Wrap.IsZero}} · {{.Text.Wrap}}{{end}} · ID: {{.Text.ID}}
       </p>
       <form method="post" action="/sessions">
         <input type="hidden" name="text_id" value="{{.Text.ID}}">
+        <div class="mode">
+          <label>
+            Session
+            <select name="mode">
+              <option value="full_text">Full text</option>
+              <option value="minutes">Minutes</option>
+              <option value="words">Words</option>
+              <option value="lines">Lines</option>
+            </select>
+          </label>
+          <label>
+            Limit
+            <input type="number" name="mode_limit" min="1" placeholder="e.g. 5">
+          </label>
+        </div>
         <button type="submit">Start practice session</button>
       </form>
     </section>
+    {{if .Chapters}}
+    <section class="card chapters">
+      <h2>Chapters</h2>
+      <ol>
+        {{range .Chapters}}
+        <li>{{.Title}} <span class="meta">line {{.Line}}</span></li>
+        {{end}}
+      </ol>
+    </section>
+    {{end}}
   </main>
 </body>
 </html>`
@@ -557,6 +849,30 @@ const sessionHTML = `<!doctype html>
     .pill-dot.inactive {
       background: #6b7280;
     }
+    #breakdown {
+      grid-column: 1 / -1;
+    }
+    #breakdown table {
+      width: 100%;
+      border-collapse: collapse;
+      margin-top: 0.75rem;
+      font-size: 0.85rem;
+    }
+    #breakdown th,
+    #breakdown td {
+      text-align: left;
+      padding: 0.4rem 0.5rem;
+      border-bottom: 1px solid #1f2937;
+    }
+    #breakdown th {
+      color: #9ca3af;
+      font-weight: 500;
+    }
+    #breakdown td.typed {
+      font-family: ui-monospace, SFMono-Regular, Menlo, Monaco, Consolas, "Liberation Mono", "Courier New", monospace;
+      white-space: pre-wrap;
+      word-break: break-word;
+    }
   </style>
 </head>
 <body>
@@ -575,61 +891,140 @@ const sessionHTML = `<!doctype html>
       <div id="current-line">Loading text…</div>
       <textarea id="input" placeholder="Type the line above here…"></textarea>
       <button id="complete-line-btn" type="button">Complete line</button>
+      <button id="pause-btn" type="button">Pause</button>
+      <button id="abandon-btn" type="button">Abandon</button>
     </section>
     <section class="card">
       <div class="stat-label">Session progress</div>
       <div class="stats-grid">
         <div>
           <div class="stat-label">Completed lines</div>
-          <div class="stat-value" id="stat-lines">0</div>
+          <div class="stat-value" id="stat-lines">{{.Session.CompletedLines}}</div>
         </div>
         <div>
           <div class="stat-label">Average WPM</div>
-          <div class="stat-value" id="stat-wpm">0</div>
+          <div class="stat-value" id="stat-wpm">{{printf "%.1f" .Session.AverageWPM}}</div>
         </div>
         <div>
           <div class="stat-label">Accuracy</div>
-          <div class="stat-value" id="stat-accuracy">0%</div>
+          <div class="stat-value" id="stat-accuracy">{{printf "%.1f" .Session.TotalAccuracyPercent}}%</div>
         </div>
         <div>
           <div class="stat-label">Elapsed</div>
           <div class="stat-value" id="stat-time">0s</div>
         </div>
+        <div>
+          <div class="stat-label">Mode</div>
+          <div class="stat-value">{{.Session.Mode}}</div>
+        </div>
+        {{- if eq .Session.Mode.Kind "minutes"}}
+        <div>
+          <div class="stat-label">Time left</div>
+          <div class="stat-value" id="stat-remaining">–</div>
+        </div>
+        {{- else if eq .Session.Mode.Kind "words"}}
+        <div>
+          <div class="stat-label">Words</div>
+          <div class="stat-value"><span id="stat-limit">{{.Session.CompletedWords}}</span> / {{.Session.Mode.Limit}}</div>
+        </div>
+        {{- else if eq .Session.Mode.Kind "lines"}}
+        <div>
+          <div class="stat-label">Lines</div>
+          <div class="stat-value"><span id="stat-limit">{{.Session.CompletedLines}}</span> / {{.Session.Mode.Limit}}</div>
+        </div>
+        {{- end}}
       </div>
     </section>
+    <section class="card" id="breakdown"{{if not .Session.IsCompleted}} hidden{{end}}>
+      <div class="stat-label">Line breakdown</div>
+      <table>
+        <thead>
+          <tr><th>#</th><th>Typed</th><th>Accuracy</th><th>WPM</th><th>Time</th><th>Errors</th></tr>
+        </thead>
+        <tbody id="breakdown-rows">
+          {{range .Lines}}
+          <tr>
+            <td>{{.Number}}</td>
+            <td class="typed">{{.Result.Typed}}</td>
+            <td>{{printf "%.1f" .Result.AccuracyPercent}}%</td>
+            <td>{{printf "%.1f" .Result.WPM}}</td>
+            <td>{{printf "%.1f" .Result.Duration.Seconds}}s</td>
+            <td>{{.Result.Errors}}</td>
+          </tr>
+          {{end}}
+        </tbody>
+      </table>
+    </section>
   </main>
   <script>
     (function() {
       const sessionId = "{{.Session.ID}}";
       const textId = "{{.Session.TextID}}";
+      // The server tracks the cursor; reloading resumes at the current line.
+      const startIndex = {{.Session.CurrentLine}};
+      const sessionCompleted = {{.Session.IsCompleted}};
+      // Paused sessions wait for Resume; abandoned ones can no longer be typed.
+      const sessionStatus = "{{.Session.Status}}";
+      const modeKind = "{{.Session.Mode.Kind}}";
+      // Only the time spent on lines counts toward a minutes limit, as on the server.
+      let remainingMs = {{.RemainingMs}};
 
       const currentLineEl = document.getElementById("current-line");
       const inputEl = document.getElementById("input");
       const completeBtn = document.getElementById("complete-line-btn");
+      const pauseBtn = document.getElementById("pause-btn");
+      const abandonBtn = document.getElementById("abandon-btn");
       const statLinesEl = document.getElementById("stat-lines");
       const statWpmEl = document.getElementById("stat-wpm");
       const statAccuracyEl = document.getElementById("stat-accuracy");
       const statTimeEl = document.getElementById("stat-time");
+      const statRemainingEl = document.getElementById("stat-remaining");
+      const statLimitEl = document.getElementById("stat-limit");
       const statusPillEl = document.getElementById("status-pill");
       const statusDotEl = document.getElementById("status-dot");
       const statusTextEl = document.getElementById("status-text");
+      const breakdownEl = document.getElementById("breakdown");
+      const breakdownRowsEl = document.getElementById("breakdown-rows");
 
       let lines = [];
       let currentIndex = 0;
       let sessionStart = Date.now();
       let lineStart = Date.now();
       let timerId = null;
+      let keystrokes = [];
+      let sending = false;
+      let finished = false;
+      let autoSubmitted = false;
+      let pausedAt = null;
+
+      function formatSeconds(totalSec) {
+        const mins = Math.floor(totalSec / 60);
+        const secs = totalSec % 60;
+        return (mins > 0 ? mins + "m " : "") + secs + "s";
+      }
 
       function updateTimer() {
-        const elapsedSec = Math.floor((Date.now() - sessionStart) / 1000);
-        const mins = Math.floor(elapsedSec / 60);
-        const secs = elapsedSec % 60;
-        statTimeEl.textContent = (mins > 0 ? mins + "m " : "") + secs + "s";
+        statTimeEl.textContent = formatSeconds(Math.floor(((pausedAt || Date.now()) - sessionStart) / 1000));
+        updateCountdown();
+      }
+
+      // When the time runs out the line typed so far is sent; the server scores it
+      // against the same number of characters and completes the session.
+      function updateCountdown() {
+        if (!statRemainingEl) {
+          return;
+        }
+        const left = finished || sending || pausedAt ? remainingMs : Math.max(0, remainingMs - (Date.now() - lineStart));
+        statRemainingEl.textContent = formatSeconds(Math.ceil(left / 1000));
+        if (left === 0 && !finished && !sending && !autoSubmitted) {
+          autoSubmitted = true;
+          completeBtn.click();
+        }
       }
 
       function startTimer() {
         if (timerId) return;
-        timerId = setInterval(updateTimer, 1000);
+        timerId = setInterval(updateTimer, 250);
       }
 
       function setStatus(text, active) {
@@ -663,12 +1058,24 @@ const sessionHTML = `<!doctype html>
               setStatus("Idle", false);
               return;
             }
-            currentIndex = 0;
+            currentIndex = Math.min(startIndex, lines.length);
+            if (sessionStatus === "abandoned") {
+              abandoned();
+              return;
+            }
+            if (sessionCompleted || currentIndex >= lines.length) {
+              finish();
+              return;
+            }
             currentLineEl.textContent = lines[currentIndex];
             inputEl.value = "";
-            inputEl.focus();
             sessionStart = Date.now();
             lineStart = Date.now();
+            if (sessionStatus === "paused") {
+              paused();
+              return;
+            }
+            inputEl.focus();
             startTimer();
             setStatus("Typing", true);
           })
@@ -679,33 +1086,162 @@ const sessionHTML = `<!doctype html>
           });
       }
 
-      function computeAccuracy(expected, typed) {
-        if (!expected && !typed) return 100.0;
-        const maxLen = Math.max(expected.length, typed.length);
-        if (maxLen === 0) return 100.0;
-        let correct = 0;
-        for (let i = 0; i < maxLen; i++) {
-          if (expected[i] === typed[i]) {
-            correct++;
+      function endControls() {
+        finished = true;
+        inputEl.disabled = true;
+        completeBtn.disabled = true;
+        pauseBtn.disabled = true;
+        abandonBtn.disabled = true;
+      }
+
+      function paused() {
+        pausedAt = Date.now();
+        inputEl.disabled = true;
+        completeBtn.disabled = true;
+        pauseBtn.textContent = "Resume";
+        setStatus("Paused", false);
+      }
+
+      // Time spent paused does not count toward the line or the session.
+      function resumed() {
+        if (pausedAt) {
+          const pausedMs = Date.now() - pausedAt;
+          sessionStart += pausedMs;
+          lineStart += pausedMs;
+          pausedAt = null;
+        }
+        inputEl.disabled = false;
+        completeBtn.disabled = false;
+        pauseBtn.textContent = "Pause";
+        inputEl.focus();
+        startTimer();
+        setStatus("Typing", true);
+      }
+
+      function abandoned() {
+        endControls();
+        currentLineEl.textContent = "Session abandoned.";
+        setStatus("Abandoned", false);
+      }
+
+      function changeStatus(action) {
+        return fetch("/api/sessions/" + encodeURIComponent(sessionId) + "/" + action, {
+          method: "POST"
+        }).then(function(res) {
+          if (!res.ok) {
+            throw new Error("Failed to " + action + " session");
           }
+          return res.json();
+        });
+      }
+
+      pauseBtn.addEventListener("click", function() {
+        if (finished || sending) {
+          return;
         }
-        return (correct / maxLen) * 100.0;
+        const action = pausedAt ? "resume" : "pause";
+        pauseBtn.disabled = true;
+        changeStatus(action).then(function() {
+          if (action === "pause") {
+            paused();
+          } else {
+            resumed();
+          }
+        }).catch(function(err) {
+          console.error(err);
+          setStatus("Error changing session status", false);
+        }).then(function() {
+          pauseBtn.disabled = finished;
+        });
+      });
+
+      abandonBtn.addEventListener("click", function() {
+        if (finished || sending || !confirm("Abandon this session?")) {
+          return;
+        }
+        changeStatus("abandon").then(abandoned).catch(function(err) {
+          console.error(err);
+          setStatus("Error changing session status", false);
+        });
+      });
+
+      function finish() {
+        endControls();
+        currentLineEl.textContent = modeKind === "full_text" || currentIndex >= lines.length
+          ? "All lines completed. Great job!"
+          : "Session limit reached. Great job!";
+        setStatus("Completed", false);
+        updateCountdown();
+        showBreakdown();
+      }
+
+      // The breakdown is rendered by the server for completed sessions; when the
+      // session is finished on this page it is fetched instead.
+      function showBreakdown() {
+        if (breakdownRowsEl.querySelector("tr")) {
+          breakdownEl.hidden = false;
+          return;
+        }
+        fetch("/api/sessions/" + encodeURIComponent(sessionId) + "/lines")
+          .then(function(res) {
+            if (!res.ok) {
+              throw new Error("Failed to load line results");
+            }
+            return res.json();
+          })
+          .then(function(data) {
+            (data.lines || []).forEach(function(line) {
+              const row = document.createElement("tr");
+              [
+                String(line.line + 1),
+                line.typed,
+                line.accuracy_percent.toFixed(1) + "%",
+                line.wpm.toFixed(1),
+                (line.duration_ms / 1000).toFixed(1) + "s",
+                String(line.errors)
+              ].forEach(function(value, i) {
+                const cell = document.createElement("td");
+                if (i === 1) {
+                  cell.className = "typed";
+                }
+                cell.textContent = value;
+                row.appendChild(cell);
+              });
+              breakdownRowsEl.appendChild(row);
+            });
+            breakdownEl.hidden = false;
+          })
+          .catch(function(err) {
+            console.error(err);
+          });
       }
 
-      function computeWPM(typed, millis) {
-        const minutes = millis / 60000.0;
-        if (minutes <= 0) return 0.0;
-        const words = typed.length / 5.0;
-        return words / minutes;
+      // Raw keystrokes are stored for later analysis; losing a batch must not block typing.
+      function sendKeystrokes(lineIdx, events) {
+        if (events.length === 0) {
+          return Promise.resolve();
+        }
+        return fetch("/api/sessions/" + encodeURIComponent(sessionId) + "/keystrokes", {
+          method: "POST",
+          headers: { "Content-Type": "application/json" },
+          body: JSON.stringify({ line_idx: lineIdx, events: events })
+        }).then(function(res) {
+          if (!res.ok) {
+            throw new Error("Failed to record keystrokes");
+          }
+        }).catch(function(err) {
+          console.error(err);
+        });
       }
 
-      function sendProgress(accuracy, wpm) {
+      // The server scores the line against the text; only the input and timing are sent.
+      function sendProgress(typed, millis) {
         return fetch("/api/sessions/" + encodeURIComponent(sessionId) + "/progress", {
           method: "POST",
           headers: { "Content-Type": "application/json" },
           body: JSON.stringify({
-            accuracy_percent: accuracy,
-            wpm: wpm
+            typed: typed,
+            elapsed_ms: Math.max(1, millis)
           })
         }).then(function(res) {
           if (!res.ok) {
@@ -716,44 +1252,73 @@ const sessionHTML = `<!doctype html>
           statLinesEl.textContent = data.completed_lines;
           statWpmEl.textContent = data.average_wpm.toFixed(1);
           statAccuracyEl.textContent = data.total_accuracy_percent.toFixed(1) + "%";
-          if (data.is_completed) {
-            setStatus("Completed", false);
+          remainingMs = data.remaining_ms || 0;
+          if (statLimitEl) {
+            statLimitEl.textContent = modeKind === "words" ? data.completed_words : data.completed_lines;
           }
+          return data;
         }).catch(function(err) {
           console.error(err);
           setStatus("Error sending progress", false);
+          return null;
         });
       }
 
       completeBtn.addEventListener("click", function() {
+        if (sending) {
+          return;
+        }
         if (currentIndex >= lines.length) {
           setStatus("Completed", false);
           return;
         }
-        const expected = lines[currentIndex] || "";
         const typed = inputEl.value || "";
-        const now = Date.now();
-        const lineMillis = now - lineStart;
-        const accuracy = computeAccuracy(expected, typed);
-        const wpm = computeWPM(typed, lineMillis);
-
-        sendProgress(accuracy, wpm).then(function() {
-          currentIndex++;
-          if (currentIndex >= lines.length) {
-            currentLineEl.textContent = "All lines completed. Great job!";
-            inputEl.disabled = true;
-            completeBtn.disabled = true;
-            setStatus("Completed", false);
+        const lineMillis = Date.now() - lineStart;
+        const events = keystrokes;
+        keystrokes = [];
+        sending = true;
+
+        sendKeystrokes(currentIndex, events).then(function() {
+          return sendProgress(typed, lineMillis);
+        }).then(function(data) {
+          sending = false;
+          if (!data) {
+            return;
+          }
+          currentIndex = data.current_line;
+          if (data.is_completed || currentIndex >= lines.length) {
+            finish();
           } else {
             currentLineEl.textContent = lines[currentIndex];
             inputEl.value = "";
             inputEl.focus();
             lineStart = Date.now();
+            autoSubmitted = false;
             setStatus("Typing", true);
           }
         });
       });
 
+      inputEl.addEventListener("keydown", function(e) {
+        if (e.ctrlKey || e.metaKey || e.altKey) {
+          return;
+        }
+        const correction = e.key === "Backspace" || e.key === "Delete";
+        if (e.key.length !== 1 && !correction) {
+          return; // modifiers, arrows and other non-printing keys
+        }
+        if (keystrokes.length >= {{.MaxKeystrokes}}) {
+          return;
+        }
+        const expected = lines[currentIndex] || "";
+        keystrokes.push({
+          key: e.key,
+          expected: expected.charAt(inputEl.selectionStart),
+          offset_ms: Math.max(0, Date.now() - lineStart),
+          correction: correction
+        });
+      });
+
       inputEl.addEventListener("keydown", function(e) {
         if (e.key === "Enter" && (e.ctrlKey || e.metaKey)) {
           e.preventDefault();
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"typeten/internal/usecases"
+)
+
+func TestHandlers_ResumeSession(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	ctx := context.Background()
+	textOutput, err := handlers.createTextUseCase.Execute(ctx, usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Resumable Text",
+		Content: "line1\nline2\nline3",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+	sessionOutput, err := handlers.createSessionUseCase.Execute(ctx, usecases.CreateSessionInput{
+		UserID: handlers.defaultUserID,
+		TextID: textOutput.TextInfo.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+	sessionID := string(sessionOutput.Session.ID)
+	_, err = handlers.recordProgressUseCase.Execute(ctx, usecases.RecordProgressInput{
+		UserID:    handlers.defaultUserID,
+		SessionID: sessionID,
+		Typed:     "line1",
+		Elapsed:   2 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Failed to record progress: %v", err)
+	}
+
+	t.Run("api exposes the cursor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/sessions/"+sessionID, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GetSession() status = %v, want %v", w.Code, http.StatusOK)
+		}
+		var resp GetSessionResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.CurrentLine != 1 || resp.CurrentLineIdx != 1 {
+			t.Errorf("GetSession() cursor = line %v (idx %v), want 1 (idx 1)", resp.CurrentLine, resp.CurrentLineIdx)
+		}
+	})
+
+	t.Run("session page starts at the cursor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sessions/"+sessionID, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("SessionPage() status = %v, want %v", w.Code, http.StatusOK)
+		}
+		// html/template pads values interpolated into scripts with spaces
+		if !regexp.MustCompile(`const startIndex =\s*1\s*;`).MatchString(w.Body.String()) {
+			t.Error("SessionPage() does not start at the current line")
+		}
+	})
+
+	t.Run("index lists the unfinished session", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("IndexPage() status = %v, want %v", w.Code, http.StatusOK)
+		}
+		body := w.Body.String()
+		if !strings.Contains(body, `href="/sessions/`+sessionID+`"`) {
+			t.Error("IndexPage() does not link the unfinished session")
+		}
+		if !strings.Contains(body, "1 of 3 lines") {
+			t.Error("IndexPage() does not show session progress")
+		}
+	})
+}
+
+func TestHandlers_CreateSessionHTML_Mode(t *testing.T) {
+	handlers := setupTestHandlers(t)
+	router := NewRouter(handlers)
+
+	textOutput, err := handlers.createTextUseCase.Execute(context.Background(), usecases.CreateTextInput{
+		UserID:  handlers.defaultUserID,
+		Title:   "Timed Text",
+		Content: "line1\nline2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test text: %v", err)
+	}
+
+	post := func(form string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/sessions", strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("text_id=" + string(textOutput.TextInfo.ID) + "&mode=minutes&mode_limit=5")
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("CreateSessionHTML() status = %v, want %v", w.Code, http.StatusSeeOther)
+	}
+
+	w2 := httptest.NewRecorder()
+	router.ServeHTTP(w2, httptest.NewRequest(http.MethodGet, w.Header().Get("Location"), nil))
+	if w2.Code != http.StatusOK {
+		t.Fatalf("SessionPage() status = %v, want %v", w2.Code, http.StatusOK)
+	}
+	body := w2.Body.String()
+	if !regexp.MustCompile(`let remainingMs =\s*300000\s*;`).MatchString(body) {
+		t.Error("SessionPage() does not start the countdown at 5 minutes")
+	}
+	if !strings.Contains(body, "5 minutes") {
+		t.Error("SessionPage() does not show the session mode")
+	}
+
+	// The limit is ignored for full text, as the form always sends it.
+	if w := post("text_id=" + string(textOutput.TextInfo.ID) + "&mode=full_text&mode_limit=5"); w.Code != http.StatusSeeOther {
+		t.Errorf("CreateSessionHTML() full text status = %v, want %v", w.Code, http.StatusSeeOther)
+	}
+	if w := post("text_id=" + string(textOutput.TextInfo.ID) + "&mode=words&mode_limit=many"); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("CreateSessionHTML() invalid limit status = %v, want %v", w.Code, http.StatusUnprocessableEntity)
+	}
+}
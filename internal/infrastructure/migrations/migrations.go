@@ -0,0 +1,272 @@
+// Package migrations manages the versioned SQL schema of the SQLite store.
+// Migrations are embedded SQL files named NNNN_description.up.sql and
+// NNNN_description.down.sql; applied versions are recorded in schema_migrations.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed sql/*.sql
+var embedded embed.FS
+
+// Errors returned by the Migrator. Callers can use errors.Is to branch on these.
+var (
+	ErrInvalidMigration = errors.New("migrations: invalid migration set")
+	ErrNoApplied        = errors.New("migrations: no applied migrations to revert")
+)
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    INTEGER PRIMARY KEY,
+	name       TEXT NOT NULL,
+	applied_at INTEGER NOT NULL
+)`
+
+// Migration is one schema change with its forward (Up) and reverse (Down) SQL.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes whether a migration has been applied and when.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies and reverts migrations against a database.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New creates a Migrator for db using the embedded migration set.
+func New(db *sql.DB) (*Migrator, error) {
+	sub, err := fs.Sub(embedded, "sql")
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := Load(sub)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load reads migrations from the root of fsys and returns them ordered by version.
+// Every version must have exactly one up and one down file.
+// Returns ErrInvalidMigration on malformed names, duplicates or missing halves.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		version, name, direction, err := parseFilename(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("%w: version %d has names %q and %q", ErrInvalidMigration, version, m.Name, name)
+		}
+		switch direction {
+		case "up":
+			if m.Up != "" {
+				return nil, fmt.Errorf("%w: duplicate up migration %d", ErrInvalidMigration, version)
+			}
+			m.Up = string(content)
+		case "down":
+			if m.Down != "" {
+				return nil, fmt.Errorf("%w: duplicate down migration %d", ErrInvalidMigration, version)
+			}
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" || strings.TrimSpace(m.Down) == "" {
+			return nil, fmt.Errorf("%w: migration %d must have non-empty up and down files", ErrInvalidMigration, m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// parseFilename splits "0001_create_users.up.sql" into (1, "create_users", "up").
+func parseFilename(filename string) (version int, name, direction string, err error) {
+	base := strings.TrimSuffix(filename, ".sql")
+	dot := strings.LastIndex(base, ".")
+	if dot < 0 {
+		return 0, "", "", fmt.Errorf("%w: %s has no up/down suffix", ErrInvalidMigration, filename)
+	}
+	direction = base[dot+1:]
+	if direction != "up" && direction != "down" {
+		return 0, "", "", fmt.Errorf("%w: %s has no up/down suffix", ErrInvalidMigration, filename)
+	}
+	prefix, name, ok := strings.Cut(base[:dot], "_")
+	if !ok || name == "" {
+		return 0, "", "", fmt.Errorf("%w: %s is not named NNNN_description", ErrInvalidMigration, filename)
+	}
+	version, err = strconv.Atoi(prefix)
+	if err != nil || version <= 0 {
+		return 0, "", "", fmt.Errorf("%w: %s has invalid version", ErrInvalidMigration, filename)
+	}
+	return version, name, direction, nil
+}
+
+// Migrations returns the known migrations ordered by version.
+func (m *Migrator) Migrations() []Migration {
+	out := make([]Migration, len(m.migrations))
+	copy(out, m.migrations)
+	return out
+}
+
+// Status reports every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses[i] = Status{Migration: migration, Applied: ok, AppliedAt: appliedAt}
+	}
+	return statuses, nil
+}
+
+// Pending returns the migrations that have not been applied yet, in order.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, s := range statuses {
+		if !s.Applied {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies all pending migrations in version order, each in its own transaction.
+// Returns the migrations that were applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, migration := range pending {
+		err := m.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+				migration.Version, migration.Name, time.Now().UnixNano())
+			return err
+		})
+		if err != nil {
+			return pending[:i], fmt.Errorf("failed to apply migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+// Down reverts the most recently applied migration and returns it.
+// Returns ErrNoApplied if nothing has been applied.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var last *Migration
+	for i := len(statuses) - 1; i >= 0; i-- {
+		if statuses[i].Applied {
+			last = &statuses[i].Migration
+			break
+		}
+	}
+	if last == nil {
+		return nil, ErrNoApplied
+	}
+
+	err = m.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, last.Down); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, last.Version)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revert migration %04d_%s: %w", last.Version, last.Name, err)
+	}
+	return last, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]time.Time, error) {
+	if _, err := m.db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt int64
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		applied[version] = time.Unix(0, appliedAt)
+	}
+	return applied, rows.Err()
+}
+
+func (m *Migrator) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
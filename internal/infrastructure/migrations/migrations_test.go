@@ -0,0 +1,189 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	_ "modernc.org/sqlite"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "typeten.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, name).Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to query sqlite_master: %v", err)
+	}
+	return count > 0
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		wantLen int
+		wantErr error
+	}{
+		{
+			name: "ordered by version",
+			files: fstest.MapFS{
+				"0002_second.up.sql":   {Data: []byte("SELECT 2")},
+				"0002_second.down.sql": {Data: []byte("SELECT 2")},
+				"0001_first.up.sql":    {Data: []byte("SELECT 1")},
+				"0001_first.down.sql":  {Data: []byte("SELECT 1")},
+			},
+			wantLen: 2,
+		},
+		{
+			name: "missing down",
+			files: fstest.MapFS{
+				"0001_first.up.sql": {Data: []byte("SELECT 1")},
+			},
+			wantErr: ErrInvalidMigration,
+		},
+		{
+			name: "no direction suffix",
+			files: fstest.MapFS{
+				"0001_first.sql": {Data: []byte("SELECT 1")},
+			},
+			wantErr: ErrInvalidMigration,
+		},
+		{
+			name: "non-numeric version",
+			files: fstest.MapFS{
+				"abc_first.up.sql":   {Data: []byte("SELECT 1")},
+				"abc_first.down.sql": {Data: []byte("SELECT 1")},
+			},
+			wantErr: ErrInvalidMigration,
+		},
+		{
+			name: "mismatched names",
+			files: fstest.MapFS{
+				"0001_first.up.sql":   {Data: []byte("SELECT 1")},
+				"0001_other.down.sql": {Data: []byte("SELECT 1")},
+			},
+			wantErr: ErrInvalidMigration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.files)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil {
+				if len(got) != tt.wantLen {
+					t.Fatalf("Load() length = %v, want %v", len(got), tt.wantLen)
+				}
+				for i := 1; i < len(got); i++ {
+					if got[i].Version <= got[i-1].Version {
+						t.Error("Load() migrations are not sorted by version")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	migrator, err := New(db)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	total := len(migrator.Migrations())
+	if total == 0 {
+		t.Fatal("New() loaded no embedded migrations")
+	}
+
+	t.Run("all pending on empty database", func(t *testing.T) {
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			t.Fatalf("Pending() error = %v", err)
+		}
+		if len(pending) != total {
+			t.Errorf("Pending() length = %v, want %v", len(pending), total)
+		}
+	})
+
+	t.Run("Down with nothing applied", func(t *testing.T) {
+		_, err := migrator.Down(ctx)
+		if !errors.Is(err, ErrNoApplied) {
+			t.Errorf("Down() error = %v, wantErr %v", err, ErrNoApplied)
+		}
+	})
+
+	t.Run("Up applies everything", func(t *testing.T) {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		if len(applied) != total {
+			t.Errorf("Up() applied = %v, want %v", len(applied), total)
+		}
+		for _, table := range []string{"users", "texts", "text_fragments", "sessions"} {
+			if !tableExists(t, db, table) {
+				t.Errorf("Up() did not create table %s", table)
+			}
+		}
+	})
+
+	t.Run("Up is idempotent", func(t *testing.T) {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		if len(applied) != 0 {
+			t.Errorf("Up() applied = %v, want 0", len(applied))
+		}
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		for _, s := range statuses {
+			if !s.Applied || s.AppliedAt.IsZero() {
+				t.Errorf("Status() migration %d Applied = %v, AppliedAt = %v", s.Version, s.Applied, s.AppliedAt)
+			}
+		}
+	})
+
+	t.Run("Down reverts the latest migration", func(t *testing.T) {
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			t.Fatalf("Down() error = %v", err)
+		}
+		last := migrator.Migrations()[total-1]
+		if reverted.Version != last.Version {
+			t.Errorf("Down() version = %v, want %v", reverted.Version, last.Version)
+		}
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			t.Fatalf("Pending() error = %v", err)
+		}
+		if len(pending) != 1 || pending[0].Version != last.Version {
+			t.Errorf("Pending() = %v, want only version %v", pending, last.Version)
+		}
+	})
+}
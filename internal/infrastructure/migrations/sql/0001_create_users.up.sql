@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS users (
+	id         TEXT PRIMARY KEY,
+	email      TEXT NOT NULL UNIQUE,
+	username   TEXT NOT NULL,
+	created_at INTEGER NOT NULL
+);
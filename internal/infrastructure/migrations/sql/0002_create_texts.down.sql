@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_texts_user_id;
+DROP TABLE IF EXISTS texts;
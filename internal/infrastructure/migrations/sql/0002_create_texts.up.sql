@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS texts (
+	id             TEXT PRIMARY KEY,
+	user_id        TEXT NOT NULL,
+	title          TEXT NOT NULL,
+	total_lines    INTEGER NOT NULL,
+	fragment_size  INTEGER NOT NULL,
+	fragment_count INTEGER NOT NULL,
+	created_at     INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_texts_user_id ON texts (user_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_text_fragments_text_id;
+DROP TABLE IF EXISTS text_fragments;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS text_fragments (
+	id           TEXT PRIMARY KEY,
+	text_id      TEXT NOT NULL,
+	fragment_idx INTEGER NOT NULL,
+	lines        TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_text_fragments_text_id ON text_fragments (text_id);
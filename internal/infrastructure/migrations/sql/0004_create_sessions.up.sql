@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS sessions (
+	id                     TEXT PRIMARY KEY,
+	user_id                TEXT NOT NULL,
+	text_id                TEXT NOT NULL,
+	current_fragment_idx   INTEGER NOT NULL,
+	current_line_idx       INTEGER NOT NULL,
+	completed_lines        INTEGER NOT NULL,
+	total_accuracy_percent REAL NOT NULL,
+	average_wpm            REAL NOT NULL,
+	is_completed           INTEGER NOT NULL,
+	created_at             INTEGER NOT NULL,
+	updated_at             INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id);
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN password_hash TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS api_tokens (
+	id           TEXT PRIMARY KEY,
+	user_id      TEXT NOT NULL,
+	name         TEXT NOT NULL,
+	token_hash   TEXT NOT NULL UNIQUE,
+	scope        TEXT NOT NULL,
+	created_at   INTEGER NOT NULL,
+	last_used_at INTEGER,
+	revoked_at   INTEGER
+);
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user_id ON api_tokens (user_id);
@@ -0,0 +1,2 @@
+ALTER TABLE sessions DROP COLUMN fragment_size;
+ALTER TABLE sessions DROP COLUMN total_lines;
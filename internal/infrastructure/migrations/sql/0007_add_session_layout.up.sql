@@ -0,0 +1,5 @@
+ALTER TABLE sessions ADD COLUMN total_lines INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE sessions ADD COLUMN fragment_size INTEGER NOT NULL DEFAULT 0;
+UPDATE sessions SET
+	total_lines = COALESCE((SELECT t.total_lines FROM texts t WHERE t.id = sessions.text_id), 0),
+	fragment_size = COALESCE((SELECT t.fragment_size FROM texts t WHERE t.id = sessions.text_id), 0);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_keystroke_events_session_line;
+DROP TABLE IF EXISTS keystroke_events;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS keystroke_events (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	session_id    TEXT NOT NULL,
+	line_idx      INTEGER NOT NULL,
+	key           TEXT NOT NULL,
+	expected      TEXT NOT NULL,
+	offset_ms     INTEGER NOT NULL,
+	is_correction INTEGER NOT NULL,
+	recorded_at   INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_keystroke_events_session_line ON keystroke_events (session_id, line_idx);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS line_results (
+	session_id       TEXT NOT NULL,
+	fragment_idx     INTEGER NOT NULL,
+	line_idx         INTEGER NOT NULL,
+	typed            TEXT NOT NULL,
+	accuracy_percent REAL NOT NULL,
+	wpm              REAL NOT NULL,
+	duration_ms      INTEGER NOT NULL,
+	errors           INTEGER NOT NULL,
+	recorded_at      INTEGER NOT NULL,
+	PRIMARY KEY (session_id, fragment_idx, line_idx)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE sessions DROP COLUMN practice_ms;
+ALTER TABLE sessions DROP COLUMN completed_words;
+ALTER TABLE sessions DROP COLUMN mode_limit;
+ALTER TABLE sessions DROP COLUMN mode;
@@ -0,0 +1,4 @@
+ALTER TABLE sessions ADD COLUMN mode TEXT NOT NULL DEFAULT 'full_text';
+ALTER TABLE sessions ADD COLUMN mode_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE sessions ADD COLUMN completed_words INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE sessions ADD COLUMN practice_ms INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_sessions_status_updated_at;
+ALTER TABLE sessions ADD COLUMN is_completed INTEGER NOT NULL DEFAULT 0;
+UPDATE sessions SET is_completed = 1 WHERE status = 'completed';
+ALTER TABLE sessions DROP COLUMN paused_ms;
+ALTER TABLE sessions DROP COLUMN paused_at;
+ALTER TABLE sessions DROP COLUMN status;
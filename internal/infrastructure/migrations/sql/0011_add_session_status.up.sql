@@ -0,0 +1,6 @@
+ALTER TABLE sessions ADD COLUMN status TEXT NOT NULL DEFAULT 'active';
+ALTER TABLE sessions ADD COLUMN paused_at INTEGER;
+ALTER TABLE sessions ADD COLUMN paused_ms INTEGER NOT NULL DEFAULT 0;
+UPDATE sessions SET status = 'completed' WHERE is_completed <> 0;
+ALTER TABLE sessions DROP COLUMN is_completed;
+CREATE INDEX IF NOT EXISTS idx_sessions_status_updated_at ON sessions (status, updated_at);
@@ -0,0 +1,2 @@
+ALTER TABLE texts DROP COLUMN chapters;
+ALTER TABLE texts DROP COLUMN author;
@@ -0,0 +1,2 @@
+ALTER TABLE texts ADD COLUMN author TEXT NOT NULL DEFAULT '';
+ALTER TABLE texts ADD COLUMN chapters TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1 @@
+ALTER TABLE texts ADD COLUMN normalization TEXT NOT NULL DEFAULT '[]';
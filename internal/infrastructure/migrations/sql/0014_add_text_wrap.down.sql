@@ -0,0 +1,2 @@
+ALTER TABLE texts DROP COLUMN wrap_sentences;
+ALTER TABLE texts DROP COLUMN wrap_width;
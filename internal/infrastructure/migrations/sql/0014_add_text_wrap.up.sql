@@ -0,0 +1,2 @@
+ALTER TABLE texts ADD COLUMN wrap_width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE texts ADD COLUMN wrap_sentences INTEGER NOT NULL DEFAULT 0;
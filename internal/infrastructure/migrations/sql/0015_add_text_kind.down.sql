@@ -0,0 +1 @@
+ALTER TABLE texts DROP COLUMN kind;
@@ -0,0 +1 @@
+ALTER TABLE texts ADD COLUMN kind TEXT NOT NULL DEFAULT 'user';
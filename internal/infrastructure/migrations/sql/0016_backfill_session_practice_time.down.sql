@@ -0,0 +1,2 @@
+-- The backfilled practice time is valid under the previous schema as well.
+SELECT 1;
@@ -0,0 +1,3 @@
+UPDATE sessions SET practice_ms = (
+	SELECT COALESCE(SUM(duration_ms), 0) FROM line_results WHERE line_results.session_id = sessions.id
+) WHERE practice_ms = 0;
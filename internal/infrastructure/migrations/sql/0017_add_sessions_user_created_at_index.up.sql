@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_sessions_user_created_at ON sessions (user_id, created_at, id);
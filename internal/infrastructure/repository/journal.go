@@ -0,0 +1,552 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"typeten/internal/domain"
+)
+
+const (
+	journalFileName  = "journal.log"
+	snapshotFileName = "snapshot.json"
+)
+
+// Journal operations. Each journal record carries one of these and the
+// JSON-encoded argument of the corresponding repository call.
+const (
+	opUserCreate     = "user.create"
+	opTextCreate     = "text.create"
+	opFragmentCreate = "fragment.create"
+	opSessionCreate  = "session.create"
+	opSessionUpdate  = "session.update"
+	opSessionAbandon = "session.abandon"
+	opSessionStatus  = "session.status"
+	opTokenCreate    = "token.create"
+	opTokenUpdate    = "token.update"
+	opTokenUsed      = "token.used"
+	opKeystrokesAdd  = "keystrokes.append"
+	opLineResultAdd  = "line_result.create"
+)
+
+// journalRecord is one line of the append-only journal.
+type journalRecord struct {
+	Seq  uint64          `json:"seq"`
+	Op   string          `json:"op"`
+	Data json.RawMessage `json:"data"`
+}
+
+// fragmentRecord is the serialized form of a TextFragment, whose lines are unexported.
+type fragmentRecord struct {
+	ID          domain.TextFragmentID `json:"id"`
+	TextID      domain.TextID         `json:"text_id"`
+	FragmentIdx int                   `json:"fragment_idx"`
+	Lines       []string              `json:"lines"`
+}
+
+// sessionAbandonRecord is the serialized form of a SessionRepository.AbandonIfIdle call.
+type sessionAbandonRecord struct {
+	ID     domain.SessionID `json:"id"`
+	Before time.Time        `json:"before"`
+}
+
+// sessionStatusRecord is the serialized form of a SessionRepository.UpdateStatus call.
+type sessionStatusRecord struct {
+	ID         domain.SessionID     `json:"id"`
+	From       domain.SessionStatus `json:"from"`
+	Status     domain.SessionStatus `json:"status"`
+	PausedAt   *time.Time           `json:"paused_at,omitempty"`
+	PausedTime time.Duration        `json:"paused_time"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+}
+
+// tokenUsedRecord is the serialized form of an APITokenRepository.MarkUsed call.
+type tokenUsedRecord struct {
+	ID domain.APITokenID `json:"id"`
+	At time.Time         `json:"at"`
+}
+
+// sessionRecord is the serialized form of a Session. Records written before session
+// statuses existed carry IsCompleted instead of Status; session fills Status from it.
+type sessionRecord struct {
+	domain.Session
+	IsCompleted bool `json:",omitempty"`
+}
+
+func (r *sessionRecord) session() *domain.Session {
+	session := r.Session
+	if session.Status == "" {
+		session.Status = domain.SessionActive
+		if r.IsCompleted {
+			session.Status = domain.SessionCompleted
+		}
+	}
+	return &session
+}
+
+// snapshot is the compacted state of all repositories up to and including LastSeq.
+type snapshot struct {
+	LastSeq    uint64                   `json:"last_seq"`
+	CreatedAt  time.Time                `json:"created_at"`
+	Users      []*domain.User           `json:"users"`
+	Texts      []*domain.TextInfo       `json:"texts"`
+	Fragments  []fragmentRecord         `json:"fragments"`
+	Sessions   []*sessionRecord         `json:"sessions"`
+	APITokens  []*domain.APIToken       `json:"api_tokens"`
+	Keystrokes []*domain.KeystrokeEvent `json:"keystrokes"`
+	Lines      []*domain.LineResult     `json:"line_results"`
+}
+
+// journalFile is the open journal; an *os.File outside of tests.
+type journalFile interface {
+	io.WriteSeeker
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+}
+
+// JournalStore makes the in-memory repositories durable. Every Create/Update is
+// checked, appended (and fsynced) to a write-ahead journal and only then applied
+// in memory. Snapshot compacts the state into a JSON snapshot and
+// truncates the journal. OpenJournalStore loads the snapshot and replays the journal.
+type JournalStore struct {
+	mu         sync.Mutex // serializes writes so journal order matches apply order
+	dir        string
+	journal    journalFile
+	seq        uint64
+	failed     error // set when a failed append could not be rolled back; refuses writes until a snapshot
+	users      *MemoryUserRepository
+	texts      *MemoryTextRepository
+	sessions   *MemorySessionRepository
+	tokens     *MemoryAPITokenRepository
+	keystrokes *MemoryKeystrokeRepository
+	lines      *MemoryLineResultRepository
+}
+
+// OpenJournalStore opens (or creates) a journal store in dir and restores its state.
+// A torn record at the end of the journal (from a crash mid-write) is discarded.
+func OpenJournalStore(dir string) (*JournalStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	sessions := NewMemorySessionRepository().(*MemorySessionRepository)
+	s := &JournalStore{
+		dir:        dir,
+		users:      NewMemoryUserRepository().(*MemoryUserRepository),
+		texts:      NewMemoryTextRepository().(*MemoryTextRepository),
+		sessions:   sessions,
+		tokens:     NewMemoryAPITokenRepository().(*MemoryAPITokenRepository),
+		keystrokes: NewMemoryKeystrokeRepository(sessions).(*MemoryKeystrokeRepository),
+		lines:      NewMemoryLineResultRepository(sessions).(*MemoryLineResultRepository),
+	}
+	if err := s.loadSnapshot(); err != nil {
+		return nil, err
+	}
+
+	journal, err := os.OpenFile(filepath.Join(dir, journalFileName), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	if err := s.replay(journal); err != nil {
+		journal.Close()
+		return nil, err
+	}
+	s.journal = journal
+	return s, nil
+}
+
+// Users returns the durable user repository.
+func (s *JournalStore) Users() *JournalUserRepository {
+	return &JournalUserRepository{MemoryUserRepository: s.users, store: s}
+}
+
+// Texts returns the durable text repository.
+func (s *JournalStore) Texts() *JournalTextRepository {
+	return &JournalTextRepository{MemoryTextRepository: s.texts, store: s}
+}
+
+// Sessions returns the durable session repository.
+func (s *JournalStore) Sessions() *JournalSessionRepository {
+	return &JournalSessionRepository{MemorySessionRepository: s.sessions, store: s}
+}
+
+// APITokens returns the durable API token repository.
+func (s *JournalStore) APITokens() *JournalAPITokenRepository {
+	return &JournalAPITokenRepository{MemoryAPITokenRepository: s.tokens, store: s}
+}
+
+// Keystrokes returns the durable keystroke repository.
+func (s *JournalStore) Keystrokes() *JournalKeystrokeRepository {
+	return &JournalKeystrokeRepository{MemoryKeystrokeRepository: s.keystrokes, store: s}
+}
+
+// LineResults returns the durable line result repository.
+func (s *JournalStore) LineResults() *JournalLineResultRepository {
+	return &JournalLineResultRepository{MemoryLineResultRepository: s.lines, store: s}
+}
+
+// Snapshot writes the full current state to the snapshot file and truncates the journal.
+// The snapshot is written to a temporary file and renamed into place, so a crash
+// leaves either the old or the new snapshot intact.
+func (s *JournalStore) Snapshot() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	texts, fragments := s.texts.all()
+	sessions := s.sessions.all()
+	snap := snapshot{
+		LastSeq:    s.seq,
+		CreatedAt:  time.Now(),
+		Users:      s.users.all(),
+		Texts:      texts,
+		Fragments:  make([]fragmentRecord, len(fragments)),
+		Sessions:   make([]*sessionRecord, 0, len(sessions)),
+		APITokens:  s.tokens.all(),
+		Keystrokes: s.keystrokes.all(),
+		Lines:      s.lines.all(),
+	}
+	for i, f := range fragments {
+		snap.Fragments[i] = fragmentRecord{ID: f.ID, TextID: f.TextID, FragmentIdx: f.FragmentIdx, Lines: f.Lines()}
+	}
+	for _, session := range sessions {
+		snap.Sessions = append(snap.Sessions, &sessionRecord{Session: *session})
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	path := filepath.Join(s.dir, snapshotFileName)
+	if err := writeFileSync(path+".tmp", data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to install snapshot: %w", err)
+	}
+	if err := syncDir(s.dir); err != nil {
+		return fmt.Errorf("failed to sync journal directory: %w", err)
+	}
+
+	// Records up to LastSeq are now in the snapshot; replay skips them even
+	// if we crash before the truncate below completes.
+	if err := s.journal.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate journal: %w", err)
+	}
+	if _, err := s.journal.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind journal: %w", err)
+	}
+	if err := s.journal.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	// Whatever a failed append left behind is gone with the truncate.
+	s.failed = nil
+	return nil
+}
+
+// RunCompaction calls Snapshot every interval until ctx is cancelled.
+// Errors are passed to onError (if non-nil) and do not stop the loop.
+func (s *JournalStore) RunCompaction(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Snapshot(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Close writes a final snapshot and closes the journal.
+func (s *JournalStore) Close() error {
+	snapErr := s.Snapshot()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(snapErr, s.journal.Close())
+}
+
+// commitFunc appends the journal record of a write. The in-memory repositories call
+// it, when non-nil, after the write passed their checks and before it changes any
+// state, so a write that cannot be journaled never reaches memory.
+type commitFunc func() error
+
+func (c commitFunc) run() error {
+	if c == nil {
+		return nil
+	}
+	return c()
+}
+
+// write journals op/arg ahead of the in-memory change. fn performs the change on a
+// repository, calling commit once the write is known to succeed; commit appends the
+// record and returns only after it is fsynced.
+func (s *JournalStore) write(op string, arg any, fn func(commit commitFunc) error) error {
+	data, err := json.Marshal(arg)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failed != nil {
+		return s.failed
+	}
+	return fn(func() error {
+		line, err := json.Marshal(journalRecord{Seq: s.seq + 1, Op: op, Data: data})
+		if err != nil {
+			return fmt.Errorf("failed to encode journal record: %w", err)
+		}
+		end, err := s.journal.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return fmt.Errorf("failed to locate journal end: %w", err)
+		}
+		if err := s.append(append(line, '\n')); err != nil {
+			// A partly written record would be replayed on restart, and the next
+			// record would reuse its seq and be skipped; cut it off.
+			if rerr := s.rewind(end); rerr != nil {
+				s.failed = fmt.Errorf("journal is unusable after a failed append: %w", errors.Join(err, rerr))
+			}
+			return err
+		}
+		s.seq++
+		return nil
+	})
+}
+
+// append writes a record to the journal and fsyncs it.
+func (s *JournalStore) append(record []byte) error {
+	if _, err := s.journal.Write(record); err != nil {
+		return fmt.Errorf("failed to append journal record: %w", err)
+	}
+	if err := s.journal.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	return nil
+}
+
+// rewind truncates the journal back to offset and moves the write position there.
+func (s *JournalStore) rewind(offset int64) error {
+	if err := s.journal.Truncate(offset); err != nil {
+		return err
+	}
+	if _, err := s.journal.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	return s.journal.Sync()
+}
+
+func (s *JournalStore) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(s.dir, snapshotFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	ctx := context.Background()
+	for _, user := range snap.Users {
+		if err := s.users.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to restore user %s: %w", user.ID, err)
+		}
+	}
+	for _, info := range snap.Texts {
+		if err := s.texts.CreateTextInfo(ctx, info); err != nil {
+			return fmt.Errorf("failed to restore text %s: %w", info.ID, err)
+		}
+	}
+	for _, rec := range snap.Fragments {
+		if err := s.applyFragment(ctx, rec); err != nil {
+			return fmt.Errorf("failed to restore fragment %s: %w", rec.ID, err)
+		}
+	}
+	for _, rec := range snap.Sessions {
+		session := rec.session()
+		if err := s.sessions.Create(ctx, session); err != nil {
+			return fmt.Errorf("failed to restore session %s: %w", session.ID, err)
+		}
+	}
+	for _, token := range snap.APITokens {
+		if err := s.tokens.Create(ctx, token); err != nil {
+			return fmt.Errorf("failed to restore api token %s: %w", token.ID, err)
+		}
+	}
+	if err := s.keystrokes.Append(ctx, snap.Keystrokes); err != nil {
+		return fmt.Errorf("failed to restore keystroke events: %w", err)
+	}
+	for _, line := range snap.Lines {
+		if err := s.lines.Create(ctx, line); err != nil {
+			return fmt.Errorf("failed to restore line result for session %s: %w", line.SessionID, err)
+		}
+	}
+	s.seq = snap.LastSeq
+	return nil
+}
+
+// replay applies every journal record newer than the snapshot. If the final
+// record is incomplete it is truncated away; corruption elsewhere is an error.
+func (s *JournalStore) replay(journal *os.File) error {
+	ctx := context.Background()
+	reader := bufio.NewReader(journal)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(bytes.TrimSpace(line)) > 0 {
+				// Torn write: drop the partial record.
+				if err := journal.Truncate(offset); err != nil {
+					return fmt.Errorf("failed to truncate torn journal record: %w", err)
+				}
+			}
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read journal: %w", err)
+		}
+
+		var rec journalRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return fmt.Errorf("corrupt journal record at offset %d: %w", offset, err)
+		}
+		offset += int64(len(line))
+		if rec.Seq <= s.seq {
+			continue
+		}
+		if err := s.apply(ctx, rec); err != nil {
+			return fmt.Errorf("failed to replay journal record %d (%s): %w", rec.Seq, rec.Op, err)
+		}
+		s.seq = rec.Seq
+	}
+	_, err := journal.Seek(offset, io.SeekStart)
+	return err
+}
+
+func (s *JournalStore) apply(ctx context.Context, rec journalRecord) error {
+	switch rec.Op {
+	case opUserCreate:
+		var user domain.User
+		if err := json.Unmarshal(rec.Data, &user); err != nil {
+			return err
+		}
+		return s.users.Create(ctx, &user)
+	case opTextCreate:
+		var info domain.TextInfo
+		if err := json.Unmarshal(rec.Data, &info); err != nil {
+			return err
+		}
+		return s.texts.CreateTextInfo(ctx, &info)
+	case opFragmentCreate:
+		var frag fragmentRecord
+		if err := json.Unmarshal(rec.Data, &frag); err != nil {
+			return err
+		}
+		return s.applyFragment(ctx, frag)
+	case opSessionCreate, opSessionUpdate:
+		var record sessionRecord
+		if err := json.Unmarshal(rec.Data, &record); err != nil {
+			return err
+		}
+		if rec.Op == opSessionCreate {
+			return s.sessions.Create(ctx, record.session())
+		}
+		return s.sessions.Update(ctx, record.session())
+	case opSessionAbandon:
+		var abandon sessionAbandonRecord
+		if err := json.Unmarshal(rec.Data, &abandon); err != nil {
+			return err
+		}
+		return s.sessions.AbandonIfIdle(ctx, abandon.ID, abandon.Before)
+	case opSessionStatus:
+		var change sessionStatusRecord
+		if err := json.Unmarshal(rec.Data, &change); err != nil {
+			return err
+		}
+		session := domain.Session{
+			ID:         change.ID,
+			Status:     change.Status,
+			PausedAt:   change.PausedAt,
+			PausedTime: change.PausedTime,
+			UpdatedAt:  change.UpdatedAt,
+		}
+		return s.sessions.UpdateStatus(ctx, &session, change.From)
+	case opTokenCreate, opTokenUpdate:
+		var token domain.APIToken
+		if err := json.Unmarshal(rec.Data, &token); err != nil {
+			return err
+		}
+		if rec.Op == opTokenCreate {
+			return s.tokens.Create(ctx, &token)
+		}
+		return s.tokens.Update(ctx, &token)
+	case opTokenUsed:
+		var used tokenUsedRecord
+		if err := json.Unmarshal(rec.Data, &used); err != nil {
+			return err
+		}
+		return s.tokens.MarkUsed(ctx, used.ID, used.At)
+	case opKeystrokesAdd:
+		var events []*domain.KeystrokeEvent
+		if err := json.Unmarshal(rec.Data, &events); err != nil {
+			return err
+		}
+		return s.keystrokes.Append(ctx, events)
+	case opLineResultAdd:
+		var line domain.LineResult
+		if err := json.Unmarshal(rec.Data, &line); err != nil {
+			return err
+		}
+		return s.lines.Create(ctx, &line)
+	default:
+		return fmt.Errorf("unknown journal operation %q", rec.Op)
+	}
+}
+
+func (s *JournalStore) applyFragment(ctx context.Context, rec fragmentRecord) error {
+	fragment, err := domain.NewTextFragment(rec.ID, rec.TextID, rec.FragmentIdx, rec.Lines)
+	if err != nil {
+		return err
+	}
+	return s.texts.CreateFragment(ctx, fragment)
+}
+
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+var (
+	_ repository.UserRepository       = (*JournalUserRepository)(nil)
+	_ repository.TextRepository       = (*JournalTextRepository)(nil)
+	_ repository.SessionRepository    = (*JournalSessionRepository)(nil)
+	_ repository.APITokenRepository   = (*JournalAPITokenRepository)(nil)
+	_ repository.KeystrokeRepository  = (*JournalKeystrokeRepository)(nil)
+	_ repository.LineResultRepository = (*JournalLineResultRepository)(nil)
+)
+
+// JournalUserRepository is a MemoryUserRepository whose writes are journaled by a JournalStore.
+type JournalUserRepository struct {
+	*MemoryUserRepository
+	store *JournalStore
+}
+
+func (r *JournalUserRepository) Create(ctx context.Context, user *domain.User) error {
+	return r.store.write(opUserCreate, user, func(commit commitFunc) error {
+		return r.MemoryUserRepository.create(user, commit)
+	})
+}
+
+// JournalTextRepository is a MemoryTextRepository whose writes are journaled by a JournalStore.
+type JournalTextRepository struct {
+	*MemoryTextRepository
+	store *JournalStore
+}
+
+func (r *JournalTextRepository) CreateTextInfo(ctx context.Context, info *domain.TextInfo) error {
+	return r.store.write(opTextCreate, info, func(commit commitFunc) error {
+		return r.MemoryTextRepository.createTextInfo(info, commit)
+	})
+}
+
+func (r *JournalTextRepository) CreateFragment(ctx context.Context, fragment *domain.TextFragment) error {
+	rec := fragmentRecord{
+		ID:          fragment.ID,
+		TextID:      fragment.TextID,
+		FragmentIdx: fragment.FragmentIdx,
+		Lines:       fragment.Lines(),
+	}
+	return r.store.write(opFragmentCreate, rec, func(commit commitFunc) error {
+		return r.MemoryTextRepository.createFragment(fragment, commit)
+	})
+}
+
+// JournalSessionRepository is a MemorySessionRepository whose writes are journaled by a JournalStore.
+type JournalSessionRepository struct {
+	*MemorySessionRepository
+	store *JournalStore
+}
+
+func (r *JournalSessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	return r.store.write(opSessionCreate, session, func(commit commitFunc) error {
+		return r.MemorySessionRepository.create(session, commit)
+	})
+}
+
+func (r *JournalSessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	return r.store.write(opSessionUpdate, session, func(commit commitFunc) error {
+		return r.MemorySessionRepository.update(session, commit)
+	})
+}
+
+func (r *JournalSessionRepository) UpdateStatus(ctx context.Context, session *domain.Session, from domain.SessionStatus) error {
+	rec := sessionStatusRecord{
+		ID:         session.ID,
+		From:       from,
+		Status:     session.Status,
+		PausedAt:   session.PausedAt,
+		PausedTime: session.PausedTime,
+		UpdatedAt:  session.UpdatedAt,
+	}
+	return r.store.write(opSessionStatus, rec, func(commit commitFunc) error {
+		return r.MemorySessionRepository.updateStatus(session, from, commit)
+	})
+}
+
+func (r *JournalSessionRepository) AbandonIfIdle(ctx context.Context, id domain.SessionID, before time.Time) error {
+	return r.store.write(opSessionAbandon, sessionAbandonRecord{ID: id, Before: before}, func(commit commitFunc) error {
+		return r.MemorySessionRepository.abandonIfIdle(id, before, commit)
+	})
+}
+
+// JournalAPITokenRepository is a MemoryAPITokenRepository whose writes are journaled by a JournalStore.
+type JournalAPITokenRepository struct {
+	*MemoryAPITokenRepository
+	store *JournalStore
+}
+
+func (r *JournalAPITokenRepository) Create(ctx context.Context, token *domain.APIToken) error {
+	return r.store.write(opTokenCreate, token, func(commit commitFunc) error {
+		return r.MemoryAPITokenRepository.create(token, commit)
+	})
+}
+
+func (r *JournalAPITokenRepository) Update(ctx context.Context, token *domain.APIToken) error {
+	return r.store.write(opTokenUpdate, token, func(commit commitFunc) error {
+		return r.MemoryAPITokenRepository.update(token, commit)
+	})
+}
+
+func (r *JournalAPITokenRepository) MarkUsed(ctx context.Context, id domain.APITokenID, at time.Time) error {
+	return r.store.write(opTokenUsed, tokenUsedRecord{ID: id, At: at}, func(commit commitFunc) error {
+		return r.MemoryAPITokenRepository.markUsed(id, at, commit)
+	})
+}
+
+// JournalKeystrokeRepository is a MemoryKeystrokeRepository whose appends are journaled by a JournalStore.
+// A batch is one journal record, so it is replayed entirely or not at all.
+type JournalKeystrokeRepository struct {
+	*MemoryKeystrokeRepository
+	store *JournalStore
+}
+
+func (r *JournalKeystrokeRepository) Append(ctx context.Context, events []*domain.KeystrokeEvent) error {
+	stored := make([]*domain.KeystrokeEvent, len(events))
+	for i, event := range events {
+		cp := *event
+		stored[i] = &cp
+	}
+	return r.store.write(opKeystrokesAdd, stored, func(commit commitFunc) error {
+		return r.MemoryKeystrokeRepository.append(stored, commit)
+	})
+}
+
+// JournalLineResultRepository is a MemoryLineResultRepository whose writes are journaled by a JournalStore.
+type JournalLineResultRepository struct {
+	*MemoryLineResultRepository
+	store *JournalStore
+}
+
+func (r *JournalLineResultRepository) Create(ctx context.Context, result *domain.LineResult) error {
+	stored := *result
+	return r.store.write(opLineResultAdd, &stored, func(commit commitFunc) error {
+		return r.MemoryLineResultRepository.create(&stored, commit)
+	})
+}
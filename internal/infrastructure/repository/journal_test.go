@@ -0,0 +1,465 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// seedJournalStore writes one of each record type through the durable repositories.
+func seedJournalStore(t *testing.T, store *JournalStore) *domain.Session {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now()
+
+	user, err := domain.NewUser("user_1", "test@example.com", "user1", now)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := store.Users().Create(ctx, user); err != nil {
+		t.Fatalf("Create user error = %v", err)
+	}
+	info, err := domain.NewTextInfo("text_1", user.ID, "Test Text", 2, 5, 1, now)
+	if err != nil {
+		t.Fatalf("Failed to create text info: %v", err)
+	}
+	if err := store.Texts().CreateTextInfo(ctx, info); err != nil {
+		t.Fatalf("CreateTextInfo() error = %v", err)
+	}
+	frag, err := domain.NewTextFragment("frag_1", info.ID, 0, []string{"line1", "line2"})
+	if err != nil {
+		t.Fatalf("Failed to create fragment: %v", err)
+	}
+	if err := store.Texts().CreateFragment(ctx, frag); err != nil {
+		t.Fatalf("CreateFragment() error = %v", err)
+	}
+	session, err := domain.NewSession("session_1", user.ID, info.ID, now)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := store.Sessions().Create(ctx, session); err != nil {
+		t.Fatalf("Create session error = %v", err)
+	}
+	if err := session.RecordLineCompleted(90, 40, 0, 0, now.Add(time.Second)); err != nil {
+		t.Fatalf("RecordLineCompleted() error = %v", err)
+	}
+	if err := store.Sessions().Update(ctx, session); err != nil {
+		t.Fatalf("Update session error = %v", err)
+	}
+	token, err := domain.NewAPIToken("token_1", user.ID, "ci", "hash_1", domain.ScopeRead, now)
+	if err != nil {
+		t.Fatalf("Failed to create api token: %v", err)
+	}
+	if err := store.APITokens().Create(ctx, token); err != nil {
+		t.Fatalf("Create api token error = %v", err)
+	}
+	if err := store.APITokens().MarkUsed(ctx, token.ID, now); err != nil {
+		t.Fatalf("MarkUsed api token error = %v", err)
+	}
+	token.MarkUsed(now)
+	token.Revoke(now)
+	if err := store.APITokens().Update(ctx, token); err != nil {
+		t.Fatalf("Update api token error = %v", err)
+	}
+	event, err := domain.NewKeystrokeEvent(session.ID, 0, "l", "l", 120, false, now)
+	if err != nil {
+		t.Fatalf("Failed to create keystroke event: %v", err)
+	}
+	if err := store.Keystrokes().Append(ctx, []*domain.KeystrokeEvent{event}); err != nil {
+		t.Fatalf("Append keystrokes error = %v", err)
+	}
+	line, err := domain.NewLineResult(session.ID, 0, 0, "line1", 90, 40, time.Second, 0, now)
+	if err != nil {
+		t.Fatalf("Failed to create line result: %v", err)
+	}
+	if err := store.LineResults().Create(ctx, line); err != nil {
+		t.Fatalf("Create line result error = %v", err)
+	}
+	return session
+}
+
+func assertJournalStoreState(t *testing.T, store *JournalStore, want *domain.Session) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := store.Users().GetByEmail(ctx, "test@example.com"); err != nil {
+		t.Errorf("GetByEmail() error = %v", err)
+	}
+	texts, err := store.Texts().ListByUserID(ctx, "user_1")
+	if err != nil || len(texts) != 1 {
+		t.Errorf("ListByUserID() = %v, %v, want 1 text", len(texts), err)
+	}
+	frag, err := store.Texts().GetFragment(ctx, "frag_1")
+	if err != nil {
+		t.Fatalf("GetFragment() error = %v", err)
+	}
+	if lines := frag.Lines(); len(lines) != 2 || lines[0] != "line1" {
+		t.Errorf("GetFragment() Lines = %v, want [line1 line2]", lines)
+	}
+	got, err := store.Sessions().GetByID(ctx, want.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.CompletedLines != want.CompletedLines || got.AverageWPM != want.AverageWPM {
+		t.Errorf("GetByID() CompletedLines/AverageWPM = %v/%v, want %v/%v",
+			got.CompletedLines, got.AverageWPM, want.CompletedLines, want.AverageWPM)
+	}
+	sessions, err := store.Sessions().ListByUserID(ctx, want.UserID)
+	if err != nil || len(sessions) != 1 || sessions[0].CompletedLines != want.CompletedLines {
+		t.Errorf("ListByUserID() sessions not restored: %v, %v", sessions, err)
+	}
+	token, err := store.APITokens().GetByHash(ctx, "hash_1")
+	if err != nil || !token.IsRevoked() || token.LastUsedAt == nil {
+		t.Errorf("GetByHash() token not restored: %v, %v", token, err)
+	}
+	events, err := store.Keystrokes().ListByLine(ctx, want.ID, 0)
+	if err != nil || len(events) != 1 || events[0].Key != "l" {
+		t.Errorf("ListByLine() keystrokes not restored: %v, %v", events, err)
+	}
+	lines, err := store.LineResults().ListBySessionID(ctx, want.ID)
+	if err != nil || len(lines) != 1 || lines[0].Typed != "line1" || lines[0].Duration != time.Second {
+		t.Errorf("ListBySessionID() line results not restored: %v, %v", lines, err)
+	}
+}
+
+func TestJournalUserRepository(t *testing.T) {
+	store, err := OpenJournalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	defer store.Close()
+	testUserRepository(t, store.Users())
+}
+
+func TestJournalStore_ReplayJournal(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	session := seedJournalStore(t, store)
+	// Simulate a crash: close the file without snapshotting.
+	store.journal.Close()
+
+	reopened, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() reopen error = %v", err)
+	}
+	defer reopened.Close()
+	assertJournalStoreState(t, reopened, session)
+}
+
+func TestJournalStore_Snapshot(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	session := seedJournalStore(t, store)
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, journalFileName))
+	if err != nil {
+		t.Fatalf("Stat journal error = %v", err)
+	}
+	if info.Size() != 0 {
+		t.Errorf("journal size after snapshot = %v, want 0", info.Size())
+	}
+
+	reopened, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() reopen error = %v", err)
+	}
+	defer reopened.Close()
+	assertJournalStoreState(t, reopened, session)
+}
+
+func TestJournalStore_SkipsRecordsCoveredBySnapshot(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	session := seedJournalStore(t, store)
+	journal, err := os.ReadFile(filepath.Join(dir, journalFileName))
+	if err != nil {
+		t.Fatalf("ReadFile journal error = %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Simulate a crash after the snapshot was installed but before the journal was truncated.
+	if err := os.WriteFile(filepath.Join(dir, journalFileName), journal, 0o644); err != nil {
+		t.Fatalf("WriteFile journal error = %v", err)
+	}
+
+	reopened, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() reopen error = %v", err)
+	}
+	defer reopened.Close()
+	assertJournalStoreState(t, reopened, session)
+}
+
+func TestJournalStore_TornRecord(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	session := seedJournalStore(t, store)
+	if _, err := store.journal.Write([]byte(`{"seq":99,"op":"user.cre`)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	store.journal.Close()
+
+	reopened, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() with torn record error = %v", err)
+	}
+	defer reopened.Close()
+	assertJournalStoreState(t, reopened, session)
+
+	// New writes must land after the last complete record.
+	user, _ := domain.NewUser("user_2", "test2@example.com", "user2", time.Now())
+	if err := reopened.Users().Create(context.Background(), user); err != nil {
+		t.Fatalf("Create user after torn record error = %v", err)
+	}
+}
+
+func TestJournalStore_FailedWriteNotJournaled(t *testing.T) {
+	store, err := OpenJournalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	user, _ := domain.NewUser("user_1", "test@example.com", "user1", time.Now())
+	if err := store.Users().Create(ctx, user); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	seq := store.seq
+	if err := store.Users().Create(ctx, user); err == nil {
+		t.Error("Create() expected error for duplicate user")
+	}
+	if store.seq != seq {
+		t.Errorf("seq after failed write = %v, want %v", store.seq, seq)
+	}
+}
+
+// failingJournal is a journal whose appends write half of the record and then fail,
+// as on a full disk. With failTruncate set, rolling the torn record back fails too.
+type failingJournal struct {
+	journalFile
+	failTruncate bool
+}
+
+func (j failingJournal) Write(p []byte) (int, error) {
+	n, _ := j.journalFile.Write(p[:len(p)/2])
+	return n, errors.New("no space left on device")
+}
+
+func (j failingJournal) Truncate(size int64) error {
+	if j.failTruncate {
+		return errors.New("input/output error")
+	}
+	return j.journalFile.Truncate(size)
+}
+
+func TestJournalStore_UnjournaledWriteNotApplied(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	ctx := context.Background()
+
+	journal := store.journal
+	store.journal = failingJournal{journalFile: journal}
+	user, _ := domain.NewUser("user_1", "test@example.com", "user1", time.Now())
+	if err := store.Users().Create(ctx, user); err == nil {
+		t.Fatal("Create() expected error when the journal append fails")
+	}
+	store.journal = journal
+
+	if _, err := store.Users().GetByID(ctx, user.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("GetByID() error = %v, want %v", err, repository.ErrNotFound)
+	}
+	if store.seq != 0 {
+		t.Errorf("seq after failed append = %v, want 0", store.seq)
+	}
+
+	// The snapshot taken on Close must not contain the rejected write either.
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	reopened, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() reopen error = %v", err)
+	}
+	defer reopened.Close()
+	if _, err := reopened.Users().GetByID(ctx, user.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("GetByID() after reopen error = %v, want %v", err, repository.ErrNotFound)
+	}
+}
+
+func TestJournalStore_WriteAfterFailedAppend(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	ctx := context.Background()
+
+	journal := store.journal
+	store.journal = failingJournal{journalFile: journal}
+	lost, _ := domain.NewUser("user_1", "test1@example.com", "user1", time.Now())
+	if err := store.Users().Create(ctx, lost); err == nil {
+		t.Fatal("Create() expected error when the journal append fails")
+	}
+	store.journal = journal
+	kept, _ := domain.NewUser("user_2", "test2@example.com", "user2", time.Now())
+	if err := store.Users().Create(ctx, kept); err != nil {
+		t.Fatalf("Create() after failed append error = %v", err)
+	}
+	// Simulate a crash: close the file without snapshotting.
+	store.journal.Close()
+
+	reopened, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() reopen error = %v", err)
+	}
+	defer reopened.Close()
+	if _, err := reopened.Users().GetByID(ctx, kept.ID); err != nil {
+		t.Errorf("GetByID(%s) after replay error = %v", kept.ID, err)
+	}
+	if _, err := reopened.Users().GetByID(ctx, lost.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("GetByID(%s) after replay error = %v, want %v", lost.ID, err, repository.ErrNotFound)
+	}
+}
+
+func TestJournalStore_FailedRollbackRefusesWrites(t *testing.T) {
+	store, err := OpenJournalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	journal := store.journal
+	store.journal = failingJournal{journalFile: journal, failTruncate: true}
+	user1, _ := domain.NewUser("user_1", "test1@example.com", "user1", time.Now())
+	if err := store.Users().Create(ctx, user1); err == nil {
+		t.Fatal("Create() expected error when the journal append fails")
+	}
+	store.journal = journal
+
+	user2, _ := domain.NewUser("user_2", "test2@example.com", "user2", time.Now())
+	if err := store.Users().Create(ctx, user2); err == nil {
+		t.Error("Create() expected error while the journal holds a torn record")
+	}
+
+	// A snapshot truncates the journal, which makes it usable again.
+	if err := store.Snapshot(); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if err := store.Users().Create(ctx, user2); err != nil {
+		t.Errorf("Create() after snapshot error = %v", err)
+	}
+}
+
+func TestJournalStore_ReplayAbandon(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	session := seedJournalStore(t, store)
+	if err := store.Sessions().AbandonIfIdle(context.Background(), session.ID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("AbandonIfIdle() error = %v", err)
+	}
+	store.journal.Close()
+
+	reopened, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() reopen error = %v", err)
+	}
+	defer reopened.Close()
+	got, err := reopened.Sessions().GetByID(context.Background(), session.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.SessionAbandoned || !got.UpdatedAt.Equal(session.UpdatedAt) {
+		t.Errorf("GetByID() Status = %v, UpdatedAt = %v, want abandoned as of %v", got.Status, got.UpdatedAt, session.UpdatedAt)
+	}
+}
+
+func TestJournalStore_ReplayUpdateStatus(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	session := seedJournalStore(t, store)
+	pausedAt := session.UpdatedAt.Add(time.Second)
+	if err := session.Pause(pausedAt); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if err := store.Sessions().UpdateStatus(context.Background(), session, domain.SessionActive); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	store.journal.Close()
+
+	reopened, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() reopen error = %v", err)
+	}
+	defer reopened.Close()
+	got, err := reopened.Sessions().GetByID(context.Background(), session.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.SessionPaused || got.PausedAt == nil || !got.PausedAt.Equal(pausedAt) ||
+		got.CompletedLines != session.CompletedLines {
+		t.Errorf("GetByID() = %v paused at %v with %v lines, want paused at %v with %v lines",
+			got.Status, got.PausedAt, got.CompletedLines, pausedAt, session.CompletedLines)
+	}
+}
+
+func TestJournalStore_LegacySessionStatus(t *testing.T) {
+	dir := t.TempDir()
+	// Sessions journaled before session statuses existed only have IsCompleted.
+	journal := `{"seq":1,"op":"session.create","data":{"ID":"session_1","UserID":"user_1","TextID":"text_1","IsCompleted":true}}
+{"seq":2,"op":"session.create","data":{"ID":"session_2","UserID":"user_1","TextID":"text_1","IsCompleted":false}}
+`
+	if err := os.WriteFile(filepath.Join(dir, journalFileName), []byte(journal), 0o644); err != nil {
+		t.Fatalf("WriteFile journal error = %v", err)
+	}
+
+	store, err := OpenJournalStore(dir)
+	if err != nil {
+		t.Fatalf("OpenJournalStore() error = %v", err)
+	}
+	defer store.Close()
+	for id, want := range map[domain.SessionID]domain.SessionStatus{
+		"session_1": domain.SessionCompleted,
+		"session_2": domain.SessionActive,
+	} {
+		got, err := store.Sessions().GetByID(context.Background(), id)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Status != want {
+			t.Errorf("GetByID(%s) Status = %v, want %v", id, got.Status, want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// MemoryAPITokenRepository is an in-memory implementation of APITokenRepository.
+// Tokens are stored and returned as copies, so callers never share a token with
+// the repository or with each other.
+type MemoryAPITokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[domain.APITokenID]*domain.APIToken
+	byHash map[string]*domain.APIToken
+	byUser map[domain.UserID][]*domain.APIToken
+}
+
+// NewMemoryAPITokenRepository creates a new in-memory API token repository.
+func NewMemoryAPITokenRepository() repository.APITokenRepository {
+	return &MemoryAPITokenRepository{
+		tokens: make(map[domain.APITokenID]*domain.APIToken),
+		byHash: make(map[string]*domain.APIToken),
+		byUser: make(map[domain.UserID][]*domain.APIToken),
+	}
+}
+
+func (r *MemoryAPITokenRepository) Create(ctx context.Context, token *domain.APIToken) error {
+	return r.create(token, nil)
+}
+
+func (r *MemoryAPITokenRepository) create(token *domain.APIToken, commit commitFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tokens[token.ID]; exists {
+		return fmt.Errorf("api token: %w", repository.ErrAlreadyExists)
+	}
+	if _, exists := r.byHash[token.TokenHash]; exists {
+		return fmt.Errorf("api token: %w", repository.ErrAlreadyExists)
+	}
+	if err := commit.run(); err != nil {
+		return err
+	}
+
+	stored := *token
+	r.tokens[token.ID] = &stored
+	r.byHash[token.TokenHash] = &stored
+	r.byUser[token.UserID] = append(r.byUser[token.UserID], &stored)
+	return nil
+}
+
+func (r *MemoryAPITokenRepository) GetByID(ctx context.Context, id domain.APITokenID) (*domain.APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, exists := r.tokens[id]
+	if !exists {
+		return nil, fmt.Errorf("api token: %w", repository.ErrNotFound)
+	}
+	cp := *token
+	return &cp, nil
+}
+
+func (r *MemoryAPITokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, exists := r.byHash[tokenHash]
+	if !exists {
+		return nil, fmt.Errorf("api token: %w", repository.ErrNotFound)
+	}
+	cp := *token
+	return &cp, nil
+}
+
+func (r *MemoryAPITokenRepository) Update(ctx context.Context, token *domain.APIToken) error {
+	return r.update(token, nil)
+}
+
+func (r *MemoryAPITokenRepository) update(token *domain.APIToken, commit commitFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.tokens[token.ID]
+	if !exists {
+		return fmt.Errorf("api token: %w", repository.ErrNotFound)
+	}
+	if err := commit.run(); err != nil {
+		return err
+	}
+	*stored = *token
+	return nil
+}
+
+func (r *MemoryAPITokenRepository) MarkUsed(ctx context.Context, id domain.APITokenID, at time.Time) error {
+	return r.markUsed(id, at, nil)
+}
+
+func (r *MemoryAPITokenRepository) markUsed(id domain.APITokenID, at time.Time, commit commitFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, exists := r.tokens[id]
+	if !exists || token.IsRevoked() {
+		return fmt.Errorf("api token: %w", repository.ErrNotFound)
+	}
+	if err := commit.run(); err != nil {
+		return err
+	}
+	token.MarkUsed(at)
+	return nil
+}
+
+func (r *MemoryAPITokenRepository) ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.APIToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.APIToken, len(r.byUser[userID]))
+	for i, token := range r.byUser[userID] {
+		cp := *token
+		result[i] = &cp
+	}
+	return result, nil
+}
+
+// all returns every stored token, preserving per-user insertion order. Used for snapshots.
+func (r *MemoryAPITokenRepository) all() []*domain.APIToken {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.APIToken, 0, len(r.tokens))
+	for _, list := range r.byUser {
+		result = append(result, list...)
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+func TestMemoryAPITokenRepository(t *testing.T) {
+	testAPITokenRepository(t, NewMemoryAPITokenRepository())
+}
+
+// testAPITokenRepository exercises an APITokenRepository implementation.
+func testAPITokenRepository(t *testing.T, repo repository.APITokenRepository) {
+	t.Helper()
+	ctx := context.Background()
+
+	now := time.Now()
+	token1, err := domain.NewAPIToken("token_1", "user_1", "ci", "hash_1", domain.ScopeRead, now)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	token2, err := domain.NewAPIToken("token_2", "user_1", "bot", "hash_2", domain.ScopeReadWrite, now)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	t.Run("Create and GetByHash", func(t *testing.T) {
+		if err := repo.Create(ctx, token1); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		got, err := repo.GetByHash(ctx, "hash_1")
+		if err != nil {
+			t.Fatalf("GetByHash() error = %v", err)
+		}
+		if got.ID != token1.ID || got.Scope != domain.ScopeRead || got.Name != "ci" {
+			t.Errorf("GetByHash() = %+v, want %+v", got, token1)
+		}
+		if got.LastUsedAt != nil || got.RevokedAt != nil {
+			t.Errorf("GetByHash() LastUsedAt/RevokedAt = %v/%v, want nil", got.LastUsedAt, got.RevokedAt)
+		}
+	})
+
+	t.Run("MarkUsed", func(t *testing.T) {
+		used := now.Add(time.Second)
+		if err := repo.MarkUsed(ctx, token1.ID, used); err != nil {
+			t.Fatalf("MarkUsed() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, token1.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.LastUsedAt == nil || !got.LastUsedAt.Equal(used) || got.IsRevoked() {
+			t.Errorf("MarkUsed() LastUsedAt = %v, revoked = %v, want %v and not revoked", got.LastUsedAt, got.IsRevoked(), used)
+		}
+		if err := repo.MarkUsed(ctx, "nonexistent", used); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("MarkUsed() non-existent error = %v, want %v", err, repository.ErrNotFound)
+		}
+	})
+
+	t.Run("GetByHash non-existent", func(t *testing.T) {
+		if _, err := repo.GetByHash(ctx, "nonexistent"); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetByHash() error = %v, want %v", err, repository.ErrNotFound)
+		}
+	})
+
+	t.Run("Create duplicate hash", func(t *testing.T) {
+		dup, _ := domain.NewAPIToken("token_dup", "user_2", "dup", "hash_1", domain.ScopeRead, now)
+		if err := repo.Create(ctx, dup); !errors.Is(err, repository.ErrAlreadyExists) {
+			t.Errorf("Create() error = %v, want %v", err, repository.ErrAlreadyExists)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		if err := repo.Create(ctx, token2); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		used := now.Add(time.Minute)
+		token2.MarkUsed(used)
+		token2.Revoke(used)
+		if err := repo.Update(ctx, token2); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, token2.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.LastUsedAt == nil || !got.LastUsedAt.Equal(used) || !got.IsRevoked() {
+			t.Errorf("Update() LastUsedAt = %v, revoked = %v, want %v and revoked", got.LastUsedAt, got.IsRevoked(), used)
+		}
+	})
+
+	t.Run("MarkUsed revoked", func(t *testing.T) {
+		if err := repo.MarkUsed(ctx, token2.ID, now.Add(time.Hour)); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("MarkUsed() error = %v, want %v", err, repository.ErrNotFound)
+		}
+
+		got, err := repo.GetByID(ctx, token2.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if !got.IsRevoked() || !got.LastUsedAt.Equal(now.Add(time.Minute)) {
+			t.Errorf("MarkUsed() on revoked token LastUsedAt = %v, revoked = %v, want unchanged", got.LastUsedAt, got.IsRevoked())
+		}
+	})
+
+	t.Run("Update non-existent", func(t *testing.T) {
+		missing, _ := domain.NewAPIToken("nonexistent", "user_1", "x", "hash_x", domain.ScopeRead, now)
+		if err := repo.Update(ctx, missing); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Update() error = %v, want %v", err, repository.ErrNotFound)
+		}
+	})
+
+	t.Run("ListByUserID", func(t *testing.T) {
+		tokens, err := repo.ListByUserID(ctx, "user_1")
+		if err != nil {
+			t.Fatalf("ListByUserID() error = %v", err)
+		}
+		if len(tokens) != 2 || tokens[0].ID != token1.ID || tokens[1].ID != token2.ID {
+			t.Errorf("ListByUserID() = %v, want [token_1 token_2]", tokens)
+		}
+
+		empty, err := repo.ListByUserID(ctx, "user_3")
+		if err != nil || len(empty) != 0 {
+			t.Errorf("ListByUserID() for unknown user = %v, %v, want empty", empty, err)
+		}
+	})
+}
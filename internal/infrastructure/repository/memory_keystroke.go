@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// MemoryKeystrokeRepository is an in-memory implementation of KeystrokeRepository.
+// Events are listed by user through the sessions they belong to.
+type MemoryKeystrokeRepository struct {
+	mu        sync.RWMutex
+	bySession map[domain.SessionID][]*domain.KeystrokeEvent
+	sessions  repository.SessionRepository
+}
+
+// NewMemoryKeystrokeRepository creates a new in-memory keystroke repository whose
+// events belong to the sessions of sessions.
+func NewMemoryKeystrokeRepository(sessions repository.SessionRepository) repository.KeystrokeRepository {
+	return &MemoryKeystrokeRepository{
+		bySession: make(map[domain.SessionID][]*domain.KeystrokeEvent),
+		sessions:  sessions,
+	}
+}
+
+func (r *MemoryKeystrokeRepository) Append(ctx context.Context, events []*domain.KeystrokeEvent) error {
+	return r.append(events, nil)
+}
+
+func (r *MemoryKeystrokeRepository) append(events []*domain.KeystrokeEvent, commit commitFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := commit.run(); err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		r.bySession[event.SessionID] = append(r.bySession[event.SessionID], event)
+	}
+	return nil
+}
+
+func (r *MemoryKeystrokeRepository) ListBySessionID(ctx context.Context, sessionID domain.SessionID) ([]*domain.KeystrokeEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.KeystrokeEvent, len(r.bySession[sessionID]))
+	copy(result, r.bySession[sessionID])
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LineIdx < result[j].LineIdx
+	})
+	return result, nil
+}
+
+func (r *MemoryKeystrokeRepository) ListByLine(ctx context.Context, sessionID domain.SessionID, lineIdx int) ([]*domain.KeystrokeEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*domain.KeystrokeEvent{}
+	for _, event := range r.bySession[sessionID] {
+		if event.LineIdx == lineIdx {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (r *MemoryKeystrokeRepository) CountByLine(ctx context.Context, sessionID domain.SessionID, lineIdx int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, event := range r.bySession[sessionID] {
+		if event.LineIdx == lineIdx {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *MemoryKeystrokeRepository) ListByUserID(ctx context.Context, userID domain.UserID, from, to time.Time) ([]*domain.KeystrokeEvent, error) {
+	sessions, err := listSessionsCreatedIn(ctx, r.sessions, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*domain.KeystrokeEvent{}
+	for _, session := range sessions {
+		events, err := r.ListBySessionID(ctx, session.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, events...)
+	}
+	return result, nil
+}
+
+// all returns every stored event, preserving per-session append order. Used for snapshots.
+func (r *MemoryKeystrokeRepository) all() []*domain.KeystrokeEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.KeystrokeEvent
+	for _, list := range r.bySession {
+		result = append(result, list...)
+	}
+	return result
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+func TestMemoryKeystrokeRepository(t *testing.T) {
+	sessions := NewMemorySessionRepository()
+	testKeystrokeRepository(t, NewMemoryKeystrokeRepository(sessions), sessions)
+}
+
+// testKeystrokeRepository exercises a KeystrokeRepository implementation whose
+// events belong to the sessions of sessions.
+func testKeystrokeRepository(t *testing.T, repo repository.KeystrokeRepository, sessions repository.SessionRepository) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+	createOwnedSessions(t, sessions, now)
+
+	newEvent := func(sessionID domain.SessionID, lineIdx int, key string, offsetMs int64) *domain.KeystrokeEvent {
+		event, err := domain.NewKeystrokeEvent(sessionID, lineIdx, key, "a", offsetMs, key == "Backspace", now)
+		if err != nil {
+			t.Fatalf("Failed to create keystroke event: %v", err)
+		}
+		return event
+	}
+
+	batches := [][]*domain.KeystrokeEvent{
+		{newEvent("session_1", 1, "b", 100), newEvent("session_1", 1, "Backspace", 180)},
+		{newEvent("session_1", 0, "a", 90), newEvent("session_2", 0, "x", 50)},
+		{newEvent("session_1", 1, "a", 260)},
+	}
+	for _, batch := range batches {
+		if err := repo.Append(ctx, batch); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	t.Run("ListByLine", func(t *testing.T) {
+		got, err := repo.ListByLine(ctx, "session_1", 1)
+		if err != nil {
+			t.Fatalf("ListByLine() error = %v", err)
+		}
+		wantKeys := []string{"b", "Backspace", "a"}
+		if len(got) != len(wantKeys) {
+			t.Fatalf("ListByLine() length = %v, want %v", len(got), len(wantKeys))
+		}
+		for i, key := range wantKeys {
+			if got[i].Key != key {
+				t.Errorf("ListByLine()[%d].Key = %v, want %v", i, got[i].Key, key)
+			}
+		}
+		if !got[1].IsCorrection || got[1].OffsetMs != 180 || !got[1].RecordedAt.Equal(now) {
+			t.Errorf("ListByLine()[1] = %+v, want correction at 180ms", got[1])
+		}
+		if count, err := repo.CountByLine(ctx, "session_1", 1); err != nil || count != len(wantKeys) {
+			t.Errorf("CountByLine() = %v, %v, want %v", count, err, len(wantKeys))
+		}
+	})
+
+	t.Run("ListBySessionID orders by line", func(t *testing.T) {
+		got, err := repo.ListBySessionID(ctx, "session_1")
+		if err != nil {
+			t.Fatalf("ListBySessionID() error = %v", err)
+		}
+		wantKeys := []string{"a", "b", "Backspace", "a"}
+		if len(got) != len(wantKeys) {
+			t.Fatalf("ListBySessionID() length = %v, want %v", len(got), len(wantKeys))
+		}
+		for i, key := range wantKeys {
+			if got[i].Key != key {
+				t.Errorf("ListBySessionID()[%d].Key = %v, want %v", i, got[i].Key, key)
+			}
+		}
+	})
+
+	t.Run("ListByUserID", func(t *testing.T) {
+		got, err := repo.ListByUserID(ctx, "user_1", time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatalf("ListByUserID() error = %v", err)
+		}
+		wantKeys := []string{"a", "b", "Backspace", "a"}
+		if len(got) != len(wantKeys) {
+			t.Fatalf("ListByUserID() length = %v, want %v", len(got), len(wantKeys))
+		}
+		for i, key := range wantKeys {
+			if got[i].SessionID != "session_1" || got[i].Key != key {
+				t.Errorf("ListByUserID()[%d] = %v %v, want session_1 %v", i, got[i].SessionID, got[i].Key, key)
+			}
+		}
+
+		got, err = repo.ListByUserID(ctx, "user_1", now.Add(time.Millisecond), time.Time{})
+		if err != nil || len(got) != 0 {
+			t.Errorf("ListByUserID() after the session = %v, %v, want empty", got, err)
+		}
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		got, err := repo.ListBySessionID(ctx, "nonexistent")
+		if err != nil {
+			t.Fatalf("ListBySessionID() error = %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("ListBySessionID() length = %v, want 0", len(got))
+		}
+	})
+}
+
+// createOwnedSessions stores session_1 of user_1 and session_2 of user_2, both created at now.
+func createOwnedSessions(t *testing.T, sessions repository.SessionRepository, now time.Time) {
+	t.Helper()
+	for id, userID := range map[domain.SessionID]domain.UserID{"session_1": "user_1", "session_2": "user_2"} {
+		session, err := domain.NewSession(id, userID, "text_1", now)
+		if err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		if err := sessions.Create(context.Background(), session); err != nil {
+			t.Fatalf("Create() session error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// lineKey identifies a line of a session.
+type lineKey struct {
+	fragmentIdx int
+	lineIdx     int
+}
+
+// MemoryLineResultRepository is an in-memory implementation of LineResultRepository.
+// Results are listed by user through the sessions they belong to.
+type MemoryLineResultRepository struct {
+	mu        sync.RWMutex
+	bySession map[domain.SessionID]map[lineKey]*domain.LineResult
+	sessions  repository.SessionRepository
+}
+
+// NewMemoryLineResultRepository creates a new in-memory line result repository whose
+// results belong to the sessions of sessions.
+func NewMemoryLineResultRepository(sessions repository.SessionRepository) repository.LineResultRepository {
+	return &MemoryLineResultRepository{
+		bySession: make(map[domain.SessionID]map[lineKey]*domain.LineResult),
+		sessions:  sessions,
+	}
+}
+
+func (r *MemoryLineResultRepository) Create(ctx context.Context, result *domain.LineResult) error {
+	return r.create(result, nil)
+}
+
+func (r *MemoryLineResultRepository) create(result *domain.LineResult, commit commitFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lines, ok := r.bySession[result.SessionID]
+	if !ok {
+		lines = make(map[lineKey]*domain.LineResult)
+		r.bySession[result.SessionID] = lines
+	}
+	key := lineKey{fragmentIdx: result.FragmentIdx, lineIdx: result.LineIdx}
+	if _, exists := lines[key]; exists {
+		return fmt.Errorf("line result: %w", repository.ErrAlreadyExists)
+	}
+	if err := commit.run(); err != nil {
+		return err
+	}
+	lines[key] = result
+	return nil
+}
+
+func (r *MemoryLineResultRepository) ListBySessionID(ctx context.Context, sessionID domain.SessionID) ([]*domain.LineResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.LineResult, 0, len(r.bySession[sessionID]))
+	for _, line := range r.bySession[sessionID] {
+		result = append(result, line)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].FragmentIdx != result[j].FragmentIdx {
+			return result[i].FragmentIdx < result[j].FragmentIdx
+		}
+		return result[i].LineIdx < result[j].LineIdx
+	})
+	return result, nil
+}
+
+func (r *MemoryLineResultRepository) ListByUserID(ctx context.Context, userID domain.UserID, from, to time.Time) ([]*domain.LineResult, error) {
+	sessions, err := listSessionsCreatedIn(ctx, r.sessions, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*domain.LineResult{}
+	for _, session := range sessions {
+		lines, err := r.ListBySessionID(ctx, session.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, lines...)
+	}
+	return result, nil
+}
+
+// all returns every stored result. Used for snapshots.
+func (r *MemoryLineResultRepository) all() []*domain.LineResult {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.LineResult
+	for _, lines := range r.bySession {
+		for _, line := range lines {
+			result = append(result, line)
+		}
+	}
+	return result
+}
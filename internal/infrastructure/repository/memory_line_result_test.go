@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+func TestMemoryLineResultRepository(t *testing.T) {
+	sessions := NewMemorySessionRepository()
+	testLineResultRepository(t, NewMemoryLineResultRepository(sessions), sessions)
+}
+
+// testLineResultRepository exercises a LineResultRepository implementation whose
+// results belong to the sessions of sessions.
+func testLineResultRepository(t *testing.T, repo repository.LineResultRepository, sessions repository.SessionRepository) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+	createOwnedSessions(t, sessions, now)
+
+	newResult := func(sessionID domain.SessionID, fragmentIdx, lineIdx int, typed string) *domain.LineResult {
+		result, err := domain.NewLineResult(sessionID, fragmentIdx, lineIdx, typed, 90, 40, 1500*time.Millisecond, 1, now)
+		if err != nil {
+			t.Fatalf("Failed to create line result: %v", err)
+		}
+		return result
+	}
+
+	for _, result := range []*domain.LineResult{
+		newResult("session_1", 1, 0, "line3"),
+		newResult("session_1", 0, 1, "line2"),
+		newResult("session_2", 0, 0, "other"),
+		newResult("session_1", 0, 0, "line1"),
+	} {
+		if err := repo.Create(ctx, result); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	t.Run("Create duplicate line", func(t *testing.T) {
+		if err := repo.Create(ctx, newResult("session_1", 0, 1, "again")); !errors.Is(err, repository.ErrAlreadyExists) {
+			t.Errorf("Create() error = %v, want %v", err, repository.ErrAlreadyExists)
+		}
+	})
+
+	t.Run("ListBySessionID orders by line", func(t *testing.T) {
+		got, err := repo.ListBySessionID(ctx, "session_1")
+		if err != nil {
+			t.Fatalf("ListBySessionID() error = %v", err)
+		}
+		wantTyped := []string{"line1", "line2", "line3"}
+		if len(got) != len(wantTyped) {
+			t.Fatalf("ListBySessionID() length = %v, want %v", len(got), len(wantTyped))
+		}
+		for i, typed := range wantTyped {
+			if got[i].Typed != typed {
+				t.Errorf("ListBySessionID()[%d].Typed = %v, want %v", i, got[i].Typed, typed)
+			}
+		}
+		first := got[0]
+		if first.AccuracyPercent != 90 || first.WPM != 40 || first.Errors != 1 ||
+			first.Duration != 1500*time.Millisecond || !first.RecordedAt.Equal(now) {
+			t.Errorf("ListBySessionID()[0] = %+v, want stored fields", first)
+		}
+	})
+
+	t.Run("ListByUserID", func(t *testing.T) {
+		got, err := repo.ListByUserID(ctx, "user_2", time.Time{}, now.Add(time.Millisecond))
+		if err != nil {
+			t.Fatalf("ListByUserID() error = %v", err)
+		}
+		if len(got) != 1 || got[0].Typed != "other" {
+			t.Errorf("ListByUserID() = %v results, want only session_2's", len(got))
+		}
+
+		got, err = repo.ListByUserID(ctx, "user_2", time.Time{}, now)
+		if err != nil || len(got) != 0 {
+			t.Errorf("ListByUserID() before the session = %v, %v, want empty", got, err)
+		}
+	})
+
+	t.Run("ListBySessionID unknown session", func(t *testing.T) {
+		got, err := repo.ListBySessionID(ctx, "nonexistent")
+		if err != nil || len(got) != 0 {
+			t.Errorf("ListBySessionID() = %v, %v, want empty", got, err)
+		}
+	})
+}
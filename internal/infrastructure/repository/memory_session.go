@@ -3,12 +3,16 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 	"typeten/internal/domain"
 	"typeten/internal/repository"
 )
 
 // MemorySessionRepository is an in-memory implementation of SessionRepository.
+// Sessions are stored and returned as copies, so a caller mutating a session it
+// read never races with other readers or with a snapshot.
 type MemorySessionRepository struct {
 	mu       sync.RWMutex
 	sessions map[domain.SessionID]*domain.Session
@@ -24,15 +28,23 @@ func NewMemorySessionRepository() repository.SessionRepository {
 }
 
 func (r *MemorySessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	return r.create(session, nil)
+}
+
+func (r *MemorySessionRepository) create(session *domain.Session, commit commitFunc) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
 	if _, exists := r.sessions[session.ID]; exists {
-		return fmt.Errorf("session already exists")
+		return fmt.Errorf("session: %w", repository.ErrAlreadyExists)
+	}
+	if err := commit.run(); err != nil {
+		return err
 	}
 	
-	r.sessions[session.ID] = session
-	r.byUser[session.UserID] = append(r.byUser[session.UserID], session)
+	stored := *session
+	r.sessions[session.ID] = &stored
+	r.byUser[session.UserID] = append(r.byUser[session.UserID], &stored)
 	return nil
 }
 
@@ -42,23 +54,74 @@ func (r *MemorySessionRepository) GetByID(ctx context.Context, id domain.Session
 	
 	session, exists := r.sessions[id]
 	if !exists {
-		return nil, fmt.Errorf("session not found")
+		return nil, fmt.Errorf("session: %w", repository.ErrNotFound)
 	}
-	return session, nil
+	cp := *session
+	return &cp, nil
 }
 
 func (r *MemorySessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	return r.update(session, nil)
+}
+
+func (r *MemorySessionRepository) update(session *domain.Session, commit commitFunc) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
-	if _, exists := r.sessions[session.ID]; !exists {
-		return fmt.Errorf("session not found")
+	stored, exists := r.sessions[session.ID]
+	if !exists {
+		return fmt.Errorf("session: %w", repository.ErrNotFound)
 	}
-	
-	r.sessions[session.ID] = session
+	if err := commit.run(); err != nil {
+		return err
+	}
+
+	*stored = *session
+	return nil
+}
+
+func (r *MemorySessionRepository) UpdateStatus(ctx context.Context, session *domain.Session, from domain.SessionStatus) error {
+	return r.updateStatus(session, from, nil)
+}
+
+func (r *MemorySessionRepository) updateStatus(session *domain.Session, from domain.SessionStatus, commit commitFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.sessions[session.ID]
+	if !exists || stored.Status != from {
+		return fmt.Errorf("session: %w", repository.ErrNotFound)
+	}
+	if err := commit.run(); err != nil {
+		return err
+	}
+
+	stored.Status = session.Status
+	stored.PausedAt = session.PausedAt
+	stored.PausedTime = session.PausedTime
+	stored.UpdatedAt = session.UpdatedAt
 	return nil
 }
 
+func (r *MemorySessionRepository) AbandonIfIdle(ctx context.Context, id domain.SessionID, before time.Time) error {
+	return r.abandonIfIdle(id, before, nil)
+}
+
+func (r *MemorySessionRepository) abandonIfIdle(id domain.SessionID, before time.Time, commit commitFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, exists := r.sessions[id]
+	if !exists || !session.IsOpen() || !session.UpdatedAt.Before(before) {
+		return fmt.Errorf("session: %w", repository.ErrNotFound)
+	}
+	if err := commit.run(); err != nil {
+		return err
+	}
+
+	return session.Abandon(session.UpdatedAt)
+}
+
 func (r *MemorySessionRepository) ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.Session, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -68,8 +131,79 @@ func (r *MemorySessionRepository) ListByUserID(ctx context.Context, userID domai
 		return []*domain.Session{}, nil
 	}
 	
-	// Return a copy
 	result := make([]*domain.Session, len(sessions))
-	copy(result, sessions)
+	for i, session := range sessions {
+		cp := *session
+		result[i] = &cp
+	}
 	return result, nil
 }
+
+func (r *MemorySessionRepository) ListPage(ctx context.Context, query repository.SessionQuery) ([]*domain.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*domain.Session{}
+	for _, session := range r.byUser[query.UserID] {
+		if query.Matches(session) {
+			cp := *session
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return repository.PositionOf(result[i]).Before(repository.PositionOf(result[j]))
+	})
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result, nil
+}
+
+func (r *MemorySessionRepository) ListIdle(ctx context.Context, before time.Time) ([]*domain.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []*domain.Session{}
+	for _, session := range r.sessions {
+		if session.IsOpen() && session.UpdatedAt.Before(before) {
+			cp := *session
+			result = append(result, &cp)
+		}
+	}
+	sortByUpdatedAt(result)
+	return result, nil
+}
+
+// listSessionsCreatedIn returns the user's sessions created in [from, to), oldest first.
+func listSessionsCreatedIn(ctx context.Context, sessions repository.SessionRepository, userID domain.UserID, from, to time.Time) ([]*domain.Session, error) {
+	page, err := sessions.ListPage(ctx, repository.SessionQuery{UserID: userID, From: from, To: to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	for i, j := 0, len(page)-1; i < j; i, j = i+1, j-1 {
+		page[i], page[j] = page[j], page[i]
+	}
+	return page, nil
+}
+
+// sortByUpdatedAt orders sessions by UpdatedAt, then ID, so map iteration order does not leak.
+func sortByUpdatedAt(sessions []*domain.Session) {
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].UpdatedAt.Equal(sessions[j].UpdatedAt) {
+			return sessions[i].UpdatedAt.Before(sessions[j].UpdatedAt)
+		}
+		return sessions[i].ID < sessions[j].ID
+	})
+}
+
+// all returns every stored session, preserving per-user insertion order. Used for snapshots.
+func (r *MemorySessionRepository) all() []*domain.Session {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.Session, 0, len(r.sessions))
+	for _, list := range r.byUser {
+		result = append(result, list...)
+	}
+	return result
+}
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 	"typeten/internal/domain"
+	"typeten/internal/repository"
 )
 
 func TestMemorySessionRepository(t *testing.T) {
@@ -41,15 +44,15 @@ func TestMemorySessionRepository(t *testing.T) {
 
 	t.Run("GetByID non-existent", func(t *testing.T) {
 		_, err := repo.GetByID(ctx, "nonexistent")
-		if err == nil {
-			t.Error("GetByID() expected error for non-existent session")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetByID() error = %v, want %v", err, repository.ErrNotFound)
 		}
 	})
 
 	t.Run("Create duplicate", func(t *testing.T) {
 		err := repo.Create(ctx, session1)
-		if err == nil {
-			t.Error("Create() expected error for duplicate session")
+		if !errors.Is(err, repository.ErrAlreadyExists) {
+			t.Errorf("Create() error = %v, want %v", err, repository.ErrAlreadyExists)
 		}
 	})
 
@@ -75,8 +78,47 @@ func TestMemorySessionRepository(t *testing.T) {
 	t.Run("Update non-existent", func(t *testing.T) {
 		nonExistent, _ := domain.NewSession("nonexistent", "user_1", "text_1", now)
 		err := repo.Update(ctx, nonExistent)
-		if err == nil {
-			t.Error("Update() expected error for non-existent session")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Update() error = %v, want %v", err, repository.ErrNotFound)
+		}
+	})
+
+	t.Run("UpdateStatus", func(t *testing.T) {
+		stale, err := repo.GetByID(ctx, session1.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		progressed := *stale
+		progressed.CompletedLines = 1
+		if err := repo.Update(ctx, &progressed); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		if err := stale.Pause(now); err != nil {
+			t.Fatalf("Pause() error = %v", err)
+		}
+		if err := repo.UpdateStatus(ctx, stale, domain.SessionCompleted); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("UpdateStatus() from wrong status error = %v, want %v", err, repository.ErrNotFound)
+		}
+		if err := repo.UpdateStatus(ctx, stale, domain.SessionActive); err != nil {
+			t.Fatalf("UpdateStatus() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, session1.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Status != domain.SessionPaused || got.PausedAt == nil || !got.PausedAt.Equal(now) {
+			t.Errorf("UpdateStatus() = %v paused at %v, want paused at %v", got.Status, got.PausedAt, now)
+		}
+		if got.CompletedLines != 1 {
+			t.Errorf("UpdateStatus() CompletedLines = %v, want the stored 1", got.CompletedLines)
+		}
+
+		// Put session_1 back as the later subtests expect it.
+		progressed.CompletedLines = 0
+		if err := repo.Update(ctx, &progressed); err != nil {
+			t.Fatalf("Update() error = %v", err)
 		}
 	})
 
@@ -90,6 +132,117 @@ func TestMemorySessionRepository(t *testing.T) {
 		}
 	})
 
+	t.Run("ListIdle", func(t *testing.T) {
+		if sessions, err := repo.ListIdle(ctx, now); err != nil || len(sessions) != 0 {
+			t.Errorf("ListIdle(now) = %v, %v, want no sessions", len(sessions), err)
+		}
+		if err := session2.MarkCompleted(now); err != nil {
+			t.Fatalf("MarkCompleted() error = %v", err)
+		}
+		if err := repo.Update(ctx, session2); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		sessions, err := repo.ListIdle(ctx, now.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("ListIdle() error = %v", err)
+		}
+		if len(sessions) != 1 || sessions[0].ID != session1.ID {
+			t.Errorf("ListIdle() = %v sessions, want only %v", len(sessions), session1.ID)
+		}
+	})
+
+	t.Run("AbandonIfIdle", func(t *testing.T) {
+		if err := repo.AbandonIfIdle(ctx, session1.ID, now); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("AbandonIfIdle() recent session error = %v, want %v", err, repository.ErrNotFound)
+		}
+		if err := repo.AbandonIfIdle(ctx, session2.ID, now.Add(time.Minute)); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("AbandonIfIdle() completed session error = %v, want %v", err, repository.ErrNotFound)
+		}
+		if err := repo.AbandonIfIdle(ctx, session1.ID, now.Add(time.Minute)); err != nil {
+			t.Fatalf("AbandonIfIdle() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, session1.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Status != domain.SessionAbandoned || !got.UpdatedAt.Equal(session1.UpdatedAt) {
+			t.Errorf("AbandonIfIdle() Status = %v, UpdatedAt = %v, want abandoned as of %v",
+				got.Status, got.UpdatedAt, session1.UpdatedAt)
+		}
+		if err := repo.AbandonIfIdle(ctx, session1.ID, now.Add(time.Minute)); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("AbandonIfIdle() twice error = %v, want %v", err, repository.ErrNotFound)
+		}
+	})
+
+	t.Run("Sessions are copied", func(t *testing.T) {
+		got, err := repo.GetByID(ctx, session2.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		got.CompletedLines = 99
+		session2.CompletedLines = 98
+
+		again, err := repo.GetByID(ctx, session2.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if again.CompletedLines != 5 {
+			t.Errorf("GetByID() CompletedLines = %v after mutating earlier copies, want 5", again.CompletedLines)
+		}
+	})
+
+	t.Run("ListPage", func(t *testing.T) {
+		// user_3's sessions, oldest first; the last two share a creation time.
+		var ids []domain.SessionID
+		for i, textID := range []domain.TextID{"text_a", "text_b", "text_a", "text_a"} {
+			createdAt := now.Add(time.Duration(min(i, 2)) * time.Hour)
+			id := domain.SessionID(fmt.Sprintf("page_%d", i))
+			session, err := domain.NewSession(id, "user_3", textID, createdAt)
+			if err != nil {
+				t.Fatalf("Failed to create session: %v", err)
+			}
+			if i == 0 {
+				session.Status = domain.SessionCompleted
+			}
+			if err := repo.Create(ctx, session); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			ids = append(ids, id)
+		}
+		completed := true
+		tests := []struct {
+			name  string
+			query repository.SessionQuery
+			want  []domain.SessionID
+		}{
+			{name: "all", query: repository.SessionQuery{UserID: "user_3"}, want: []domain.SessionID{ids[3], ids[2], ids[1], ids[0]}},
+			{name: "limit", query: repository.SessionQuery{UserID: "user_3", Limit: 2}, want: []domain.SessionID{ids[3], ids[2]}},
+			{name: "text", query: repository.SessionQuery{UserID: "user_3", TextID: "text_b"}, want: []domain.SessionID{ids[1]}},
+			{name: "completed", query: repository.SessionQuery{UserID: "user_3", Completed: &completed}, want: []domain.SessionID{ids[0]}},
+			{name: "status", query: repository.SessionQuery{UserID: "user_3", Status: domain.SessionActive, Limit: 1}, want: []domain.SessionID{ids[3]}},
+			{name: "time range", query: repository.SessionQuery{UserID: "user_3", From: now.Add(time.Hour), To: now.Add(2 * time.Hour)}, want: []domain.SessionID{ids[1]}},
+			{
+				name:  "after tie",
+				query: repository.SessionQuery{UserID: "user_3", After: &repository.SessionPosition{CreatedAt: now.Add(2 * time.Hour), ID: ids[3]}, Limit: 2},
+				want:  []domain.SessionID{ids[2], ids[1]},
+			},
+		}
+		for _, tt := range tests {
+			sessions, err := repo.ListPage(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("%s: ListPage() error = %v", tt.name, err)
+			}
+			got := make([]domain.SessionID, len(sessions))
+			for i, session := range sessions {
+				got[i] = session.ID
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("%s: ListPage() = %v, want %v", tt.name, got, tt.want)
+			}
+		}
+	})
+
 	t.Run("ListByUserID empty", func(t *testing.T) {
 		sessions, err := repo.ListByUserID(ctx, "nonexistent")
 		if err != nil {
@@ -28,11 +28,18 @@ func NewMemoryTextRepository() repository.TextRepository {
 }
 
 func (r *MemoryTextRepository) CreateTextInfo(ctx context.Context, info *domain.TextInfo) error {
+	return r.createTextInfo(info, nil)
+}
+
+func (r *MemoryTextRepository) createTextInfo(info *domain.TextInfo, commit commitFunc) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
 	if _, exists := r.texts[info.ID]; exists {
-		return fmt.Errorf("text already exists")
+		return fmt.Errorf("text: %w", repository.ErrAlreadyExists)
+	}
+	if err := commit.run(); err != nil {
+		return err
 	}
 	
 	r.texts[info.ID] = info
@@ -46,7 +53,7 @@ func (r *MemoryTextRepository) GetTextInfo(ctx context.Context, id domain.TextID
 	
 	info, exists := r.texts[id]
 	if !exists {
-		return nil, fmt.Errorf("text not found")
+		return nil, fmt.Errorf("text: %w", repository.ErrNotFound)
 	}
 	return info, nil
 }
@@ -67,11 +74,18 @@ func (r *MemoryTextRepository) ListByUserID(ctx context.Context, userID domain.U
 }
 
 func (r *MemoryTextRepository) CreateFragment(ctx context.Context, fragment *domain.TextFragment) error {
+	return r.createFragment(fragment, nil)
+}
+
+func (r *MemoryTextRepository) createFragment(fragment *domain.TextFragment, commit commitFunc) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
 	if _, exists := r.fragments[fragment.ID]; exists {
-		return fmt.Errorf("fragment already exists")
+		return fmt.Errorf("fragment: %w", repository.ErrAlreadyExists)
+	}
+	if err := commit.run(); err != nil {
+		return err
 	}
 	
 	r.fragments[fragment.ID] = fragment
@@ -85,7 +99,7 @@ func (r *MemoryTextRepository) GetFragment(ctx context.Context, id domain.TextFr
 	
 	fragment, exists := r.fragments[id]
 	if !exists {
-		return nil, fmt.Errorf("fragment not found")
+		return nil, fmt.Errorf("fragment: %w", repository.ErrNotFound)
 	}
 	return fragment, nil
 }
@@ -104,3 +118,20 @@ func (r *MemoryTextRepository) GetFragmentsByTextID(ctx context.Context, textID
 	copy(result, fragments)
 	return result, nil
 }
+
+// all returns every stored text and fragment, preserving per-user and per-text
+// insertion order so that replaying them rebuilds identical indexes. Used for snapshots.
+func (r *MemoryTextRepository) all() ([]*domain.TextInfo, []*domain.TextFragment) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	texts := make([]*domain.TextInfo, 0, len(r.texts))
+	for _, list := range r.byUser {
+		texts = append(texts, list...)
+	}
+	fragments := make([]*domain.TextFragment, 0, len(r.fragments))
+	for _, list := range r.byTextID {
+		fragments = append(fragments, list...)
+	}
+	return texts, fragments
+}
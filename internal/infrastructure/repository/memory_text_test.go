@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 	"typeten/internal/domain"
+	"typeten/internal/repository"
 )
 
 func TestMemoryTextRepository(t *testing.T) {
@@ -49,15 +51,15 @@ func TestMemoryTextRepository(t *testing.T) {
 
 	t.Run("GetTextInfo non-existent", func(t *testing.T) {
 		_, err := repo.GetTextInfo(ctx, "nonexistent")
-		if err == nil {
-			t.Error("GetTextInfo() expected error for non-existent text")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetTextInfo() error = %v, want %v", err, repository.ErrNotFound)
 		}
 	})
 
 	t.Run("CreateTextInfo duplicate", func(t *testing.T) {
 		err := repo.CreateTextInfo(ctx, textInfo)
-		if err == nil {
-			t.Error("CreateTextInfo() expected error for duplicate text")
+		if !errors.Is(err, repository.ErrAlreadyExists) {
+			t.Errorf("CreateTextInfo() error = %v, want %v", err, repository.ErrAlreadyExists)
 		}
 	})
 
@@ -100,8 +102,8 @@ func TestMemoryTextRepository(t *testing.T) {
 
 	t.Run("GetFragment non-existent", func(t *testing.T) {
 		_, err := repo.GetFragment(ctx, "nonexistent")
-		if err == nil {
-			t.Error("GetFragment() expected error for non-existent fragment")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetFragment() error = %v, want %v", err, repository.ErrNotFound)
 		}
 	})
 
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"typeten/internal/domain"
 	"typeten/internal/repository"
@@ -24,11 +25,21 @@ func NewMemoryUserRepository() repository.UserRepository {
 }
 
 func (r *MemoryUserRepository) Create(ctx context.Context, user *domain.User) error {
+	return r.create(user, nil)
+}
+
+func (r *MemoryUserRepository) create(user *domain.User, commit commitFunc) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
 	if _, exists := r.users[user.ID]; exists {
-		return fmt.Errorf("user already exists")
+		return fmt.Errorf("user: %w", repository.ErrAlreadyExists)
+	}
+	if _, exists := r.byEmail[user.Email]; exists {
+		return fmt.Errorf("user: %w", repository.ErrAlreadyExists)
+	}
+	if err := commit.run(); err != nil {
+		return err
 	}
 	
 	r.users[user.ID] = user
@@ -42,7 +53,7 @@ func (r *MemoryUserRepository) GetByID(ctx context.Context, id domain.UserID) (*
 	
 	user, exists := r.users[id]
 	if !exists {
-		return nil, fmt.Errorf("user not found")
+		return nil, fmt.Errorf("user: %w", repository.ErrNotFound)
 	}
 	return user, nil
 }
@@ -53,7 +64,20 @@ func (r *MemoryUserRepository) GetByEmail(ctx context.Context, email string) (*d
 	
 	user, exists := r.byEmail[email]
 	if !exists {
-		return nil, fmt.Errorf("user not found")
+		return nil, fmt.Errorf("user: %w", repository.ErrNotFound)
 	}
 	return user, nil
 }
+
+// all returns every stored user ordered by ID. Used for snapshots.
+func (r *MemoryUserRepository) all() []*domain.User {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.User, 0, len(r.users))
+	for _, user := range r.users {
+		result = append(result, user)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
@@ -2,14 +2,21 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 	"typeten/internal/domain"
+	"typeten/internal/repository"
 )
 
 func TestMemoryUserRepository(t *testing.T) {
+	testUserRepository(t, NewMemoryUserRepository())
+}
+
+// testUserRepository exercises a UserRepository implementation.
+func testUserRepository(t *testing.T, repo repository.UserRepository) {
+	t.Helper()
 	ctx := context.Background()
-	repo := NewMemoryUserRepository()
 
 	now := time.Now()
 	user1, err := domain.NewUser("user_1", "test1@example.com", "user1", now)
@@ -17,6 +24,8 @@ func TestMemoryUserRepository(t *testing.T) {
 		t.Fatalf("Failed to create user: %v", err)
 	}
 
+	user1.PasswordHash = "$2a$10$hash"
+
 	user2, err := domain.NewUser("user_2", "test2@example.com", "user2", now)
 	if err != nil {
 		t.Fatalf("Failed to create user: %v", err)
@@ -37,19 +46,37 @@ func TestMemoryUserRepository(t *testing.T) {
 		if got.Email != user1.Email {
 			t.Errorf("GetByID() Email = %v, want %v", got.Email, user1.Email)
 		}
+		if got.PasswordHash != user1.PasswordHash {
+			t.Errorf("GetByID() PasswordHash = %v, want %v", got.PasswordHash, user1.PasswordHash)
+		}
 	})
 
 	t.Run("GetByID non-existent", func(t *testing.T) {
 		_, err := repo.GetByID(ctx, "nonexistent")
-		if err == nil {
-			t.Error("GetByID() expected error for non-existent user")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetByID() error = %v, want %v", err, repository.ErrNotFound)
 		}
 	})
 
 	t.Run("Create duplicate", func(t *testing.T) {
 		err := repo.Create(ctx, user1)
-		if err == nil {
-			t.Error("Create() expected error for duplicate user")
+		if !errors.Is(err, repository.ErrAlreadyExists) {
+			t.Errorf("Create() error = %v, want %v", err, repository.ErrAlreadyExists)
+		}
+	})
+
+	t.Run("Create duplicate email", func(t *testing.T) {
+		dup, _ := domain.NewUser("user_dup", user1.Email, "dup", now)
+		if err := repo.Create(ctx, dup); !errors.Is(err, repository.ErrAlreadyExists) {
+			t.Errorf("Create() error = %v, want %v", err, repository.ErrAlreadyExists)
+		}
+
+		got, err := repo.GetByEmail(ctx, user1.Email)
+		if err != nil || got.ID != user1.ID {
+			t.Errorf("GetByEmail() = %v, %v, want %v", got, err, user1.ID)
+		}
+		if _, err := repo.GetByID(ctx, dup.ID); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetByID() error = %v, want %v", err, repository.ErrNotFound)
 		}
 	})
 
@@ -69,8 +96,8 @@ func TestMemoryUserRepository(t *testing.T) {
 
 	t.Run("GetByEmail non-existent", func(t *testing.T) {
 		_, err := repo.GetByEmail(ctx, "nonexistent@example.com")
-		if err == nil {
-			t.Error("GetByEmail() expected error for non-existent email")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetByEmail() error = %v, want %v", err, repository.ErrNotFound)
 		}
 	})
 }
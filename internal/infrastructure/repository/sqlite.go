@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+// OpenSQLite opens (or creates) the SQLite database at path. The schema is managed
+// by the migrations package and must be migrated before the repositories are used.
+// The returned *sql.DB is shared by the SQLite repositories; the caller must close it.
+func OpenSQLite(ctx context.Context, path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	// SQLite allows a single writer; one connection avoids "database is locked"
+	// errors and keeps ":memory:" databases shared across calls.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.ExecContext(ctx, "PRAGMA foreign_keys = ON; PRAGMA busy_timeout = 5000;"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to configure sqlite database: %w", err)
+	}
+	return db, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// prefixColumns qualifies each column of a comma-separated list with a table alias.
+func prefixColumns(alias, columns string) string {
+	list := strings.Split(columns, ",")
+	for i, column := range list {
+		list[i] = alias + "." + strings.TrimSpace(column)
+	}
+	return strings.Join(list, ", ")
+}
+
+func toUnixNano(t time.Time) int64 {
+	return t.UnixNano()
+}
+
+func fromUnixNano(n int64) time.Time {
+	return time.Unix(0, n)
+}
+
+// toNullUnixNano stores an optional time as NULL when unset.
+func toNullUnixNano(t *time.Time) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: toUnixNano(*t), Valid: true}
+}
+
+func fromNullUnixNano(v sql.NullInt64) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := fromUnixNano(v.Int64)
+	return &t
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// SQLiteAPITokenRepository is a SQLite implementation of APITokenRepository.
+type SQLiteAPITokenRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteAPITokenRepository creates a new SQLite API token repository backed by db.
+func NewSQLiteAPITokenRepository(db *sql.DB) repository.APITokenRepository {
+	return &SQLiteAPITokenRepository{db: db}
+}
+
+const apiTokenColumns = `id, user_id, name, token_hash, scope, created_at, last_used_at, revoked_at`
+
+func (r *SQLiteAPITokenRepository) Create(ctx context.Context, token *domain.APIToken) error {
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO api_tokens (`+apiTokenColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT DO NOTHING`,
+		string(token.ID), string(token.UserID), token.Name, token.TokenHash, string(token.Scope),
+		toUnixNano(token.CreatedAt), toNullUnixNano(token.LastUsedAt), toNullUnixNano(token.RevokedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert api token: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("api token: %w", repository.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (r *SQLiteAPITokenRepository) GetByID(ctx context.Context, id domain.APITokenID) (*domain.APIToken, error) {
+	return r.getOne(ctx, `SELECT `+apiTokenColumns+` FROM api_tokens WHERE id = ?`, string(id))
+}
+
+func (r *SQLiteAPITokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	return r.getOne(ctx, `SELECT `+apiTokenColumns+` FROM api_tokens WHERE token_hash = ?`, tokenHash)
+}
+
+func (r *SQLiteAPITokenRepository) Update(ctx context.Context, token *domain.APIToken) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE api_tokens SET name = ?, scope = ?, last_used_at = ?, revoked_at = ? WHERE id = ?`,
+		token.Name, string(token.Scope), toNullUnixNano(token.LastUsedAt), toNullUnixNano(token.RevokedAt),
+		string(token.ID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update api token: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("api token: %w", repository.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *SQLiteAPITokenRepository) MarkUsed(ctx context.Context, id domain.APITokenID, at time.Time) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE api_tokens SET last_used_at = ? WHERE id = ? AND revoked_at IS NULL`,
+		toUnixNano(at), string(id),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record api token use: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("api token: %w", repository.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *SQLiteAPITokenRepository) ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.APIToken, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+apiTokenColumns+` FROM api_tokens WHERE user_id = ? ORDER BY created_at, rowid`,
+		string(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []*domain.APIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read api token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+func (r *SQLiteAPITokenRepository) getOne(ctx context.Context, query string, arg any) (*domain.APIToken, error) {
+	token, err := scanAPIToken(r.db.QueryRowContext(ctx, query, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("api token: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read api token: %w", err)
+	}
+	return token, nil
+}
+
+func scanAPIToken(row rowScanner) (*domain.APIToken, error) {
+	var (
+		t          domain.APIToken
+		id         string
+		userID     string
+		scope      string
+		createdAt  int64
+		lastUsedAt sql.NullInt64
+		revokedAt  sql.NullInt64
+	)
+	if err := row.Scan(&id, &userID, &t.Name, &t.TokenHash, &scope, &createdAt, &lastUsedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	t.ID = domain.APITokenID(id)
+	t.UserID = domain.UserID(userID)
+	t.Scope = domain.TokenScope(scope)
+	t.CreatedAt = fromUnixNano(createdAt)
+	t.LastUsedAt = fromNullUnixNano(lastUsedAt)
+	t.RevokedAt = fromNullUnixNano(revokedAt)
+	return &t, nil
+}
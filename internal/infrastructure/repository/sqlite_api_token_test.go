@@ -0,0 +1,7 @@
+package repository
+
+import "testing"
+
+func TestSQLiteAPITokenRepository(t *testing.T) {
+	testAPITokenRepository(t, NewSQLiteAPITokenRepository(newTestSQLiteDB(t)))
+}
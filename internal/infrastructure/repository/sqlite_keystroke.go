@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// SQLiteKeystrokeRepository is a SQLite implementation of KeystrokeRepository.
+type SQLiteKeystrokeRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteKeystrokeRepository creates a new SQLite keystroke repository backed by db.
+func NewSQLiteKeystrokeRepository(db *sql.DB) repository.KeystrokeRepository {
+	return &SQLiteKeystrokeRepository{db: db}
+}
+
+const keystrokeColumns = `session_id, line_idx, key, expected, offset_ms, is_correction, recorded_at`
+
+// Append inserts all events in one transaction, so a batch is stored entirely or not at all.
+func (r *SQLiteKeystrokeRepository) Append(ctx context.Context, events []*domain.KeystrokeEvent) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx,
+		`INSERT INTO keystroke_events (`+keystrokeColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare keystroke insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, event := range events {
+		if _, err := stmt.ExecContext(ctx,
+			string(event.SessionID), event.LineIdx, event.Key, event.Expected,
+			event.OffsetMs, event.IsCorrection, toUnixNano(event.RecordedAt),
+		); err != nil {
+			return fmt.Errorf("failed to insert keystroke event: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit keystroke events: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteKeystrokeRepository) ListBySessionID(ctx context.Context, sessionID domain.SessionID) ([]*domain.KeystrokeEvent, error) {
+	return r.list(ctx,
+		`SELECT `+keystrokeColumns+` FROM keystroke_events WHERE session_id = ? ORDER BY line_idx, id`,
+		string(sessionID))
+}
+
+func (r *SQLiteKeystrokeRepository) ListByLine(ctx context.Context, sessionID domain.SessionID, lineIdx int) ([]*domain.KeystrokeEvent, error) {
+	return r.list(ctx,
+		`SELECT `+keystrokeColumns+` FROM keystroke_events WHERE session_id = ? AND line_idx = ? ORDER BY id`,
+		string(sessionID), lineIdx)
+}
+
+func (r *SQLiteKeystrokeRepository) CountByLine(ctx context.Context, sessionID domain.SessionID, lineIdx int) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM keystroke_events WHERE session_id = ? AND line_idx = ?`,
+		string(sessionID), lineIdx).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count keystroke events: %w", err)
+	}
+	return count, nil
+}
+
+func (r *SQLiteKeystrokeRepository) ListByUserID(ctx context.Context, userID domain.UserID, from, to time.Time) ([]*domain.KeystrokeEvent, error) {
+	where, args := sessionsCreatedIn(userID, from, to)
+	return r.list(ctx,
+		`SELECT `+prefixColumns("k", keystrokeColumns)+` FROM keystroke_events k JOIN sessions s ON s.id = k.session_id
+		 WHERE `+where+` ORDER BY s.created_at, s.id, k.line_idx, k.id`,
+		args...)
+}
+
+func (r *SQLiteKeystrokeRepository) list(ctx context.Context, query string, args ...any) ([]*domain.KeystrokeEvent, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keystroke events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*domain.KeystrokeEvent{}
+	for rows.Next() {
+		var (
+			e          domain.KeystrokeEvent
+			sessionID  string
+			recordedAt int64
+		)
+		if err := rows.Scan(&sessionID, &e.LineIdx, &e.Key, &e.Expected, &e.OffsetMs, &e.IsCorrection, &recordedAt); err != nil {
+			return nil, fmt.Errorf("failed to read keystroke event: %w", err)
+		}
+		e.SessionID = domain.SessionID(sessionID)
+		e.RecordedAt = fromUnixNano(recordedAt)
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list keystroke events: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "testing"
+
+func TestSQLiteKeystrokeRepository(t *testing.T) {
+	db := newTestSQLiteDB(t)
+	testKeystrokeRepository(t, NewSQLiteKeystrokeRepository(db), NewSQLiteSessionRepository(db))
+}
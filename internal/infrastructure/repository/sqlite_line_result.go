@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// SQLiteLineResultRepository is a SQLite implementation of LineResultRepository.
+type SQLiteLineResultRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteLineResultRepository creates a new SQLite line result repository backed by db.
+func NewSQLiteLineResultRepository(db *sql.DB) repository.LineResultRepository {
+	return &SQLiteLineResultRepository{db: db}
+}
+
+const lineResultColumns = `session_id, fragment_idx, line_idx, typed, accuracy_percent, wpm, duration_ms, errors, recorded_at`
+
+func (r *SQLiteLineResultRepository) Create(ctx context.Context, result *domain.LineResult) error {
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO line_results (`+lineResultColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT DO NOTHING`,
+		string(result.SessionID), result.FragmentIdx, result.LineIdx, result.Typed,
+		result.AccuracyPercent, result.WPM, result.Duration.Milliseconds(), result.Errors,
+		toUnixNano(result.RecordedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert line result: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("line result: %w", repository.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (r *SQLiteLineResultRepository) ListBySessionID(ctx context.Context, sessionID domain.SessionID) ([]*domain.LineResult, error) {
+	return r.list(ctx,
+		`SELECT `+lineResultColumns+` FROM line_results WHERE session_id = ? ORDER BY fragment_idx, line_idx`,
+		string(sessionID))
+}
+
+func (r *SQLiteLineResultRepository) ListByUserID(ctx context.Context, userID domain.UserID, from, to time.Time) ([]*domain.LineResult, error) {
+	where, args := sessionsCreatedIn(userID, from, to)
+	return r.list(ctx,
+		`SELECT `+prefixColumns("l", lineResultColumns)+` FROM line_results l JOIN sessions s ON s.id = l.session_id
+		 WHERE `+where+` ORDER BY s.created_at, s.id, l.fragment_idx, l.line_idx`,
+		args...)
+}
+
+func (r *SQLiteLineResultRepository) list(ctx context.Context, query string, args ...any) ([]*domain.LineResult, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list line results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*domain.LineResult{}
+	for rows.Next() {
+		var (
+			lr         domain.LineResult
+			id         string
+			durationMs int64
+			recordedAt int64
+		)
+		if err := rows.Scan(&id, &lr.FragmentIdx, &lr.LineIdx, &lr.Typed,
+			&lr.AccuracyPercent, &lr.WPM, &durationMs, &lr.Errors, &recordedAt); err != nil {
+			return nil, fmt.Errorf("failed to read line result: %w", err)
+		}
+		lr.SessionID = domain.SessionID(id)
+		lr.Duration = time.Duration(durationMs) * time.Millisecond
+		lr.RecordedAt = fromUnixNano(recordedAt)
+		results = append(results, &lr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list line results: %w", err)
+	}
+	return results, nil
+}
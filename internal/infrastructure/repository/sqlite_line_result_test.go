@@ -0,0 +1,8 @@
+package repository
+
+import "testing"
+
+func TestSQLiteLineResultRepository(t *testing.T) {
+	db := newTestSQLiteDB(t)
+	testLineResultRepository(t, NewSQLiteLineResultRepository(db), NewSQLiteSessionRepository(db))
+}
@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// SQLiteSessionRepository is a SQLite implementation of SessionRepository.
+type SQLiteSessionRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteSessionRepository creates a new SQLite session repository backed by db.
+func NewSQLiteSessionRepository(db *sql.DB) repository.SessionRepository {
+	return &SQLiteSessionRepository{db: db}
+}
+
+const sessionColumns = `id, user_id, text_id, total_lines, fragment_size, current_fragment_idx, current_line_idx,
+	completed_lines, total_accuracy_percent, average_wpm, mode, mode_limit, completed_words, practice_ms,
+	status, paused_at, paused_ms, created_at, updated_at`
+
+func (r *SQLiteSessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO sessions (`+sessionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT DO NOTHING`,
+		string(session.ID), string(session.UserID), string(session.TextID),
+		session.TotalLines, session.FragmentSize,
+		session.CurrentFragmentIdx, session.CurrentLineIdx, session.CompletedLines,
+		session.TotalAccuracyPercent, session.AverageWPM,
+		sessionModeKind(session.Mode), session.Mode.Limit, session.CompletedWords, session.PracticeTime.Milliseconds(),
+		string(session.Status), toNullUnixNano(session.PausedAt), session.PausedTime.Milliseconds(),
+		toUnixNano(session.CreatedAt), toUnixNano(session.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert session: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("session: %w", repository.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (r *SQLiteSessionRepository) GetByID(ctx context.Context, id domain.SessionID) (*domain.Session, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+sessionColumns+` FROM sessions WHERE id = ?`, string(id))
+	session, err := scanSession(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("session: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	return session, nil
+}
+
+func (r *SQLiteSessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE sessions SET
+			total_lines = ?, fragment_size = ?, current_fragment_idx = ?, current_line_idx = ?, completed_lines = ?,
+			total_accuracy_percent = ?, average_wpm = ?, mode = ?, mode_limit = ?, completed_words = ?, practice_ms = ?,
+			status = ?, paused_at = ?, paused_ms = ?, updated_at = ?
+		 WHERE id = ?`,
+		session.TotalLines, session.FragmentSize, session.CurrentFragmentIdx, session.CurrentLineIdx, session.CompletedLines,
+		session.TotalAccuracyPercent, session.AverageWPM,
+		sessionModeKind(session.Mode), session.Mode.Limit, session.CompletedWords, session.PracticeTime.Milliseconds(),
+		string(session.Status), toNullUnixNano(session.PausedAt), session.PausedTime.Milliseconds(),
+		toUnixNano(session.UpdatedAt), string(session.ID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("session: %w", repository.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *SQLiteSessionRepository) UpdateStatus(ctx context.Context, session *domain.Session, from domain.SessionStatus) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE sessions SET status = ?, paused_at = ?, paused_ms = ?, updated_at = ?
+		 WHERE id = ? AND status = ?`,
+		string(session.Status), toNullUnixNano(session.PausedAt), session.PausedTime.Milliseconds(),
+		toUnixNano(session.UpdatedAt), string(session.ID), string(from),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update session status: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("session: %w", repository.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *SQLiteSessionRepository) AbandonIfIdle(ctx context.Context, id domain.SessionID, before time.Time) error {
+	// Mirrors Session.Abandon(UpdatedAt): a pause in progress ends at the last update.
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE sessions SET
+			paused_ms = paused_ms + CASE WHEN status = ? AND paused_at IS NOT NULL
+				THEN max(0, (updated_at - paused_at) / 1000000) ELSE 0 END,
+			paused_at = NULL, status = ?
+		 WHERE id = ? AND status IN (?, ?) AND updated_at < ?`,
+		string(domain.SessionPaused), string(domain.SessionAbandoned),
+		string(id), string(domain.SessionActive), string(domain.SessionPaused), toUnixNano(before),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to abandon session: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("session: %w", repository.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *SQLiteSessionRepository) ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.Session, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+sessionColumns+` FROM sessions WHERE user_id = ? ORDER BY created_at, rowid`,
+		string(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*domain.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *SQLiteSessionRepository) ListPage(ctx context.Context, query repository.SessionQuery) ([]*domain.Session, error) {
+	where := []string{"user_id = ?"}
+	args := []any{string(query.UserID)}
+	if query.TextID != "" {
+		where = append(where, "text_id = ?")
+		args = append(args, string(query.TextID))
+	}
+	if query.Completed != nil {
+		op := "<>"
+		if *query.Completed {
+			op = "="
+		}
+		where = append(where, "status "+op+" ?")
+		args = append(args, string(domain.SessionCompleted))
+	}
+	if query.Status != "" {
+		where = append(where, "status = ?")
+		args = append(args, string(query.Status))
+	}
+	if !query.From.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, toUnixNano(query.From))
+	}
+	if !query.To.IsZero() {
+		where = append(where, "created_at < ?")
+		args = append(args, toUnixNano(query.To))
+	}
+	if query.After != nil {
+		after := toUnixNano(query.After.CreatedAt)
+		where = append(where, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, after, after, string(query.After.ID))
+	}
+	sqlQuery := `SELECT ` + sessionColumns + ` FROM sessions WHERE ` + strings.Join(where, " AND ") +
+		` ORDER BY created_at DESC, id DESC`
+	if query.Limit > 0 {
+		sqlQuery += ` LIMIT ?`
+		args = append(args, query.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*domain.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *SQLiteSessionRepository) ListIdle(ctx context.Context, before time.Time) ([]*domain.Session, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+sessionColumns+` FROM sessions WHERE status IN (?, ?) AND updated_at < ? ORDER BY updated_at, id`,
+		string(domain.SessionActive), string(domain.SessionPaused), toUnixNano(before))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list idle sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*domain.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list idle sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// sessionsCreatedIn returns the condition on sessions aliased s that selects the
+// user's sessions created in [from, to), and its arguments.
+func sessionsCreatedIn(userID domain.UserID, from, to time.Time) (string, []any) {
+	where := "s.user_id = ?"
+	args := []any{string(userID)}
+	if !from.IsZero() {
+		where += " AND s.created_at >= ?"
+		args = append(args, toUnixNano(from))
+	}
+	if !to.IsZero() {
+		where += " AND s.created_at < ?"
+		args = append(args, toUnixNano(to))
+	}
+	return where, args
+}
+
+func scanSession(row rowScanner) (*domain.Session, error) {
+	var (
+		s          domain.Session
+		id         string
+		userID     string
+		textID     string
+		mode       string
+		practiceMs int64
+		status     string
+		pausedAt   sql.NullInt64
+		pausedMs   int64
+		createdAt  int64
+		updatedAt  int64
+	)
+	if err := row.Scan(
+		&id, &userID, &textID,
+		&s.TotalLines, &s.FragmentSize,
+		&s.CurrentFragmentIdx, &s.CurrentLineIdx, &s.CompletedLines,
+		&s.TotalAccuracyPercent, &s.AverageWPM,
+		&mode, &s.Mode.Limit, &s.CompletedWords, &practiceMs,
+		&status, &pausedAt, &pausedMs, &createdAt, &updatedAt,
+	); err != nil {
+		return nil, err
+	}
+	s.ID = domain.SessionID(id)
+	s.UserID = domain.UserID(userID)
+	s.TextID = domain.TextID(textID)
+	s.Mode.Kind = domain.SessionModeKind(mode)
+	s.PracticeTime = time.Duration(practiceMs) * time.Millisecond
+	s.Status = domain.SessionStatus(status)
+	s.PausedAt = fromNullUnixNano(pausedAt)
+	s.PausedTime = time.Duration(pausedMs) * time.Millisecond
+	s.CreatedAt = fromUnixNano(createdAt)
+	s.UpdatedAt = fromUnixNano(updatedAt)
+	return &s, nil
+}
+
+// sessionModeKind returns the stored kind of mode; the zero mode is full text.
+func sessionModeKind(mode domain.SessionMode) string {
+	if mode.IsFullText() {
+		return string(domain.SessionModeFullText)
+	}
+	return string(mode.Kind)
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+func TestSQLiteSessionRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSQLiteSessionRepository(newTestSQLiteDB(t))
+
+	now := time.Now()
+	session1, err := domain.NewSession("session_1", "user_1", "text_1", now)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	session2, err := domain.NewSession("session_2", "user_1", "text_2", now)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	t.Run("Create and GetByID", func(t *testing.T) {
+		if err := repo.Create(ctx, session1); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, session1.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.ID != session1.ID {
+			t.Errorf("GetByID() ID = %v, want %v", got.ID, session1.ID)
+		}
+		if got.UserID != session1.UserID {
+			t.Errorf("GetByID() UserID = %v, want %v", got.UserID, session1.UserID)
+		}
+		if got.Mode.Kind != domain.SessionModeFullText {
+			t.Errorf("GetByID() Mode = %+v, want full text", got.Mode)
+		}
+	})
+
+	t.Run("GetByID non-existent", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, "nonexistent")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetByID() error = %v, want %v", err, repository.ErrNotFound)
+		}
+	})
+
+	t.Run("Create duplicate", func(t *testing.T) {
+		err := repo.Create(ctx, session1)
+		if !errors.Is(err, repository.ErrAlreadyExists) {
+			t.Errorf("Create() error = %v, want %v", err, repository.ErrAlreadyExists)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		if err := repo.Create(ctx, session2); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		if err := session2.SetLayout(8, 3); err != nil {
+			t.Fatalf("SetLayout() error = %v", err)
+		}
+		session2.CompletedLines = 5
+		session2.CurrentFragmentIdx, session2.CurrentLineIdx = 1, 2
+		session2.TotalAccuracyPercent = 97.5
+		session2.Mode = domain.SessionMode{Kind: domain.SessionModeWords, Limit: 50}
+		session2.CompletedWords = 42
+		session2.PracticeTime = 90500 * time.Millisecond
+		session2.Status = domain.SessionCompleted
+		if err := repo.Update(ctx, session2); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, session2.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.CompletedLines != 5 {
+			t.Errorf("Update() CompletedLines = %v, want 5", got.CompletedLines)
+		}
+		if got.TotalAccuracyPercent != 97.5 {
+			t.Errorf("Update() TotalAccuracyPercent = %v, want 97.5", got.TotalAccuracyPercent)
+		}
+		if !got.IsCompleted() {
+			t.Error("Update() IsCompleted = false, want true")
+		}
+		if got.TotalLines != 8 || got.FragmentSize != 3 {
+			t.Errorf("Update() layout = %v/%v, want 8/3", got.TotalLines, got.FragmentSize)
+		}
+		if got.CurrentFragmentIdx != 1 || got.CurrentLineIdx != 2 {
+			t.Errorf("Update() cursor = (%v, %v), want (1, 2)", got.CurrentFragmentIdx, got.CurrentLineIdx)
+		}
+		if got.Mode != session2.Mode || got.CompletedWords != 42 || got.PracticeTime != session2.PracticeTime {
+			t.Errorf("Update() mode = %+v, %v words, %v, want %+v, 42 words, %v",
+				got.Mode, got.CompletedWords, got.PracticeTime, session2.Mode, session2.PracticeTime)
+		}
+	})
+
+	t.Run("Update non-existent", func(t *testing.T) {
+		nonExistent, _ := domain.NewSession("nonexistent", "user_1", "text_1", now)
+		err := repo.Update(ctx, nonExistent)
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("Update() error = %v, want %v", err, repository.ErrNotFound)
+		}
+	})
+
+	t.Run("UpdateStatus", func(t *testing.T) {
+		stale, err := repo.GetByID(ctx, session1.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		progressed := *stale
+		progressed.CompletedLines = 1
+		if err := repo.Update(ctx, &progressed); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		if err := stale.Pause(now); err != nil {
+			t.Fatalf("Pause() error = %v", err)
+		}
+		if err := repo.UpdateStatus(ctx, stale, domain.SessionCompleted); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("UpdateStatus() from wrong status error = %v, want %v", err, repository.ErrNotFound)
+		}
+		if err := repo.UpdateStatus(ctx, stale, domain.SessionActive); err != nil {
+			t.Fatalf("UpdateStatus() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, session1.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Status != domain.SessionPaused || got.PausedAt == nil || !got.PausedAt.Equal(now) {
+			t.Errorf("UpdateStatus() = %v paused at %v, want paused at %v", got.Status, got.PausedAt, now)
+		}
+		if got.CompletedLines != 1 {
+			t.Errorf("UpdateStatus() CompletedLines = %v, want the stored 1", got.CompletedLines)
+		}
+
+		// Put session_1 back as the later subtests expect it.
+		progressed.CompletedLines = 0
+		if err := repo.Update(ctx, &progressed); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	})
+
+	t.Run("ListByUserID", func(t *testing.T) {
+		sessions, err := repo.ListByUserID(ctx, "user_1")
+		if err != nil {
+			t.Fatalf("ListByUserID() error = %v", err)
+		}
+		if len(sessions) < 2 {
+			t.Errorf("ListByUserID() length = %v, want at least 2", len(sessions))
+		}
+	})
+
+	t.Run("Pause and ListIdle", func(t *testing.T) {
+		if sessions, err := repo.ListIdle(ctx, now); err != nil || len(sessions) != 0 {
+			t.Errorf("ListIdle(now) = %v, %v, want no sessions", len(sessions), err)
+		}
+		pausedAt := now.Add(time.Second)
+		if err := session1.Pause(pausedAt); err != nil {
+			t.Fatalf("Pause() error = %v", err)
+		}
+		session1.PausedTime = 1500 * time.Millisecond
+		if err := repo.Update(ctx, session1); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		sessions, err := repo.ListIdle(ctx, now.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("ListIdle() error = %v", err)
+		}
+		// session_2 was completed by Update above.
+		if len(sessions) != 1 || sessions[0].ID != session1.ID {
+			t.Fatalf("ListIdle() = %v sessions, want only %v", len(sessions), session1.ID)
+		}
+		got := sessions[0]
+		if got.Status != domain.SessionPaused || got.PausedAt == nil || !got.PausedAt.Equal(pausedAt) || got.PausedTime != session1.PausedTime {
+			t.Errorf("ListIdle() pause = %v, %v, %v, want paused at %v after %v",
+				got.Status, got.PausedAt, got.PausedTime, pausedAt, session1.PausedTime)
+		}
+	})
+
+	t.Run("AbandonIfIdle", func(t *testing.T) {
+		if err := repo.AbandonIfIdle(ctx, session1.ID, now); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("AbandonIfIdle() recent session error = %v, want %v", err, repository.ErrNotFound)
+		}
+		if err := repo.AbandonIfIdle(ctx, session1.ID, now.Add(time.Minute)); err != nil {
+			t.Fatalf("AbandonIfIdle() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, session1.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		// session_1 was paused at its last update, so no paused time is added.
+		if got.Status != domain.SessionAbandoned || got.PausedAt != nil || got.PausedTime != session1.PausedTime ||
+			!got.UpdatedAt.Equal(session1.UpdatedAt) {
+			t.Errorf("AbandonIfIdle() = %v, %v, %v, %v, want abandoned as of %v after %v",
+				got.Status, got.PausedAt, got.PausedTime, got.UpdatedAt, session1.UpdatedAt, session1.PausedTime)
+		}
+		if err := repo.AbandonIfIdle(ctx, session1.ID, now.Add(time.Minute)); !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("AbandonIfIdle() twice error = %v, want %v", err, repository.ErrNotFound)
+		}
+	})
+
+	t.Run("ListPage", func(t *testing.T) {
+		// user_3's sessions, oldest first; the last two share a creation time.
+		var ids []domain.SessionID
+		for i, textID := range []domain.TextID{"text_a", "text_b", "text_a", "text_a"} {
+			createdAt := now.Add(time.Duration(min(i, 2)) * time.Hour)
+			id := domain.SessionID(fmt.Sprintf("page_%d", i))
+			session, err := domain.NewSession(id, "user_3", textID, createdAt)
+			if err != nil {
+				t.Fatalf("Failed to create session: %v", err)
+			}
+			if i == 0 {
+				session.Status = domain.SessionCompleted
+			}
+			if err := repo.Create(ctx, session); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			ids = append(ids, id)
+		}
+		completed := true
+		tests := []struct {
+			name  string
+			query repository.SessionQuery
+			want  []domain.SessionID
+		}{
+			{name: "all", query: repository.SessionQuery{UserID: "user_3"}, want: []domain.SessionID{ids[3], ids[2], ids[1], ids[0]}},
+			{name: "limit", query: repository.SessionQuery{UserID: "user_3", Limit: 2}, want: []domain.SessionID{ids[3], ids[2]}},
+			{name: "text", query: repository.SessionQuery{UserID: "user_3", TextID: "text_b"}, want: []domain.SessionID{ids[1]}},
+			{name: "completed", query: repository.SessionQuery{UserID: "user_3", Completed: &completed}, want: []domain.SessionID{ids[0]}},
+			{name: "status", query: repository.SessionQuery{UserID: "user_3", Status: domain.SessionActive, Limit: 1}, want: []domain.SessionID{ids[3]}},
+			{name: "time range", query: repository.SessionQuery{UserID: "user_3", From: now.Add(time.Hour), To: now.Add(2 * time.Hour)}, want: []domain.SessionID{ids[1]}},
+			{
+				name:  "after tie",
+				query: repository.SessionQuery{UserID: "user_3", After: &repository.SessionPosition{CreatedAt: now.Add(2 * time.Hour), ID: ids[3]}, Limit: 2},
+				want:  []domain.SessionID{ids[2], ids[1]},
+			},
+		}
+		for _, tt := range tests {
+			sessions, err := repo.ListPage(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("%s: ListPage() error = %v", tt.name, err)
+			}
+			got := make([]domain.SessionID, len(sessions))
+			for i, session := range sessions {
+				got[i] = session.ID
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("%s: ListPage() = %v, want %v", tt.name, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("ListByUserID empty", func(t *testing.T) {
+		sessions, err := repo.ListByUserID(ctx, "nonexistent")
+		if err != nil {
+			t.Fatalf("ListByUserID() error = %v", err)
+		}
+		if len(sessions) != 0 {
+			t.Errorf("ListByUserID() length = %v, want 0", len(sessions))
+		}
+	})
+}
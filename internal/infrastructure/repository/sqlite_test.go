@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/infrastructure/migrations"
+)
+
+func newTestSQLiteDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := OpenSQLite(context.Background(), filepath.Join(t.TempDir(), "typeten.db"))
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	migrateTestDB(t, db)
+	return db
+}
+
+func migrateTestDB(t *testing.T, db *sql.DB) {
+	t.Helper()
+	migrator, err := migrations.New(db)
+	if err != nil {
+		t.Fatalf("migrations.New() error = %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+}
+
+func TestOpenSQLite_Persistence(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "typeten.db")
+
+	db, err := OpenSQLite(ctx, path)
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	migrateTestDB(t, db)
+	user, err := domain.NewUser("user_1", "test@example.com", "user1", time.Now())
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := NewSQLiteUserRepository(db).Create(ctx, user); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Reopening must keep existing data.
+	db, err = OpenSQLite(ctx, path)
+	if err != nil {
+		t.Fatalf("OpenSQLite() reopen error = %v", err)
+	}
+	defer db.Close()
+
+	got, err := NewSQLiteUserRepository(db).GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByID() after reopen error = %v", err)
+	}
+	if got.Email != user.Email {
+		t.Errorf("GetByID() Email = %v, want %v", got.Email, user.Email)
+	}
+	if !got.CreatedAt.Equal(user.CreatedAt) {
+		t.Errorf("GetByID() CreatedAt = %v, want %v", got.CreatedAt, user.CreatedAt)
+	}
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// SQLiteTextRepository is a SQLite implementation of TextRepository.
+// Fragment lines, text chapters and normalization steps are stored as JSON arrays in a single column.
+type SQLiteTextRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTextRepository creates a new SQLite text repository backed by db.
+func NewSQLiteTextRepository(db *sql.DB) repository.TextRepository {
+	return &SQLiteTextRepository{db: db}
+}
+
+const textInfoColumns = `id, user_id, kind, title, author, total_lines, fragment_size, fragment_count, chapters, normalization, wrap_width, wrap_sentences, created_at`
+
+func (r *SQLiteTextRepository) CreateTextInfo(ctx context.Context, info *domain.TextInfo) error {
+	chapters, err := json.Marshal(append([]domain.TextChapter{}, info.Chapters...))
+	if err != nil {
+		return fmt.Errorf("failed to encode text chapters: %w", err)
+	}
+	normalization, err := json.Marshal(append([]domain.Normalization{}, info.Normalization...))
+	if err != nil {
+		return fmt.Errorf("failed to encode text normalization: %w", err)
+	}
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO texts (`+textInfoColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT DO NOTHING`,
+		string(info.ID), string(info.UserID), string(info.Kind), info.Title, info.Author,
+		info.TotalLines, info.FragmentSize, info.FragmentCount, string(chapters), string(normalization),
+		info.Wrap.Width, info.Wrap.Sentences,
+		toUnixNano(info.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert text: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("text: %w", repository.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (r *SQLiteTextRepository) GetTextInfo(ctx context.Context, id domain.TextID) (*domain.TextInfo, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+textInfoColumns+` FROM texts WHERE id = ?`, string(id))
+	info, err := scanTextInfo(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("text: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read text: %w", err)
+	}
+	return info, nil
+}
+
+func (r *SQLiteTextRepository) ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.TextInfo, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+textInfoColumns+` FROM texts WHERE user_id = ? ORDER BY created_at, rowid`,
+		string(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list texts: %w", err)
+	}
+	defer rows.Close()
+
+	texts := []*domain.TextInfo{}
+	for rows.Next() {
+		info, err := scanTextInfo(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read text: %w", err)
+		}
+		texts = append(texts, info)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list texts: %w", err)
+	}
+	return texts, nil
+}
+
+func (r *SQLiteTextRepository) CreateFragment(ctx context.Context, fragment *domain.TextFragment) error {
+	lines, err := json.Marshal(fragment.Lines())
+	if err != nil {
+		return fmt.Errorf("failed to encode fragment lines: %w", err)
+	}
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO text_fragments (id, text_id, fragment_idx, lines) VALUES (?, ?, ?, ?)
+		 ON CONFLICT DO NOTHING`,
+		string(fragment.ID), string(fragment.TextID), fragment.FragmentIdx, string(lines),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert fragment: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("fragment: %w", repository.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (r *SQLiteTextRepository) GetFragment(ctx context.Context, id domain.TextFragmentID) (*domain.TextFragment, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT id, text_id, fragment_idx, lines FROM text_fragments WHERE id = ?`, string(id))
+	fragment, err := scanFragment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("fragment: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fragment: %w", err)
+	}
+	return fragment, nil
+}
+
+func (r *SQLiteTextRepository) GetFragmentsByTextID(ctx context.Context, textID domain.TextID) ([]*domain.TextFragment, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, text_id, fragment_idx, lines FROM text_fragments WHERE text_id = ? ORDER BY fragment_idx`,
+		string(textID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fragments: %w", err)
+	}
+	defer rows.Close()
+
+	fragments := []*domain.TextFragment{}
+	for rows.Next() {
+		fragment, err := scanFragment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fragment: %w", err)
+		}
+		fragments = append(fragments, fragment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list fragments: %w", err)
+	}
+	return fragments, nil
+}
+
+func scanTextInfo(row rowScanner) (*domain.TextInfo, error) {
+	var (
+		info          domain.TextInfo
+		id            string
+		userID        string
+		kind          string
+		chapters      string
+		normalization string
+		createdAt     int64
+	)
+	if err := row.Scan(&id, &userID, &kind, &info.Title, &info.Author, &info.TotalLines, &info.FragmentSize, &info.FragmentCount, &chapters, &normalization, &info.Wrap.Width, &info.Wrap.Sentences, &createdAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(chapters), &info.Chapters); err != nil {
+		return nil, fmt.Errorf("failed to decode text chapters: %w", err)
+	}
+	if len(info.Chapters) == 0 {
+		info.Chapters = nil
+	}
+	if err := json.Unmarshal([]byte(normalization), &info.Normalization); err != nil {
+		return nil, fmt.Errorf("failed to decode text normalization: %w", err)
+	}
+	info.ID = domain.TextID(id)
+	info.UserID = domain.UserID(userID)
+	info.Kind = domain.TextKind(kind)
+	info.CreatedAt = fromUnixNano(createdAt)
+	return &info, nil
+}
+
+func scanFragment(row rowScanner) (*domain.TextFragment, error) {
+	var (
+		id          string
+		textID      string
+		fragmentIdx int
+		encoded     string
+	)
+	if err := row.Scan(&id, &textID, &fragmentIdx, &encoded); err != nil {
+		return nil, err
+	}
+	var lines []string
+	if err := json.Unmarshal([]byte(encoded), &lines); err != nil {
+		return nil, fmt.Errorf("failed to decode fragment lines: %w", err)
+	}
+	return domain.NewTextFragment(domain.TextFragmentID(id), domain.TextID(textID), fragmentIdx, lines)
+}
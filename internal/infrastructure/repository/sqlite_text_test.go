@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+func TestSQLiteTextRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSQLiteTextRepository(newTestSQLiteDB(t))
+
+	now := time.Now()
+	userID := domain.UserID("user_1")
+	textID := domain.TextID("text_1")
+
+	textInfo, err := domain.NewTextInfo(textID, userID, "Test Text", 10, 5, 2, now)
+	if err != nil {
+		t.Fatalf("Failed to create text info: %v", err)
+	}
+	textInfo.Author = "Лев Толстой"
+	chapters := []domain.TextChapter{{Title: "Часть первая", Line: 0}, {Title: "Часть вторая", Line: 6}}
+	if err := textInfo.SetChapters(chapters); err != nil {
+		t.Fatalf("Failed to set chapters: %v", err)
+	}
+	textInfo.Normalization = []domain.Normalization{domain.NormalizeLineEndings, domain.NormalizeYo}
+	textInfo.Wrap = domain.TextWrap{Width: 80, Sentences: true}
+	textInfo.Kind = domain.TextKindDrill
+
+	frag1, err := domain.NewTextFragment("frag_1", textID, 0, []string{"line1", "line2"})
+	if err != nil {
+		t.Fatalf("Failed to create fragment: %v", err)
+	}
+
+	frag2, err := domain.NewTextFragment("frag_2", textID, 1, []string{"line3", "line4"})
+	if err != nil {
+		t.Fatalf("Failed to create fragment: %v", err)
+	}
+
+	t.Run("CreateTextInfo and GetTextInfo", func(t *testing.T) {
+		if err := repo.CreateTextInfo(ctx, textInfo); err != nil {
+			t.Fatalf("CreateTextInfo() error = %v", err)
+		}
+
+		got, err := repo.GetTextInfo(ctx, textID)
+		if err != nil {
+			t.Fatalf("GetTextInfo() error = %v", err)
+		}
+		if got.ID != textID {
+			t.Errorf("GetTextInfo() ID = %v, want %v", got.ID, textID)
+		}
+		if got.Title != textInfo.Title {
+			t.Errorf("GetTextInfo() Title = %v, want %v", got.Title, textInfo.Title)
+		}
+		if got.Author != textInfo.Author {
+			t.Errorf("GetTextInfo() Author = %v, want %v", got.Author, textInfo.Author)
+		}
+		if !reflect.DeepEqual(got.Chapters, chapters) {
+			t.Errorf("GetTextInfo() Chapters = %v, want %v", got.Chapters, chapters)
+		}
+		if !reflect.DeepEqual(got.Normalization, textInfo.Normalization) {
+			t.Errorf("GetTextInfo() Normalization = %v, want %v", got.Normalization, textInfo.Normalization)
+		}
+		if got.Wrap != textInfo.Wrap {
+			t.Errorf("GetTextInfo() Wrap = %+v, want %+v", got.Wrap, textInfo.Wrap)
+		}
+		if got.Kind != textInfo.Kind {
+			t.Errorf("GetTextInfo() Kind = %v, want %v", got.Kind, textInfo.Kind)
+		}
+	})
+
+	t.Run("GetTextInfo non-existent", func(t *testing.T) {
+		_, err := repo.GetTextInfo(ctx, "nonexistent")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetTextInfo() error = %v, want %v", err, repository.ErrNotFound)
+		}
+	})
+
+	t.Run("CreateTextInfo duplicate", func(t *testing.T) {
+		err := repo.CreateTextInfo(ctx, textInfo)
+		if !errors.Is(err, repository.ErrAlreadyExists) {
+			t.Errorf("CreateTextInfo() error = %v, want %v", err, repository.ErrAlreadyExists)
+		}
+	})
+
+	t.Run("ListByUserID", func(t *testing.T) {
+		texts, err := repo.ListByUserID(ctx, userID)
+		if err != nil {
+			t.Fatalf("ListByUserID() error = %v", err)
+		}
+		if len(texts) == 0 {
+			t.Error("ListByUserID() returned empty list")
+		}
+		if texts[0].ID != textID {
+			t.Errorf("ListByUserID() ID = %v, want %v", texts[0].ID, textID)
+		}
+	})
+
+	t.Run("ListByUserID empty", func(t *testing.T) {
+		texts, err := repo.ListByUserID(ctx, "nonexistent")
+		if err != nil {
+			t.Fatalf("ListByUserID() error = %v", err)
+		}
+		if len(texts) != 0 {
+			t.Errorf("ListByUserID() length = %v, want 0", len(texts))
+		}
+	})
+
+	t.Run("CreateFragment and GetFragment", func(t *testing.T) {
+		if err := repo.CreateFragment(ctx, frag1); err != nil {
+			t.Fatalf("CreateFragment() error = %v", err)
+		}
+
+		got, err := repo.GetFragment(ctx, frag1.ID)
+		if err != nil {
+			t.Fatalf("GetFragment() error = %v", err)
+		}
+		if got.ID != frag1.ID {
+			t.Errorf("GetFragment() ID = %v, want %v", got.ID, frag1.ID)
+		}
+		if lines := got.Lines(); len(lines) != 2 || lines[1] != "line2" {
+			t.Errorf("GetFragment() Lines = %v, want [line1 line2]", lines)
+		}
+	})
+
+	t.Run("GetFragment non-existent", func(t *testing.T) {
+		_, err := repo.GetFragment(ctx, "nonexistent")
+		if !errors.Is(err, repository.ErrNotFound) {
+			t.Errorf("GetFragment() error = %v, want %v", err, repository.ErrNotFound)
+		}
+	})
+
+	t.Run("GetFragmentsByTextID", func(t *testing.T) {
+		if err := repo.CreateFragment(ctx, frag2); err != nil {
+			t.Fatalf("CreateFragment() error = %v", err)
+		}
+
+		frags, err := repo.GetFragmentsByTextID(ctx, textID)
+		if err != nil {
+			t.Fatalf("GetFragmentsByTextID() error = %v", err)
+		}
+		if len(frags) < 2 {
+			t.Errorf("GetFragmentsByTextID() length = %v, want at least 2", len(frags))
+		}
+	})
+
+	t.Run("GetFragmentsByTextID empty", func(t *testing.T) {
+		frags, err := repo.GetFragmentsByTextID(ctx, "nonexistent")
+		if err != nil {
+			t.Fatalf("GetFragmentsByTextID() error = %v", err)
+		}
+		if len(frags) != 0 {
+			t.Errorf("GetFragmentsByTextID() length = %v, want 0", len(frags))
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// SQLiteUserRepository is a SQLite implementation of UserRepository.
+type SQLiteUserRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteUserRepository creates a new SQLite user repository backed by db.
+func NewSQLiteUserRepository(db *sql.DB) repository.UserRepository {
+	return &SQLiteUserRepository{db: db}
+}
+
+const userColumns = `id, email, username, password_hash, created_at`
+
+func (r *SQLiteUserRepository) Create(ctx context.Context, user *domain.User) error {
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO users (`+userColumns+`) VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT DO NOTHING`,
+		string(user.ID), user.Email, user.Username, user.PasswordHash, toUnixNano(user.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("user: %w", repository.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (r *SQLiteUserRepository) GetByID(ctx context.Context, id domain.UserID) (*domain.User, error) {
+	return r.getOne(ctx, `SELECT `+userColumns+` FROM users WHERE id = ?`, string(id))
+}
+
+func (r *SQLiteUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.getOne(ctx, `SELECT `+userColumns+` FROM users WHERE email = ?`, email)
+}
+
+func (r *SQLiteUserRepository) getOne(ctx context.Context, query string, arg any) (*domain.User, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("user: %w", repository.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user: %w", err)
+	}
+	return user, nil
+}
+
+func scanUser(row rowScanner) (*domain.User, error) {
+	var (
+		user      domain.User
+		id        string
+		createdAt int64
+	)
+	if err := row.Scan(&id, &user.Email, &user.Username, &user.PasswordHash, &createdAt); err != nil {
+		return nil, err
+	}
+	user.ID = domain.UserID(id)
+	user.CreatedAt = fromUnixNano(createdAt)
+	return &user, nil
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "testing"
+
+func TestSQLiteUserRepository(t *testing.T) {
+	testUserRepository(t, NewSQLiteUserRepository(newTestSQLiteDB(t)))
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "errors"
+
+// Repository errors. Implementations return these (possibly wrapped) so callers
+// can tell a missing or duplicate record apart from a storage failure with errors.Is.
+var (
+	ErrNotFound      = errors.New("repository: not found")
+	ErrAlreadyExists = errors.New("repository: already exists")
+)
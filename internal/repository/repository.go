@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"time"
 	"typeten/internal/domain"
 )
 
 // UserRepository defines operations for user persistence.
 type UserRepository interface {
+	// Create returns ErrAlreadyExists if the user's ID or email is already taken.
 	Create(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, id domain.UserID) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
@@ -28,5 +30,111 @@ type SessionRepository interface {
 	Create(ctx context.Context, session *domain.Session) error
 	GetByID(ctx context.Context, id domain.SessionID) (*domain.Session, error)
 	Update(ctx context.Context, session *domain.Session) error
+	// UpdateStatus stores the status, pause and update time of session, provided its
+	// stored status is still from, leaving the progress fields as stored. Returns
+	// ErrNotFound if there is no such session.
+	UpdateStatus(ctx context.Context, session *domain.Session, from domain.SessionStatus) error
 	ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.Session, error)
+	// ListPage returns the sessions matching query, newest first.
+	ListPage(ctx context.Context, query SessionQuery) ([]*domain.Session, error)
+	// ListIdle returns the active and paused sessions of all users last updated
+	// before the given time, least recently updated first.
+	ListIdle(ctx context.Context, before time.Time) ([]*domain.Session, error)
+	// AbandonIfIdle abandons a session as of its last update, provided it is still
+	// active or paused and was last updated before the given time. Returns
+	// ErrNotFound if there is no such session.
+	AbandonIfIdle(ctx context.Context, id domain.SessionID, before time.Time) error
+}
+
+// SessionQuery selects a page of one user's sessions for SessionRepository.ListPage.
+// Zero values disable a filter: TextID matches any text, Completed nil matches both
+// completed and other sessions, an empty Status matches every status and zero From/To
+// leave the creation time unbounded. From is inclusive, To exclusive. After, when set,
+// skips the sessions listed up to and including that position. Limit caps the number
+// of sessions returned; zero means no cap.
+type SessionQuery struct {
+	UserID    domain.UserID
+	TextID    domain.TextID
+	Completed *bool
+	Status    domain.SessionStatus
+	From      time.Time
+	To        time.Time
+	After     *SessionPosition
+	Limit     int
+}
+
+// Matches reports whether session passes every filter of q.
+func (q SessionQuery) Matches(session *domain.Session) bool {
+	switch {
+	case session.UserID != q.UserID:
+		return false
+	case q.TextID != "" && session.TextID != q.TextID:
+		return false
+	case q.Completed != nil && session.IsCompleted() != *q.Completed:
+		return false
+	case q.Status != "" && session.Status != q.Status:
+		return false
+	case !q.From.IsZero() && session.CreatedAt.Before(q.From):
+		return false
+	case !q.To.IsZero() && !session.CreatedAt.Before(q.To):
+		return false
+	case q.After != nil && !q.After.Before(PositionOf(session)):
+		return false
+	}
+	return true
+}
+
+// SessionPosition is the place of a session in the newest-first listing order.
+// The session ID breaks ties between sessions created at the same instant.
+type SessionPosition struct {
+	CreatedAt time.Time
+	ID        domain.SessionID
+}
+
+// PositionOf returns the listing position of session.
+func PositionOf(session *domain.Session) SessionPosition {
+	return SessionPosition{CreatedAt: session.CreatedAt, ID: session.ID}
+}
+
+// Before reports whether p is listed before other.
+func (p SessionPosition) Before(other SessionPosition) bool {
+	if !p.CreatedAt.Equal(other.CreatedAt) {
+		return p.CreatedAt.After(other.CreatedAt)
+	}
+	return p.ID > other.ID
+}
+
+// APITokenRepository defines operations for API token persistence.
+type APITokenRepository interface {
+	Create(ctx context.Context, token *domain.APIToken) error
+	GetByID(ctx context.Context, id domain.APITokenID) (*domain.APIToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error)
+	Update(ctx context.Context, token *domain.APIToken) error
+	// MarkUsed sets the last-used time of a token that has not been revoked, leaving
+	// every other field as stored. Returns ErrNotFound if there is no such token.
+	MarkUsed(ctx context.Context, id domain.APITokenID, at time.Time) error
+	ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.APIToken, error)
+}
+
+// KeystrokeRepository defines operations for keystroke event persistence.
+// Events are append-only. Lists are ordered by line index, then by append order.
+type KeystrokeRepository interface {
+	Append(ctx context.Context, events []*domain.KeystrokeEvent) error
+	ListBySessionID(ctx context.Context, sessionID domain.SessionID) ([]*domain.KeystrokeEvent, error)
+	ListByLine(ctx context.Context, sessionID domain.SessionID, lineIdx int) ([]*domain.KeystrokeEvent, error)
+	CountByLine(ctx context.Context, sessionID domain.SessionID, lineIdx int) (int, error)
+	// ListByUserID returns the events of the user's sessions created in [from, to),
+	// session by session in creation order. Zero from/to leave the range unbounded.
+	ListByUserID(ctx context.Context, userID domain.UserID, from, to time.Time) ([]*domain.KeystrokeEvent, error)
+}
+
+// LineResultRepository defines operations for per-line result persistence.
+// A line is recorded at most once per session. Lists are ordered by fragment index,
+// then by line index.
+type LineResultRepository interface {
+	Create(ctx context.Context, result *domain.LineResult) error
+	ListBySessionID(ctx context.Context, sessionID domain.SessionID) ([]*domain.LineResult, error)
+	// ListByUserID returns the results of the user's sessions created in [from, to),
+	// session by session in creation order. Zero from/to leave the range unbounded.
+	ListByUserID(ctx context.Context, userID domain.UserID, from, to time.Time) ([]*domain.LineResult, error)
 }
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// AbandonIdleSessionsUseCase handles abandoning sessions nobody has touched for a while.
+type AbandonIdleSessionsUseCase struct {
+	sessionRepo repository.SessionRepository
+}
+
+// NewAbandonIdleSessionsUseCase creates a new AbandonIdleSessionsUseCase.
+func NewAbandonIdleSessionsUseCase(sessionRepo repository.SessionRepository) *AbandonIdleSessionsUseCase {
+	return &AbandonIdleSessionsUseCase{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// AbandonIdleSessionsInput represents the input for abandoning idle sessions.
+// A session is idle once IdleTimeout has passed since its last update.
+type AbandonIdleSessionsInput struct {
+	IdleTimeout time.Duration
+}
+
+// AbandonIdleSessionsOutput lists the sessions that were abandoned.
+type AbandonIdleSessionsOutput struct {
+	Sessions []*domain.Session
+}
+
+// Execute abandons the active and paused sessions of all users that have been idle
+// longer than input.IdleTimeout. A session is abandoned as of its last update, so the
+// idle period does not count toward its active time.
+// Returns domain.ErrInvalidSessionOp if the timeout is not positive.
+func (uc *AbandonIdleSessionsUseCase) Execute(ctx context.Context, input AbandonIdleSessionsInput) (*AbandonIdleSessionsOutput, error) {
+	if input.IdleTimeout <= 0 {
+		return nil, domain.NewFieldError(domain.ErrInvalidSessionOp, "idle_timeout", "must be positive")
+	}
+
+	before := time.Now().Add(-input.IdleTimeout)
+	sessions, err := uc.sessionRepo.ListIdle(ctx, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list idle sessions: %w", err)
+	}
+
+	// The repository re-checks idleness when abandoning, so a session used since it
+	// was listed is skipped rather than overwritten with the stale copy.
+	abandoned := make([]*domain.Session, 0, len(sessions))
+	for _, session := range sessions {
+		err := uc.sessionRepo.AbandonIfIdle(ctx, session.ID, before)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to abandon session %s: %w", session.ID, err)
+		}
+		if err := session.Abandon(session.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to abandon session %s: %w", session.ID, err)
+		}
+		abandoned = append(abandoned, session)
+	}
+
+	return &AbandonIdleSessionsOutput{Sessions: abandoned}, nil
+}
@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestAbandonIdleSessionsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	sessionRepo := NewMockSessionRepository()
+
+	for _, spec := range []struct {
+		id     domain.SessionID
+		idle   time.Duration
+		status domain.SessionStatus
+	}{
+		{id: "session_active", idle: 3 * time.Hour, status: domain.SessionActive},
+		{id: "session_paused", idle: 2 * time.Hour, status: domain.SessionPaused},
+		{id: "session_recent", idle: 10 * time.Minute, status: domain.SessionActive},
+		{id: "session_done", idle: 3 * time.Hour, status: domain.SessionCompleted},
+	} {
+		created := now.Add(-spec.idle - time.Hour)
+		session, err := domain.NewSession(spec.id, "user_1", "text_1", created)
+		if err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		session.UpdatedAt = now.Add(-spec.idle)
+		switch spec.status {
+		case domain.SessionPaused:
+			err = session.Pause(session.UpdatedAt)
+		case domain.SessionCompleted:
+			err = session.MarkCompleted(session.UpdatedAt)
+		}
+		if err != nil {
+			t.Fatalf("Failed to set status: %v", err)
+		}
+		if err := sessionRepo.Create(ctx, session); err != nil {
+			t.Fatalf("Failed to store session: %v", err)
+		}
+	}
+
+	useCase := NewAbandonIdleSessionsUseCase(sessionRepo)
+
+	output, err := useCase.Execute(ctx, AbandonIdleSessionsInput{IdleTimeout: time.Hour})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var got []domain.SessionID
+	for _, session := range output.Sessions {
+		got = append(got, session.ID)
+	}
+	if len(got) != 2 || got[0] != "session_active" || got[1] != "session_paused" {
+		t.Errorf("Execute() abandoned %v, want [session_active session_paused]", got)
+	}
+
+	for id, want := range map[domain.SessionID]domain.SessionStatus{
+		"session_active": domain.SessionAbandoned,
+		"session_paused": domain.SessionAbandoned,
+		"session_recent": domain.SessionActive,
+		"session_done":   domain.SessionCompleted,
+	} {
+		stored, err := sessionRepo.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if stored.Status != want {
+			t.Errorf("%s Status = %v, want %v", id, stored.Status, want)
+		}
+		// The idle hours are not counted; every session was used for one hour.
+		if want == domain.SessionAbandoned && stored.ActiveTime(now) != time.Hour {
+			t.Errorf("%s ActiveTime() = %v, want 1h", id, stored.ActiveTime(now))
+		}
+	}
+
+	if _, err := useCase.Execute(ctx, AbandonIdleSessionsInput{}); !errors.Is(err, domain.ErrInvalidSessionOp) {
+		t.Errorf("Execute() without timeout error = %v, want %v", err, domain.ErrInvalidSessionOp)
+	}
+}
+
+// touchingSessionRepository records a line on every session it lists as idle, as if
+// the user came back between the sweeper's read and its write.
+type touchingSessionRepository struct {
+	*MockSessionRepository
+}
+
+func (r touchingSessionRepository) ListIdle(ctx context.Context, before time.Time) ([]*domain.Session, error) {
+	sessions, err := r.MockSessionRepository.ListIdle(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		stored := r.sessions[session.ID]
+		stored.CompletedLines++
+		stored.UpdatedAt = time.Now()
+	}
+	return sessions, nil
+}
+
+func TestAbandonIdleSessionsUseCase_SkipsSessionsUsedMeanwhile(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := NewMockSessionRepository()
+	session, err := domain.NewSession("session_1", "user_1", "text_1", time.Now().Add(-3*time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	sessionRepo.Create(ctx, session)
+
+	output, err := NewAbandonIdleSessionsUseCase(touchingSessionRepository{sessionRepo}).Execute(ctx,
+		AbandonIdleSessionsInput{IdleTimeout: time.Hour})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(output.Sessions) != 0 {
+		t.Errorf("Execute() abandoned %v sessions, want none", len(output.Sessions))
+	}
+
+	stored, _ := sessionRepo.GetByID(ctx, session.ID)
+	if stored.Status != domain.SessionActive || stored.CompletedLines != 1 {
+		t.Errorf("stored Status = %v, CompletedLines = %v, want active with the new line", stored.Status, stored.CompletedLines)
+	}
+}
@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// apiTokenPrefix marks token secrets so they are easy to recognise in scripts and secret scanners.
+const apiTokenPrefix = "tt_"
+
+// newAPITokenSecret returns a random token secret.
+func newAPITokenSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return apiTokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashAPITokenSecret returns the stored form of a token secret. Secrets are random
+// and long, so an unsalted SHA-256 is enough and allows lookup by hash.
+func hashAPITokenSecret(secret string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(secret)))
+	return hex.EncodeToString(sum[:])
+}
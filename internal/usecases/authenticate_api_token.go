@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// lastUsedResolution limits how often LastUsedAt is persisted, so a busy script
+// does not turn every read request into a write.
+const lastUsedResolution = time.Minute
+
+// AuthenticateAPITokenUseCase handles resolving a bearer token to its API token.
+type AuthenticateAPITokenUseCase struct {
+	tokenRepo repository.APITokenRepository
+}
+
+// NewAuthenticateAPITokenUseCase creates a new AuthenticateAPITokenUseCase.
+func NewAuthenticateAPITokenUseCase(tokenRepo repository.APITokenRepository) *AuthenticateAPITokenUseCase {
+	return &AuthenticateAPITokenUseCase{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// AuthenticateAPITokenInput represents the input for authenticating a bearer token.
+type AuthenticateAPITokenInput struct {
+	Secret string
+}
+
+// AuthenticateAPITokenOutput represents the result of authenticating a bearer token.
+type AuthenticateAPITokenOutput struct {
+	Token *domain.APIToken
+}
+
+// Execute looks up the token by the hash of its secret and records that it was used.
+// Returns domain.ErrInvalidCredentials for an unknown or revoked token.
+func (uc *AuthenticateAPITokenUseCase) Execute(ctx context.Context, input AuthenticateAPITokenInput) (*AuthenticateAPITokenOutput, error) {
+	token, err := uc.tokenRepo.GetByHash(ctx, hashAPITokenSecret(input.Secret))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api token: %w", err)
+	}
+	if token.IsRevoked() {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	// Only the last-used time is written, so a revoke that lands after the read
+	// above is never undone; MarkUsed refuses the revoked token instead.
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedResolution {
+		err := uc.tokenRepo.MarkUsed(ctx, token.ID, now)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrInvalidCredentials
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to record api token use: %w", err)
+		}
+		token.MarkUsed(now)
+	}
+
+	return &AuthenticateAPITokenOutput{Token: token}, nil
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestAuthenticateAPITokenUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := NewMockUserRepository()
+	user, _ := domain.NewUser("user_1", "test@example.com", "testuser", time.Now())
+	userRepo.Create(ctx, user)
+
+	tokenRepo := NewMockAPITokenRepository()
+	createUseCase := NewCreateAPITokenUseCase(tokenRepo, userRepo)
+	active, err := createUseCase.Execute(ctx, CreateAPITokenInput{UserID: "user_1", Name: "ci", Scope: domain.ScopeRead})
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	revoked, err := createUseCase.Execute(ctx, CreateAPITokenInput{UserID: "user_1", Name: "old", Scope: domain.ScopeRead})
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	NewRevokeAPITokenUseCase(tokenRepo).Execute(ctx, RevokeAPITokenInput{UserID: "user_1", TokenID: revoked.Token.ID})
+
+	useCase := NewAuthenticateAPITokenUseCase(tokenRepo)
+
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr error
+	}{
+		{name: "active token", secret: active.Secret},
+		{name: "revoked token", secret: revoked.Secret, wantErr: domain.ErrInvalidCredentials},
+		{name: "unknown token", secret: "tt_unknown", wantErr: domain.ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := useCase.Execute(ctx, AuthenticateAPITokenInput{Secret: tt.secret})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && (output.Token.UserID != "user_1" || output.Token.LastUsedAt == nil) {
+				t.Errorf("Execute() UserID = %v, LastUsedAt = %v, want user_1 and a last-used time",
+					output.Token.UserID, output.Token.LastUsedAt)
+			}
+		})
+	}
+}
+
+// revokingTokenRepository revokes every token it hands out by hash, as if a revoke
+// landed between the lookup and the use being recorded.
+type revokingTokenRepository struct {
+	*MockAPITokenRepository
+}
+
+func (r revokingTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	token, err := r.MockAPITokenRepository.GetByHash(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	cp := *token
+	token.Revoke(time.Now())
+	return &cp, nil
+}
+
+func TestAuthenticateAPITokenUseCase_RevokedConcurrently(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := NewMockUserRepository()
+	user, _ := domain.NewUser("user_1", "test@example.com", "testuser", time.Now())
+	userRepo.Create(ctx, user)
+
+	tokenRepo := NewMockAPITokenRepository()
+	created, err := NewCreateAPITokenUseCase(tokenRepo, userRepo).Execute(ctx,
+		CreateAPITokenInput{UserID: "user_1", Name: "ci", Scope: domain.ScopeRead})
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	useCase := NewAuthenticateAPITokenUseCase(revokingTokenRepository{tokenRepo})
+	if _, err := useCase.Execute(ctx, AuthenticateAPITokenInput{Secret: created.Secret}); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Errorf("Execute() error = %v, want %v", err, domain.ErrInvalidCredentials)
+	}
+
+	token, _ := tokenRepo.GetByID(ctx, created.Token.ID)
+	if !token.IsRevoked() {
+		t.Errorf("Execute() undid the revoke: RevokedAt = %v", token.RevokedAt)
+	}
+}
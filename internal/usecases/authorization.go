@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// Ownership checks shared by the use cases. Every use case that takes a text or
+// session ID from the caller must load it through one of these helpers, so a
+// guessed ID never exposes or modifies another user's data.
+
+// authorizeText returns domain.ErrForbidden unless userID owns the text.
+func authorizeText(info *domain.TextInfo, userID domain.UserID) error {
+	if info.UserID != userID {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeSession returns domain.ErrForbidden unless userID owns the session.
+func authorizeSession(session *domain.Session, userID domain.UserID) error {
+	if session.UserID != userID {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// getOwnedText loads a text and checks that userID owns it.
+func getOwnedText(ctx context.Context, textRepo repository.TextRepository, id domain.TextID, userID domain.UserID) (*domain.TextInfo, error) {
+	info, err := textRepo.GetTextInfo(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get text: %w", err)
+	}
+	if err := authorizeText(info, userID); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// getOwnedSession loads a session and checks that userID owns it.
+func getOwnedSession(ctx context.Context, sessionRepo repository.SessionRepository, id domain.SessionID, userID domain.UserID) (*domain.Session, error) {
+	session, err := sessionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if err := authorizeSession(session, userID); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
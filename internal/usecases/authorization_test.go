@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestOwnershipChecks(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	textRepo := NewMockTextRepository()
+	info, _ := domain.NewTextInfo("text_1", "user_1", "Test Text", 10, 5, 2, now)
+	textRepo.CreateTextInfo(ctx, info)
+
+	sessionRepo := NewMockSessionRepository()
+	session, _ := domain.NewSession("session_1", "user_1", "text_1", now)
+	sessionRepo.Create(ctx, session)
+
+	tests := []struct {
+		name    string
+		userID  domain.UserID
+		wantErr error
+	}{
+		{name: "owner", userID: "user_1", wantErr: nil},
+		{name: "other user", userID: "user_2", wantErr: domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := getOwnedText(ctx, textRepo, "text_1", tt.userID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("getOwnedText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, err := getOwnedSession(ctx, sessionRepo, "session_1", tt.userID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("getOwnedSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
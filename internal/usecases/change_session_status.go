@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// ChangeSessionStatusUseCase handles pausing, resuming, completing and abandoning a session.
+type ChangeSessionStatusUseCase struct {
+	sessionRepo repository.SessionRepository
+}
+
+// NewChangeSessionStatusUseCase creates a new ChangeSessionStatusUseCase.
+func NewChangeSessionStatusUseCase(sessionRepo repository.SessionRepository) *ChangeSessionStatusUseCase {
+	return &ChangeSessionStatusUseCase{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// ChangeSessionStatusInput represents the input for changing a session's status.
+// Status is the status to move to: active resumes a paused session, paused pauses it,
+// completed ends it early and abandoned gives it up.
+type ChangeSessionStatusInput struct {
+	UserID    domain.UserID
+	SessionID domain.SessionID
+	Status    domain.SessionStatus
+}
+
+// ChangeSessionStatusOutput represents the session after the transition.
+type ChangeSessionStatusOutput struct {
+	Session *domain.Session
+}
+
+// Execute moves the session to input.Status.
+// Returns domain.ErrForbidden if the session belongs to another user,
+// domain.ErrInvalidSession for an unknown status and domain.ErrInvalidSessionOp if
+// the session cannot move to it from its current status, including when its status
+// changed while the request was handled.
+func (uc *ChangeSessionStatusUseCase) Execute(ctx context.Context, input ChangeSessionStatusInput) (*ChangeSessionStatusOutput, error) {
+	status, err := domain.ParseSessionStatus(string(input.Status))
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := getOwnedSession(ctx, uc.sessionRepo, input.SessionID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := session.Status
+	now := time.Now()
+	switch status {
+	case domain.SessionActive:
+		err = session.Resume(now)
+	case domain.SessionPaused:
+		err = session.Pause(now)
+	case domain.SessionCompleted:
+		err = session.MarkCompleted(now)
+	case domain.SessionAbandoned:
+		err = session.Abandon(now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the status fields are written, and only if nothing moved the session on
+	// since it was read, so a line recorded meanwhile is not overwritten.
+	err = uc.sessionRepo.UpdateStatus(ctx, session, from)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, domain.ErrInvalidSessionOp
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return &ChangeSessionStatusOutput{Session: session}, nil
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestChangeSessionStatusUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := NewMockSessionRepository()
+	session, err := domain.NewSession("session_1", "user_1", "text_1", time.Now())
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	useCase := NewChangeSessionStatusUseCase(sessionRepo)
+
+	// The steps share the session, each starting from the status the previous one left.
+	steps := []struct {
+		name       string
+		userID     domain.UserID
+		status     domain.SessionStatus
+		wantErr    error
+		wantStatus domain.SessionStatus
+	}{
+		{name: "resume active", userID: "user_1", status: domain.SessionActive, wantErr: domain.ErrInvalidSessionOp},
+		{name: "pause", userID: "user_1", status: domain.SessionPaused, wantStatus: domain.SessionPaused},
+		{name: "pause paused", userID: "user_1", status: domain.SessionPaused, wantErr: domain.ErrInvalidSessionOp},
+		{name: "other user's session", userID: "user_2", status: domain.SessionActive, wantErr: domain.ErrForbidden},
+		{name: "unknown status", userID: "user_1", status: "sleeping", wantErr: domain.ErrInvalidSession},
+		{name: "resume", userID: "user_1", status: domain.SessionActive, wantStatus: domain.SessionActive},
+		{name: "abandon", userID: "user_1", status: domain.SessionAbandoned, wantStatus: domain.SessionAbandoned},
+		{name: "complete abandoned", userID: "user_1", status: domain.SessionCompleted, wantErr: domain.ErrInvalidSessionOp},
+	}
+
+	for _, step := range steps {
+		output, err := useCase.Execute(ctx, ChangeSessionStatusInput{
+			UserID:    step.userID,
+			SessionID: "session_1",
+			Status:    step.status,
+		})
+		if !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: Execute() error = %v, wantErr %v", step.name, err, step.wantErr)
+		}
+		if step.wantErr != nil {
+			continue
+		}
+		if output.Session.Status != step.wantStatus {
+			t.Errorf("%s: Execute() Status = %v, want %v", step.name, output.Session.Status, step.wantStatus)
+		}
+		stored, err := sessionRepo.GetByID(ctx, "session_1")
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if stored.Status != step.wantStatus {
+			t.Errorf("%s: stored Status = %v, want %v", step.name, stored.Status, step.wantStatus)
+		}
+	}
+}
+
+func TestChangeSessionStatusUseCase_KeepsConcurrentProgress(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := NewMockSessionRepository()
+	session, err := domain.NewSession("session_1", "user_1", "text_1", time.Now())
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := session.SetLayout(4, 2); err != nil {
+		t.Fatalf("SetLayout() error = %v", err)
+	}
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	useCase := NewChangeSessionStatusUseCase(racingSessionRepository{sessionRepo})
+	if _, err := useCase.Execute(ctx, ChangeSessionStatusInput{UserID: "user_1", SessionID: "session_1", Status: domain.SessionPaused}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	stored, err := sessionRepo.GetByID(ctx, "session_1")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if stored.Status != domain.SessionPaused || stored.CompletedLines != 1 {
+		t.Errorf("stored session = %v with %v lines, want paused with 1 line", stored.Status, stored.CompletedLines)
+	}
+}
+
+// racingSessionRepository records a line of every session right after it is read,
+// as a concurrent RecordProgress would.
+type racingSessionRepository struct {
+	*MockSessionRepository
+}
+
+func (r racingSessionRepository) GetByID(ctx context.Context, id domain.SessionID) (*domain.Session, error) {
+	session, err := r.MockSessionRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	progressed := *session
+	progressed.CompletedLines++
+	if err := r.MockSessionRepository.Update(ctx, &progressed); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
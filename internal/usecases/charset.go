@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Encodings recognized by DecodeText.
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingWindows1251 = "windows-1251"
+	EncodingKOI8R       = "koi8-r"
+)
+
+// frequentRussianLetters are the most common lowercase letters of Russian text.
+// Decoding with the wrong single-byte charset turns them into mostly uppercase
+// letters, which is how Windows-1251 and KOI8-R are told apart.
+const frequentRussianLetters = "оеаинтсрвлкмдпу"
+
+// DecodeText converts file contents to a string and reports the detected encoding.
+// Valid UTF-8 (with or without a byte order mark) is returned as is; anything else is
+// decoded as Windows-1251 or KOI8-R, whichever yields more common Russian letters.
+func DecodeText(data []byte) (string, string) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if utf8.Valid(data) {
+		return string(data), EncodingUTF8
+	}
+
+	cp1251, _ := charmap.Windows1251.NewDecoder().Bytes(data)
+	koi8r, _ := charmap.KOI8R.NewDecoder().Bytes(data)
+	if russianScore(string(koi8r)) > russianScore(string(cp1251)) {
+		return string(koi8r), EncodingKOI8R
+	}
+	return string(cp1251), EncodingWindows1251
+}
+
+// russianScore counts the frequent Russian letters in text.
+func russianScore(text string) int {
+	score := 0
+	for _, r := range text {
+		if strings.ContainsRune(frequentRussianLetters, r) {
+			score++
+		}
+	}
+	return score
+}
@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestDecodeText(t *testing.T) {
+	const russian = "Съешь же ещё этих мягких французских булок, да выпей чаю."
+	encode := func(t *testing.T, cm *charmap.Charmap) []byte {
+		t.Helper()
+		data, err := cm.NewEncoder().Bytes([]byte(russian))
+		if err != nil {
+			t.Fatalf("Failed to encode: %v", err)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name         string
+		data         []byte
+		wantText     string
+		wantEncoding string
+	}{
+		{name: "utf-8", data: []byte(russian), wantText: russian, wantEncoding: EncodingUTF8},
+		{name: "utf-8 with bom", data: append([]byte("\xef\xbb\xbf"), "hello"...), wantText: "hello", wantEncoding: EncodingUTF8},
+		{name: "windows-1251", data: encode(t, charmap.Windows1251), wantText: russian, wantEncoding: EncodingWindows1251},
+		{name: "koi8-r", data: encode(t, charmap.KOI8R), wantText: russian, wantEncoding: EncodingKOI8R},
+		{name: "ascii", data: []byte("plain ascii"), wantText: "plain ascii", wantEncoding: EncodingUTF8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, encoding := DecodeText(tt.data)
+			if text != tt.wantText {
+				t.Errorf("DecodeText() text = %q, want %q", text, tt.wantText)
+			}
+			if encoding != tt.wantEncoding {
+				t.Errorf("DecodeText() encoding = %q, want %q", encoding, tt.wantEncoding)
+			}
+		})
+	}
+}
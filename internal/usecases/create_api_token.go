@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// CreateAPITokenUseCase handles issuing a personal API token.
+type CreateAPITokenUseCase struct {
+	tokenRepo repository.APITokenRepository
+	userRepo  repository.UserRepository
+}
+
+// NewCreateAPITokenUseCase creates a new CreateAPITokenUseCase.
+func NewCreateAPITokenUseCase(tokenRepo repository.APITokenRepository, userRepo repository.UserRepository) *CreateAPITokenUseCase {
+	return &CreateAPITokenUseCase{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// CreateAPITokenInput represents the input for creating an API token.
+type CreateAPITokenInput struct {
+	UserID domain.UserID
+	Name   string
+	Scope  domain.TokenScope
+}
+
+// CreateAPITokenOutput represents the result of creating an API token.
+// Secret is the bearer token; it is not stored and cannot be retrieved again.
+type CreateAPITokenOutput struct {
+	Token  *domain.APIToken
+	Secret string
+}
+
+// Execute generates a token secret and stores its hash for the user.
+// Returns domain.ErrInvalidAPIToken for an empty name or unknown scope.
+func (uc *CreateAPITokenUseCase) Execute(ctx context.Context, input CreateAPITokenInput) (*CreateAPITokenOutput, error) {
+	if _, err := uc.userRepo.GetByID(ctx, input.UserID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	secret, err := newAPITokenSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	now := time.Now()
+	tokenID := domain.APITokenID(fmt.Sprintf("token_%d", now.UnixNano()))
+	token, err := domain.NewAPIToken(tokenID, input.UserID, input.Name, hashAPITokenSecret(secret), input.Scope, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.tokenRepo.Create(ctx, token); err != nil {
+		return nil, fmt.Errorf("failed to create api token: %w", err)
+	}
+
+	return &CreateAPITokenOutput{Token: token, Secret: secret}, nil
+}
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestCreateAPITokenUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := NewMockUserRepository()
+	user, _ := domain.NewUser("user_1", "test@example.com", "testuser", time.Now())
+	userRepo.Create(ctx, user)
+
+	tokenRepo := NewMockAPITokenRepository()
+	useCase := NewCreateAPITokenUseCase(tokenRepo, userRepo)
+
+	tests := []struct {
+		name    string
+		input   CreateAPITokenInput
+		wantErr error
+	}{
+		{
+			name:  "read-only token",
+			input: CreateAPITokenInput{UserID: "user_1", Name: "ci", Scope: domain.ScopeRead},
+		},
+		{
+			name:    "unknown scope",
+			input:   CreateAPITokenInput{UserID: "user_1", Name: "ci", Scope: "admin"},
+			wantErr: domain.ErrInvalidAPIToken,
+		},
+		{
+			name:    "empty name",
+			input:   CreateAPITokenInput{UserID: "user_1", Name: " ", Scope: domain.ScopeRead},
+			wantErr: domain.ErrInvalidAPIToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := useCase.Execute(ctx, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !strings.HasPrefix(output.Secret, apiTokenPrefix) {
+				t.Errorf("Execute() Secret = %q, want prefix %q", output.Secret, apiTokenPrefix)
+			}
+			if output.Token.TokenHash == output.Secret || output.Token.TokenHash != hashAPITokenSecret(output.Secret) {
+				t.Error("Execute() should store only the hash of the secret")
+			}
+			if _, err := tokenRepo.GetByID(ctx, output.Token.ID); err != nil {
+				t.Errorf("Execute() token not stored: %v", err)
+			}
+		})
+	}
+
+	t.Run("unknown user", func(t *testing.T) {
+		if _, err := useCase.Execute(ctx, CreateAPITokenInput{UserID: "nobody", Name: "ci", Scope: domain.ScopeRead}); err == nil {
+			t.Error("Execute() expected error for unknown user")
+		}
+	})
+}
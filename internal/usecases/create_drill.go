@@ -0,0 +1,227 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// Drill size limits. A drill starts with lines of the densest words, followed by
+// the densest whole lines of the user's texts.
+const (
+	drillWeakKeys     = 5
+	drillWeakBigrams  = 5
+	drillWords        = 30
+	drillWordsPerLine = 6
+	drillLines        = 5
+)
+
+// drillTitlePrefix starts the title of generated drill texts.
+const drillTitlePrefix = "Drill: "
+
+// CreateDrillUseCase handles building a practice text from the user's weakest keys
+// and letter pairs and starting a session on it.
+type CreateDrillUseCase struct {
+	textRepo      repository.TextRepository
+	sessionRepo   repository.SessionRepository
+	userRepo      repository.UserRepository
+	keyStats      *GetKeyStatsUseCase
+	textProcessor *TextProcessor
+}
+
+// NewCreateDrillUseCase creates a new CreateDrillUseCase.
+func NewCreateDrillUseCase(
+	textRepo repository.TextRepository,
+	sessionRepo repository.SessionRepository,
+	userRepo repository.UserRepository,
+	keyStats *GetKeyStatsUseCase,
+	fragmentSize int,
+) *CreateDrillUseCase {
+	return &CreateDrillUseCase{
+		textRepo:      textRepo,
+		sessionRepo:   sessionRepo,
+		userRepo:      userRepo,
+		keyStats:      keyStats,
+		textProcessor: NewTextProcessor(fragmentSize),
+	}
+}
+
+// CreateDrillInput represents the input for creating a drill.
+type CreateDrillInput struct {
+	UserID domain.UserID
+}
+
+// CreateDrillOutput represents the created drill text, its session and the
+// characters and letter pairs it targets.
+type CreateDrillOutput struct {
+	TextInfo *domain.TextInfo
+	Session  *domain.Session
+	Keys     []string
+	Bigrams  []string
+}
+
+// Execute picks the most missed keys and the slowest letter pairs, collects the words
+// and lines of the user's texts with the most of them per character, stores the result
+// as a new text and starts a session on it.
+// Returns domain.ErrNotEnoughData if there are no weaknesses yet or no text contains them.
+func (uc *CreateDrillUseCase) Execute(ctx context.Context, input CreateDrillInput) (*CreateDrillOutput, error) {
+	if _, err := uc.userRepo.GetByID(ctx, input.UserID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	stats, err := uc.keyStats.Execute(ctx, GetKeyStatsInput{UserID: input.UserID})
+	if err != nil {
+		return nil, err
+	}
+	target := newDrillTarget(stats)
+	if target.empty() {
+		return nil, domain.ErrNotEnoughData
+	}
+
+	lines, err := uc.userLines(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	content := target.compose(lines)
+	if content == "" {
+		return nil, domain.ErrNotEnoughData
+	}
+
+	textInfo, err := storeText(ctx, uc.textRepo, uc.textProcessor, input.UserID, textDraft{Kind: domain.TextKindDrill, Title: target.title(), Content: content})
+	if err != nil {
+		return nil, err
+	}
+	session, err := startSession(ctx, uc.sessionRepo, input.UserID, textInfo, domain.SessionMode{Kind: domain.SessionModeFullText})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateDrillOutput{
+		TextInfo: textInfo,
+		Session:  session,
+		Keys:     target.keys,
+		Bigrams:  target.bigrams,
+	}, nil
+}
+
+// userLines returns every line of the user's texts other than drills, oldest text first.
+func (uc *CreateDrillUseCase) userLines(ctx context.Context, userID domain.UserID) ([]string, error) {
+	texts, err := uc.textRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list texts: %w", err)
+	}
+	sort.SliceStable(texts, func(i, j int) bool { return texts[i].CreatedAt.Before(texts[j].CreatedAt) })
+
+	var lines []string
+	for _, text := range texts {
+		// Drills are not material for new drills, which would otherwise mostly
+		// repeat the previous drill.
+		if text.IsDrill() {
+			continue
+		}
+		fragments, err := uc.textRepo.GetFragmentsByTextID(ctx, text.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get fragments: %w", err)
+		}
+		sort.Slice(fragments, func(i, j int) bool { return fragments[i].FragmentIdx < fragments[j].FragmentIdx })
+		for _, fragment := range fragments {
+			lines = append(lines, fragment.Lines()...)
+		}
+	}
+	return lines, nil
+}
+
+// drillTarget is the set of weak characters and letter pairs a drill practices.
+type drillTarget struct {
+	keys    []string
+	bigrams []string
+	keySet  map[rune]bool
+	pairSet map[string]bool
+}
+
+// newDrillTarget takes the most missed keys other than space and the slowest bigrams.
+func newDrillTarget(stats *GetKeyStatsOutput) *drillTarget {
+	t := &drillTarget{keySet: map[rune]bool{}, pairSet: map[string]bool{}}
+	for _, key := range stats.Keys {
+		if len(t.keys) == drillWeakKeys || key.Errors == 0 {
+			break
+		}
+		if key.Char == " " {
+			continue
+		}
+		t.keys = append(t.keys, key.Char)
+		for _, r := range key.Char {
+			t.keySet[r] = true
+		}
+	}
+	for _, bigram := range stats.Bigrams[:min(len(stats.Bigrams), drillWeakBigrams)] {
+		t.bigrams = append(t.bigrams, bigram.Bigram)
+		t.pairSet[bigram.Bigram] = true
+	}
+	return t
+}
+
+func (t *drillTarget) empty() bool {
+	return len(t.keys) == 0 && len(t.bigrams) == 0
+}
+
+func (t *drillTarget) title() string {
+	return drillTitlePrefix + strings.Join(append(append([]string{}, t.keys...), t.bigrams...), " ")
+}
+
+// density is the number of weak characters and weak letter pairs in s per character.
+func (t *drillTarget) density(s string) float64 {
+	runes := []rune(strings.ToLower(s))
+	if len(runes) == 0 {
+		return 0
+	}
+	hits := 0
+	for i, r := range runes {
+		if t.keySet[r] {
+			hits++
+		}
+		if i > 0 && t.pairSet[string(runes[i-1:i+1])] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(runes))
+}
+
+// compose builds the drill content from the densest distinct words and lines.
+// Ties keep the order of the source text.
+func (t *drillTarget) compose(lines []string) string {
+	var words, whole []string
+	seenWords, seenLines := map[string]bool{}, map[string]bool{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line != "" && !seenLines[line] && t.density(line) > 0 {
+			seenLines[line] = true
+			whole = append(whole, line)
+		}
+		for _, word := range strings.Fields(line) {
+			if !seenWords[word] && t.density(word) > 0 {
+				seenWords[word] = true
+				words = append(words, word)
+			}
+		}
+	}
+	words = t.densest(words, drillWords)
+	whole = t.densest(whole, drillLines)
+
+	var out []string
+	for i := 0; i < len(words); i += drillWordsPerLine {
+		out = append(out, strings.Join(words[i:min(i+drillWordsPerLine, len(words))], " "))
+	}
+	out = append(out, whole...)
+	return strings.Join(out, "\n")
+}
+
+func (t *drillTarget) densest(candidates []string, n int) []string {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return t.density(candidates[i]) > t.density(candidates[j])
+	})
+	return candidates[:min(len(candidates), n)]
+}
@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestCreateDrillUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	userRepo := NewMockUserRepository()
+	for _, id := range []domain.UserID{"user_1", "user_2"} {
+		user, err := domain.NewUser(id, string(id)+"@example.com", string(id), now)
+		if err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		if err := userRepo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to store user: %v", err)
+		}
+	}
+
+	textRepo := NewMockTextRepository()
+	sessionRepo := NewMockSessionRepository()
+	keystrokeRepo := NewMockKeystrokeRepository(sessionRepo)
+	lineRepo := NewMockLineResultRepository(sessionRepo)
+
+	info, err := domain.NewTextInfo("text_1", "user_1", "Cats", 3, 5, 1, now)
+	if err != nil {
+		t.Fatalf("Failed to create text: %v", err)
+	}
+	if err := textRepo.CreateTextInfo(ctx, info); err != nil {
+		t.Fatalf("Failed to store text: %v", err)
+	}
+	storeFragments(t, textRepo, "text_1", [][]string{{"the cat sat", "hello world", "a bad cab"}})
+
+	// Typing "the cot sot" for the first line misses both "a" keys.
+	session, err := domain.NewSession("session_1", "user_1", "text_1", now)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+	line, err := domain.NewLineResult("session_1", 0, 0, "the cot sot", 80, 30, time.Second, 2, now)
+	if err != nil {
+		t.Fatalf("Failed to create line result: %v", err)
+	}
+	if err := lineRepo.Create(ctx, line); err != nil {
+		t.Fatalf("Failed to store line result: %v", err)
+	}
+
+	keyStats := NewGetKeyStatsUseCase(sessionRepo, textRepo, keystrokeRepo, lineRepo)
+	useCase := NewCreateDrillUseCase(textRepo, sessionRepo, userRepo, keyStats, 2)
+
+	t.Run("drill from weak keys", func(t *testing.T) {
+		output, err := useCase.Execute(ctx, CreateDrillInput{UserID: "user_1"})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if output.TextInfo.Title != "Drill: a" {
+			t.Errorf("Execute() Title = %q, want %q", output.TextInfo.Title, "Drill: a")
+		}
+		if !output.TextInfo.IsDrill() {
+			t.Errorf("Execute() Kind = %q, want %q", output.TextInfo.Kind, domain.TextKindDrill)
+		}
+		if output.TextInfo.UserID != "user_1" || output.TextInfo.FragmentSize != 2 || output.TextInfo.TotalLines != 3 {
+			t.Errorf("Execute() TextInfo = %+v, want 3 lines of user_1 in fragments of 2", output.TextInfo)
+		}
+		if output.Session.TextID != output.TextInfo.ID || output.Session.UserID != "user_1" {
+			t.Errorf("Execute() Session = %+v, want a session of user_1 on the drill", output.Session)
+		}
+
+		fragments, err := textRepo.GetFragmentsByTextID(ctx, output.TextInfo.ID)
+		if err != nil {
+			t.Fatalf("GetFragmentsByTextID() error = %v", err)
+		}
+		var lines []string
+		for _, fragment := range fragments {
+			lines = append(lines, fragment.Lines()...)
+		}
+		want := "a cat sat bad cab\na bad cab\nthe cat sat"
+		if got := strings.Join(lines, "\n"); got != want {
+			t.Errorf("Execute() content = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("user text titled like a drill", func(t *testing.T) {
+		notes, err := domain.NewTextInfo("text_2", "user_1", "Drill: my notes", 1, 5, 1, now.Add(time.Second))
+		if err != nil {
+			t.Fatalf("Failed to create text: %v", err)
+		}
+		if err := textRepo.CreateTextInfo(ctx, notes); err != nil {
+			t.Fatalf("Failed to store text: %v", err)
+		}
+		storeFragments(t, textRepo, "text_2", [][]string{{"aaa"}})
+
+		output, err := useCase.Execute(ctx, CreateDrillInput{UserID: "user_1"})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		fragments, err := textRepo.GetFragmentsByTextID(ctx, output.TextInfo.ID)
+		if err != nil {
+			t.Fatalf("GetFragmentsByTextID() error = %v", err)
+		}
+		if lines := fragments[0].Lines(); !strings.Contains(lines[0], "aaa") {
+			t.Errorf("Execute() first line = %q, want the words of the user's text %q", lines[0], notes.Title)
+		}
+	})
+
+	t.Run("no typing yet", func(t *testing.T) {
+		_, err := useCase.Execute(ctx, CreateDrillInput{UserID: "user_2"})
+		if !errors.Is(err, domain.ErrNotEnoughData) {
+			t.Errorf("Execute() error = %v, want %v", err, domain.ErrNotEnoughData)
+		}
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		if _, err := useCase.Execute(ctx, CreateDrillInput{UserID: "nonexistent"}); err == nil {
+			t.Error("Execute() error = nil, want error")
+		}
+	})
+}
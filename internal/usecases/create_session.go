@@ -25,9 +25,13 @@ func NewCreateSessionUseCase(sessionRepo repository.SessionRepository, textRepo
 }
 
 // CreateSessionInput represents the input for creating a session.
+// Mode and ModeLimit choose when the session ends (see domain.NewSessionMode);
+// an empty Mode types the full text.
 type CreateSessionInput struct {
-	UserID domain.UserID
-	TextID domain.TextID
+	UserID    domain.UserID
+	TextID    domain.TextID
+	Mode      domain.SessionModeKind
+	ModeLimit int
 }
 
 // CreateSessionOutput represents the result of creating a session.
@@ -36,32 +40,55 @@ type CreateSessionOutput struct {
 }
 
 // Execute creates a new session after validating user and text exist.
+// Returns domain.ErrForbidden if the text belongs to another user and
+// domain.ErrInvalidSession for an invalid mode.
 func (uc *CreateSessionUseCase) Execute(ctx context.Context, input CreateSessionInput) (*CreateSessionOutput, error) {
+	mode, err := domain.NewSessionMode(input.Mode, input.ModeLimit)
+	if err != nil {
+		return nil, err
+	}
+	
 	// Verify user exists
-	_, err := uc.userRepo.GetByID(ctx, input.UserID)
+	_, err = uc.userRepo.GetByID(ctx, input.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	
-	// Verify text exists
-	_, err = uc.textRepo.GetTextInfo(ctx, input.TextID)
+	// Verify text exists and belongs to the user
+	text, err := getOwnedText(ctx, uc.textRepo, input.TextID, input.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("text not found: %w", err)
+		return nil, err
+	}
+	
+	session, err := startSession(ctx, uc.sessionRepo, input.UserID, text, mode)
+	if err != nil {
+		return nil, err
 	}
 	
+	return &CreateSessionOutput{Session: session}, nil
+}
+
+// startSession creates and stores a session in the given mode at the first line of text.
+func startSession(ctx context.Context, sessionRepo repository.SessionRepository, userID domain.UserID, text *domain.TextInfo, mode domain.SessionMode) (*domain.Session, error) {
 	// Create session
 	sessionID := domain.SessionID(fmt.Sprintf("session_%d", time.Now().UnixNano()))
 	now := time.Now()
 	
-	session, err := domain.NewSession(sessionID, input.UserID, input.TextID, now)
+	session, err := domain.NewSession(sessionID, userID, text.ID, now)
 	if err != nil {
 		return nil, err
 	}
+	if err := session.SetLayout(text.TotalLines, text.FragmentSize); err != nil {
+		return nil, err
+	}
+	if err := session.SetMode(mode); err != nil {
+		return nil, err
+	}
 	
 	// Store session
-	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+	if err := sessionRepo.Create(ctx, session); err != nil {
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 	
-	return &CreateSessionOutput{Session: session}, nil
+	return session, nil
 }
@@ -20,6 +20,14 @@ func TestCreateSessionUseCase_Execute(t *testing.T) {
 		t.Fatalf("Failed to store user: %v", err)
 	}
 
+	other, err := domain.NewUser("user_2", "other@example.com", "other", now)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := userRepo.Create(ctx, other); err != nil {
+		t.Fatalf("Failed to store user: %v", err)
+	}
+
 	textRepo := NewMockTextRepository()
 	textInfo, err := domain.NewTextInfo("text_1", user.ID, "Test Text", 10, 5, 2, now)
 	if err != nil {
@@ -53,6 +61,14 @@ func TestCreateSessionUseCase_Execute(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "other user's text",
+			input: CreateSessionInput{
+				UserID: other.ID,
+				TextID: textInfo.ID,
+			},
+			wantErr: true,
+		},
 		{
 			name: "non-existent text",
 			input: CreateSessionInput{
@@ -61,6 +77,26 @@ func TestCreateSessionUseCase_Execute(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "word limit",
+			input: CreateSessionInput{
+				UserID:    user.ID,
+				TextID:    textInfo.ID,
+				Mode:      domain.SessionModeWords,
+				ModeLimit: 50,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid mode",
+			input: CreateSessionInput{
+				UserID:    user.ID,
+				TextID:    textInfo.ID,
+				Mode:      domain.SessionModeMinutes,
+				ModeLimit: 0,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -87,6 +123,13 @@ func TestCreateSessionUseCase_Execute(t *testing.T) {
 				if output.Session.TextID != tt.input.TextID {
 					t.Errorf("Execute() TextID = %v, want %v", output.Session.TextID, tt.input.TextID)
 				}
+				if output.Session.TotalLines != textInfo.TotalLines || output.Session.FragmentSize != textInfo.FragmentSize {
+					t.Errorf("Execute() layout = %v/%v, want %v/%v", output.Session.TotalLines, output.Session.FragmentSize,
+						textInfo.TotalLines, textInfo.FragmentSize)
+				}
+				if wantMode, _ := domain.NewSessionMode(tt.input.Mode, tt.input.ModeLimit); output.Session.Mode != wantMode {
+					t.Errorf("Execute() Mode = %+v, want %+v", output.Session.Mode, wantMode)
+				}
 				// Verify session was stored
 				stored, err := sessionRepo.GetByID(ctx, output.Session.ID)
 				if err != nil {
@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 	"typeten/internal/domain"
 	"typeten/internal/repository"
@@ -25,10 +26,16 @@ func NewCreateTextUseCase(textRepo repository.TextRepository, userRepo repositor
 }
 
 // CreateTextInput represents the input for creating a text.
+// Normalization names the normalization steps (see domain.ParseNormalization);
+// nil applies the default steps. WrapWidth and WrapSentences choose how long
+// paragraphs are broken into lines (see domain.NewTextWrap); by default they are not.
 type CreateTextInput struct {
-	UserID  domain.UserID
-	Title   string
-	Content string
+	UserID        domain.UserID
+	Title         string
+	Content       string
+	Normalization []string
+	WrapWidth     int
+	WrapSentences bool
 }
 
 // CreateTextOutput represents the result of creating a text.
@@ -38,19 +45,74 @@ type CreateTextOutput struct {
 
 // Execute creates a new text by processing the content and storing it.
 func (uc *CreateTextUseCase) Execute(ctx context.Context, input CreateTextInput) (*CreateTextOutput, error) {
+	normalization, err := parseNormalization(input.Normalization)
+	if err != nil {
+		return nil, err
+	}
+	wrap, err := domain.NewTextWrap(input.WrapWidth, input.WrapSentences)
+	if err != nil {
+		return nil, err
+	}
+
 	// Verify user exists
-	_, err := uc.userRepo.GetByID(ctx, input.UserID)
+	_, err = uc.userRepo.GetByID(ctx, input.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	
+	textInfo, err := storeText(ctx, uc.textRepo, uc.textProcessor, input.UserID, textDraft{
+		Title:         input.Title,
+		Content:       input.Content,
+		Normalization: normalization,
+		Wrap:          wrap,
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return &CreateTextOutput{TextInfo: textInfo}, nil
+}
+
+// parseNormalization validates the names of normalization steps; nil stays nil so
+// that the processor's default steps apply.
+func parseNormalization(names []string) ([]domain.Normalization, error) {
+	if names == nil {
+		return nil, nil
+	}
+	return domain.ParseNormalization(names)
+}
+
+// textDraft is a text to be stored. Author and Chapters are optional; chapter lines
+// refer to the lines of Content split on \n. A nil Normalization applies the
+// processor's default steps.
+type textDraft struct {
+	Kind          domain.TextKind
+	Title         string
+	Author        string
+	Content       string
+	Chapters      []domain.TextChapter
+	Normalization []domain.Normalization
+	Wrap          domain.TextWrap
+}
+
+// storeText normalizes and wraps the draft's content, splits it into fragments and
+// stores them with a new TextInfo. Chapters are moved to the lines they start at after normalization.
+// Returns domain.ErrInvalidTextInfo if content has no non-empty line.
+func storeText(ctx context.Context, textRepo repository.TextRepository, processor *TextProcessor, userID domain.UserID, draft textDraft) (*domain.TextInfo, error) {
+	if draft.Normalization != nil {
+		processor = processor.WithNormalization(draft.Normalization)
+	}
+	processor = processor.WithWrap(draft.Wrap)
+
 	// Process text into fragments
-	totalLines, fragments := uc.textProcessor.ProcessText(input.Content)
+	lines, starts := processor.Lines(draft.Content)
+	totalLines := len(lines)
 	if totalLines == 0 {
-		return nil, domain.ErrInvalidTextInfo
+		return nil, domain.NewFieldError(domain.ErrInvalidTextInfo, "content", "must contain at least one non-empty line")
 	}
+	fragments := processor.Fragment(lines)
 	
-	fragmentSize := uc.textProcessor.FragmentSize
+	fragmentSize := processor.FragmentSize
 	fragmentCount := len(fragments)
 	
 	// Generate IDs
@@ -60,8 +122,8 @@ func (uc *CreateTextUseCase) Execute(ctx context.Context, input CreateTextInput)
 	// Create TextInfo
 	textInfo, err := domain.NewTextInfo(
 		textID,
-		input.UserID,
-		input.Title,
+		userID,
+		draft.Title,
 		totalLines,
 		fragmentSize,
 		fragmentCount,
@@ -70,9 +132,18 @@ func (uc *CreateTextUseCase) Execute(ctx context.Context, input CreateTextInput)
 	if err != nil {
 		return nil, err
 	}
+	if draft.Kind != "" {
+		textInfo.Kind = draft.Kind
+	}
+	textInfo.Author = strings.TrimSpace(draft.Author)
+	textInfo.Normalization = processor.Normalization
+	textInfo.Wrap = processor.Wrap
+	if err := textInfo.SetChapters(remapChapters(draft.Chapters, starts, totalLines)); err != nil {
+		return nil, err
+	}
 	
 	// Store TextInfo
-	if err := uc.textRepo.CreateTextInfo(ctx, textInfo); err != nil {
+	if err := textRepo.CreateTextInfo(ctx, textInfo); err != nil {
 		return nil, fmt.Errorf("failed to create text info: %w", err)
 	}
 	
@@ -84,10 +155,27 @@ func (uc *CreateTextUseCase) Execute(ctx context.Context, input CreateTextInput)
 			return nil, fmt.Errorf("failed to create fragment %d: %w", idx, err)
 		}
 		
-		if err := uc.textRepo.CreateFragment(ctx, fragment); err != nil {
+		if err := textRepo.CreateFragment(ctx, fragment); err != nil {
 			return nil, fmt.Errorf("failed to store fragment %d: %w", idx, err)
 		}
 	}
 	
-	return &CreateTextOutput{TextInfo: textInfo}, nil
+	return textInfo, nil
+}
+
+// remapChapters moves chapters to the processed lines their source lines start at
+// (see TextProcessor.Lines). Chapters left without a line of their own are dropped.
+func remapChapters(chapters []domain.TextChapter, starts []int, totalLines int) []domain.TextChapter {
+	var out []domain.TextChapter
+	for _, c := range chapters {
+		if c.Line < 0 || c.Line >= len(starts) {
+			continue
+		}
+		line := starts[c.Line]
+		if line >= totalLines || (len(out) > 0 && line <= out[len(out)-1].Line) {
+			continue
+		}
+		out = append(out, domain.TextChapter{Title: c.Title, Line: line})
+	}
+	return out
 }
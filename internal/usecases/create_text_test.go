@@ -2,6 +2,8 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 	"typeten/internal/domain"
@@ -99,3 +101,141 @@ func TestCreateTextUseCase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTextUseCase_Normalization(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := NewMockUserRepository()
+	user, err := domain.NewUser("user_1", "test@example.com", "testuser", time.Now())
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := userRepo.Create(ctx, user); err != nil {
+		t.Fatalf("Failed to store user: %v", err)
+	}
+
+	content := "«Ёлки»\r\n\r\nпалки…"
+	tests := []struct {
+		name      string
+		steps     []string
+		wantSteps []domain.Normalization
+		wantLines []string
+		wantErr   error
+	}{
+		{
+			name:      "default",
+			wantSteps: domain.DefaultNormalization(),
+			wantLines: []string{`"Ёлки"`, "палки..."},
+		},
+		{
+			name:      "chosen steps",
+			steps:     []string{"yo", "blank_lines", "line_endings"},
+			wantSteps: []domain.Normalization{domain.NormalizeLineEndings, domain.NormalizeYo, domain.NormalizeBlankLines},
+			wantLines: []string{"«Елки»", "палки…"},
+		},
+		{
+			name:    "unknown step",
+			steps:   []string{"lowercase"},
+			wantErr: domain.ErrInvalidTextInfo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			textRepo := NewMockTextRepository()
+			useCase := NewCreateTextUseCase(textRepo, userRepo, 5)
+
+			output, err := useCase.Execute(ctx, CreateTextInput{UserID: user.ID, Title: "Text", Content: content, Normalization: tt.steps})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !reflect.DeepEqual(output.TextInfo.Normalization, tt.wantSteps) {
+				t.Errorf("Execute() Normalization = %v, want %v", output.TextInfo.Normalization, tt.wantSteps)
+			}
+			frags, err := textRepo.GetFragmentsByTextID(ctx, output.TextInfo.ID)
+			if err != nil || len(frags) != 1 {
+				t.Fatalf("GetFragmentsByTextID() = %v, %v, want one fragment", frags, err)
+			}
+			if lines := frags[0].Lines(); !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("Execute() lines = %q, want %q", lines, tt.wantLines)
+			}
+		})
+	}
+}
+
+func TestRemapChapters(t *testing.T) {
+	// Source lines 1 and 2 were removed, source line 3 became lines 1 and 2.
+	starts := []int{0, 1, 1, 1, 3}
+	chapters := []domain.TextChapter{
+		{Title: "One", Line: 0},
+		{Title: "Removed", Line: 1},
+		{Title: "Same line", Line: 3},
+		{Title: "Four", Line: 4},
+		{Title: "Out of range", Line: 9},
+	}
+	want := []domain.TextChapter{{Title: "One", Line: 0}, {Title: "Removed", Line: 1}, {Title: "Four", Line: 3}}
+	if got := remapChapters(chapters, starts, 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("remapChapters() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateTextUseCase_Wrap(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := NewMockUserRepository()
+	user, err := domain.NewUser("user_1", "test@example.com", "testuser", time.Now())
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := userRepo.Create(ctx, user); err != nil {
+		t.Fatalf("Failed to store user: %v", err)
+	}
+	textRepo := NewMockTextRepository()
+	useCase := NewCreateTextUseCase(textRepo, userRepo, 5)
+
+	output, err := useCase.Execute(ctx, CreateTextInput{
+		UserID:        user.ID,
+		Title:         "Prose",
+		Content:       "First sentence here. Second one is a little longer.",
+		WrapWidth:     20,
+		WrapSentences: true,
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := (domain.TextWrap{Width: 20, Sentences: true}); output.TextInfo.Wrap != want {
+		t.Errorf("Execute() Wrap = %+v, want %+v", output.TextInfo.Wrap, want)
+	}
+	// "First sentence here." fits; the second sentence is wrapped in two.
+	if output.TextInfo.TotalLines != 3 {
+		t.Errorf("Execute() TotalLines = %d, want 3", output.TextInfo.TotalLines)
+	}
+
+	_, err = useCase.Execute(ctx, CreateTextInput{UserID: user.ID, Title: "Prose", Content: "text", WrapWidth: 5})
+	if !errors.Is(err, domain.ErrInvalidTextInfo) {
+		t.Errorf("Execute() with a narrow width error = %v, want %v", err, domain.ErrInvalidTextInfo)
+	}
+}
+
+func TestStoreText_ChaptersFollowWrapping(t *testing.T) {
+	ctx := context.Background()
+	info, err := storeText(ctx, NewMockTextRepository(), NewTextProcessor(10), "user_1", textDraft{
+		Title:    "Book",
+		Content:  "Chapter 1\nOne. Two. Three.\nChapter 2\nFour.",
+		Chapters: []domain.TextChapter{{Title: "Chapter 1", Line: 0}, {Title: "Chapter 2", Line: 2}},
+		Wrap:     domain.TextWrap{Sentences: true},
+	})
+	if err != nil {
+		t.Fatalf("storeText() error = %v", err)
+	}
+	want := []domain.TextChapter{{Title: "Chapter 1", Line: 0}, {Title: "Chapter 2", Line: 4}}
+	if !reflect.DeepEqual(info.Chapters, want) {
+		t.Errorf("storeText() Chapters = %+v, want %+v", info.Chapters, want)
+	}
+}
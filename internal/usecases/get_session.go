@@ -2,7 +2,6 @@ package usecases
 
 import (
 	"context"
-	"fmt"
 	"typeten/internal/domain"
 	"typeten/internal/repository"
 )
@@ -21,6 +20,7 @@ func NewGetSessionUseCase(sessionRepo repository.SessionRepository) *GetSessionU
 
 // GetSessionInput represents the input for getting a session.
 type GetSessionInput struct {
+	UserID    domain.UserID
 	SessionID domain.SessionID
 }
 
@@ -30,10 +30,11 @@ type GetSessionOutput struct {
 }
 
 // Execute retrieves a session by ID.
+// Returns domain.ErrForbidden if the session belongs to another user.
 func (uc *GetSessionUseCase) Execute(ctx context.Context, input GetSessionInput) (*GetSessionOutput, error) {
-	session, err := uc.sessionRepo.GetByID(ctx, input.SessionID)
+	session, err := getOwnedSession(ctx, uc.sessionRepo, input.SessionID, input.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("session not found: %w", err)
+		return nil, err
 	}
 	
 	return &GetSessionOutput{Session: session}, nil
@@ -2,9 +2,11 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 	"typeten/internal/domain"
+	"typeten/internal/repository"
 )
 
 func TestGetSessionUseCase_Execute(t *testing.T) {
@@ -25,32 +27,42 @@ func TestGetSessionUseCase_Execute(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   GetSessionInput
-		wantErr bool
+		wantErr error
 	}{
 		{
 			name: "valid session",
 			input: GetSessionInput{
+				UserID:    "user_1",
 				SessionID: "session_1",
 			},
-			wantErr: false,
+			wantErr: nil,
+		},
+		{
+			name: "other user's session",
+			input: GetSessionInput{
+				UserID:    "user_2",
+				SessionID: "session_1",
+			},
+			wantErr: domain.ErrForbidden,
 		},
 		{
 			name: "non-existent session",
 			input: GetSessionInput{
+				UserID:    "user_1",
 				SessionID: "nonexistent",
 			},
-			wantErr: true,
+			wantErr: repository.ErrNotFound,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			output, err := useCase.Execute(ctx, tt.input)
-			if (err != nil) != tt.wantErr {
+			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr {
+			if tt.wantErr == nil {
 				if output == nil {
 					t.Fatal("Execute() returned nil output")
 				}
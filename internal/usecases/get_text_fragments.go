@@ -22,6 +22,7 @@ func NewGetTextFragmentsUseCase(textRepo repository.TextRepository) *GetTextFrag
 
 // GetTextFragmentsInput represents the input for getting fragments.
 type GetTextFragmentsInput struct {
+	UserID domain.UserID
 	TextID domain.TextID
 }
 
@@ -31,11 +32,12 @@ type GetTextFragmentsOutput struct {
 }
 
 // Execute retrieves all fragments for a text, ordered by FragmentIdx.
+// Returns domain.ErrForbidden if the text belongs to another user.
 func (uc *GetTextFragmentsUseCase) Execute(ctx context.Context, input GetTextFragmentsInput) (*GetTextFragmentsOutput, error) {
-	// Verify text exists
-	_, err := uc.textRepo.GetTextInfo(ctx, input.TextID)
+	// Verify text exists and belongs to the caller
+	_, err := getOwnedText(ctx, uc.textRepo, input.TextID, input.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("text not found: %w", err)
+		return nil, err
 	}
 	
 	fragments, err := uc.textRepo.GetFragmentsByTextID(ctx, input.TextID)
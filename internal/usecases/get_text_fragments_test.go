@@ -47,14 +47,25 @@ func TestGetTextFragmentsUseCase_Execute(t *testing.T) {
 		{
 			name: "valid text",
 			input: GetTextFragmentsInput{
+				UserID: "user_1",
 				TextID: textInfo.ID,
 			},
 			wantErr: false,
 			wantLen: 2,
 		},
+		{
+			name: "other user's text",
+			input: GetTextFragmentsInput{
+				UserID: "user_2",
+				TextID: textInfo.ID,
+			},
+			wantErr: true,
+			wantLen: 0,
+		},
 		{
 			name: "non-existent text",
 			input: GetTextFragmentsInput{
+				UserID: "user_1",
 				TextID: "nonexistent",
 			},
 			wantErr: true,
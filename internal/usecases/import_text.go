@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// ImportTextUseCase handles creating a text from an uploaded file.
+type ImportTextUseCase struct {
+	textRepo      repository.TextRepository
+	userRepo      repository.UserRepository
+	importers     *ImporterRegistry
+	textProcessor *TextProcessor
+}
+
+// NewImportTextUseCase creates a new ImportTextUseCase.
+func NewImportTextUseCase(textRepo repository.TextRepository, userRepo repository.UserRepository, importers *ImporterRegistry, fragmentSize int) *ImportTextUseCase {
+	return &ImportTextUseCase{
+		textRepo:      textRepo,
+		userRepo:      userRepo,
+		importers:     importers,
+		textProcessor: NewTextProcessor(fragmentSize),
+	}
+}
+
+// ImportTextInput represents an uploaded file. The importer is chosen by the
+// extension of Filename. Title overrides the title found in the file; if neither
+// is set, the file name without its extension is used. Column picks the column of
+// a CSV or TSV file. Normalization, WrapWidth and WrapSentences are as in CreateTextInput.
+type ImportTextInput struct {
+	UserID        domain.UserID
+	Title         string
+	Filename      string
+	Data          []byte
+	Column        string
+	Normalization []string
+	WrapWidth     int
+	WrapSentences bool
+}
+
+// ImportTextOutput represents the result of importing a file.
+type ImportTextOutput struct {
+	TextInfo *domain.TextInfo
+	Encoding string
+}
+
+// Execute converts the file to lines and stores them as a new text.
+// Returns domain.ErrInvalidTextInfo if the file is empty, larger than MaxImportBytes,
+// of an unsupported type or has no typeable text, or if Column names no column.
+func (uc *ImportTextUseCase) Execute(ctx context.Context, input ImportTextInput) (*ImportTextOutput, error) {
+	if len(input.Data) == 0 {
+		return nil, domain.NewFieldError(domain.ErrInvalidTextInfo, "file", "must not be empty")
+	}
+	if len(input.Data) > MaxImportBytes {
+		return nil, domain.NewFieldError(domain.ErrInvalidTextInfo, "file", fmt.Sprintf("must be at most %d MB", MaxImportBytes>>20))
+	}
+
+	importer, err := uc.importers.Lookup(input.Filename)
+	if err != nil {
+		return nil, err
+	}
+	normalization, err := parseNormalization(input.Normalization)
+	if err != nil {
+		return nil, err
+	}
+	wrap, err := domain.NewTextWrap(input.WrapWidth, input.WrapSentences)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, input.UserID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	doc, err := importer.Import(input.Data, ImportOptions{Column: strings.TrimSpace(input.Column)})
+	if err != nil {
+		var fieldErr *domain.FieldError
+		if errors.As(err, &fieldErr) {
+			return nil, err
+		}
+		return nil, domain.NewFieldError(domain.ErrInvalidTextInfo, "file", err.Error())
+	}
+	if len(doc.Lines) == 0 {
+		return nil, domain.NewFieldError(domain.ErrInvalidTextInfo, "file", "must contain typeable text")
+	}
+
+	title := firstNonBlank(input.Title, doc.Title, strings.TrimSuffix(filepath.Base(input.Filename), filepath.Ext(input.Filename)))
+	textInfo, err := storeText(ctx, uc.textRepo, uc.textProcessor, input.UserID, textDraft{
+		Title:         title,
+		Author:        doc.Author,
+		Content:       strings.Join(doc.Lines, "\n"),
+		Chapters:      doc.Chapters,
+		Normalization: normalization,
+		Wrap:          wrap,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportTextOutput{TextInfo: textInfo, Encoding: doc.Encoding}, nil
+}
+
+// firstNonBlank returns the first of values that is not blank.
+func firstNonBlank(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Extensions returns the file extensions that can be imported.
+func (uc *ImportTextUseCase) Extensions() []string {
+	return uc.importers.Extensions()
+}
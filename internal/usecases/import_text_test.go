@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestImportTextUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	userRepo := NewMockUserRepository()
+	user, err := domain.NewUser("user_1", "test@example.com", "testuser", now)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := userRepo.Create(ctx, user); err != nil {
+		t.Fatalf("Failed to store user: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		input        ImportTextInput
+		wantErr      error
+		wantTitle    string
+		wantAuthor   string
+		wantLines    int
+		wantChapters int
+	}{
+		{
+			name:      "plain text named after the file",
+			input:     ImportTextInput{UserID: user.ID, Filename: "poem.txt", Data: []byte("one\ntwo\n\nthree")},
+			wantTitle: "poem",
+			wantLines: 3,
+		},
+		{
+			name:      "markdown title",
+			input:     ImportTextInput{UserID: user.ID, Filename: "notes.md", Data: []byte("# Notes\n\nBody")},
+			wantTitle: "Notes",
+			wantLines: 2,
+		},
+		{
+			name:      "explicit title wins",
+			input:     ImportTextInput{UserID: user.ID, Title: "Mine", Filename: "page.html", Data: []byte("<title>Page</title><p>text</p>")},
+			wantTitle: "Mine",
+			wantLines: 1,
+		},
+		{
+			name:         "book with author and chapters",
+			input:        ImportTextInput{UserID: user.ID, Filename: "book.fb2", Data: []byte(fmt.Sprintf(fb2Book, "UTF-8"))},
+			wantTitle:    "Детство",
+			wantAuthor:   "Лев Николаевич Толстой",
+			wantLines:    8,
+			wantChapters: 2,
+		},
+		{
+			name:      "quote list column",
+			input:     ImportTextInput{UserID: user.ID, Filename: "quotes.csv", Column: " text ", Data: []byte("author,text\nA,First quote.\nB,Second quote.\n")},
+			wantTitle: "quotes",
+			wantLines: 2,
+		},
+		{
+			name:    "unknown column",
+			input:   ImportTextInput{UserID: user.ID, Filename: "quotes.csv", Column: "quote", Data: []byte("author,text\nA,First quote.\n")},
+			wantErr: domain.ErrInvalidTextInfo,
+		},
+		{
+			name:    "unsupported type",
+			input:   ImportTextInput{UserID: user.ID, Filename: "book.pdf", Data: []byte("%PDF")},
+			wantErr: domain.ErrInvalidTextInfo,
+		},
+		{
+			name:    "empty file",
+			input:   ImportTextInput{UserID: user.ID, Filename: "a.txt"},
+			wantErr: domain.ErrInvalidTextInfo,
+		},
+		{
+			name:    "too large",
+			input:   ImportTextInput{UserID: user.ID, Filename: "a.txt", Data: []byte(strings.Repeat("a", MaxImportBytes+1))},
+			wantErr: domain.ErrInvalidTextInfo,
+		},
+		{
+			name:    "no typeable text",
+			input:   ImportTextInput{UserID: user.ID, Filename: "a.html", Data: []byte("<script>x()</script>")},
+			wantErr: domain.ErrInvalidTextInfo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			textRepo := NewMockTextRepository()
+			useCase := NewImportTextUseCase(textRepo, userRepo, NewDefaultImporterRegistry(), 5)
+
+			output, err := useCase.Execute(ctx, tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if output.TextInfo.Title != tt.wantTitle {
+				t.Errorf("Execute() title = %q, want %q", output.TextInfo.Title, tt.wantTitle)
+			}
+			if output.TextInfo.Author != tt.wantAuthor {
+				t.Errorf("Execute() author = %q, want %q", output.TextInfo.Author, tt.wantAuthor)
+			}
+			if len(output.TextInfo.Chapters) != tt.wantChapters {
+				t.Errorf("Execute() chapters = %+v, want %d", output.TextInfo.Chapters, tt.wantChapters)
+			}
+			if output.TextInfo.TotalLines != tt.wantLines {
+				t.Errorf("Execute() total lines = %d, want %d", output.TextInfo.TotalLines, tt.wantLines)
+			}
+			if output.Encoding != EncodingUTF8 {
+				t.Errorf("Execute() encoding = %q, want %q", output.Encoding, EncodingUTF8)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"typeten/internal/domain"
+)
+
+// MaxImportBytes bounds the size of an uploaded file.
+const MaxImportBytes = 5 << 20
+
+// ImportedDocument is the typeable content extracted from an uploaded file.
+// Title and Author are empty if the file does not name them. Chapters mark where
+// the chapters of a book start in Lines.
+type ImportedDocument struct {
+	Title    string
+	Author   string
+	Lines    []string
+	Chapters []domain.TextChapter
+	Encoding string // detected source encoding, e.g. "windows-1251"
+}
+
+// ImportOptions are the upload settings an importer may use.
+type ImportOptions struct {
+	// Column selects the column of a CSV or TSV file: a one-based number, or the name
+	// of a column in the header row. Empty means the first column.
+	Column string
+}
+
+// Importer converts the raw bytes of an uploaded file into lines.
+type Importer interface {
+	Import(data []byte, opts ImportOptions) (*ImportedDocument, error)
+}
+
+// ImporterFunc adapts a function that takes no options to the Importer interface.
+type ImporterFunc func(data []byte) (*ImportedDocument, error)
+
+// Import calls f(data).
+func (f ImporterFunc) Import(data []byte, _ ImportOptions) (*ImportedDocument, error) {
+	return f(data)
+}
+
+// ImporterRegistry picks an Importer by file extension.
+type ImporterRegistry struct {
+	importers map[string]Importer
+}
+
+// NewImporterRegistry creates an empty registry.
+func NewImporterRegistry() *ImporterRegistry {
+	return &ImporterRegistry{importers: make(map[string]Importer)}
+}
+
+// NewDefaultImporterRegistry creates a registry with the built-in importers for
+// plain text, Markdown, HTML, EPUB, FB2, SRT and WebVTT subtitles, and CSV and TSV
+// quote lists.
+func NewDefaultImporterRegistry() *ImporterRegistry {
+	r := NewImporterRegistry()
+	r.Register(ImporterFunc(importPlainText), ".txt", ".text")
+	r.Register(ImporterFunc(importMarkdown), ".md", ".markdown")
+	r.Register(ImporterFunc(importHTML), ".html", ".htm", ".xhtml")
+	r.Register(ImporterFunc(importEPUB), ".epub")
+	r.Register(ImporterFunc(importFB2), ".fb2")
+	r.Register(ImporterFunc(importSubtitles), ".srt", ".vtt")
+	r.Register(csvImporter{comma: 0}, ".csv")
+	r.Register(csvImporter{comma: '\t'}, ".tsv", ".tab")
+	return r
+}
+
+// Register makes importer handle files with the given extensions. Extensions are
+// case-insensitive and include the dot; a later registration replaces an earlier one.
+func (r *ImporterRegistry) Register(importer Importer, exts ...string) {
+	for _, ext := range exts {
+		r.importers[strings.ToLower(ext)] = importer
+	}
+}
+
+// Lookup returns the importer for filename's extension.
+// Returns domain.ErrInvalidTextInfo if no importer handles it.
+func (r *ImporterRegistry) Lookup(filename string) (Importer, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if importer, ok := r.importers[ext]; ok {
+		return importer, nil
+	}
+	return nil, domain.NewFieldError(domain.ErrInvalidTextInfo, "file",
+		fmt.Sprintf("unsupported file type %q (supported: %s)", ext, strings.Join(r.Extensions(), ", ")))
+}
+
+// Extensions returns the registered extensions in sorted order.
+func (r *ImporterRegistry) Extensions() []string {
+	exts := make([]string, 0, len(r.importers))
+	for ext := range r.importers {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return exts
+}
+
+// importPlainText keeps every non-blank line of the file.
+func importPlainText(data []byte) (*ImportedDocument, error) {
+	text, encoding := DecodeText(data)
+	var lines []string
+	for _, line := range splitLines(text) {
+		if line = strings.TrimRight(line, " \t"); strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return &ImportedDocument{Lines: lines, Encoding: encoding}, nil
+}
+
+// splitLines splits text on \n, \r\n and lone \r.
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"typeten/internal/domain"
+)
+
+// csvImporter reads one column of a CSV or TSV file, one line per row. A zero comma
+// is detected from the first row: a comma, semicolon or tab.
+type csvImporter struct {
+	comma rune
+}
+
+// Import keeps the non-blank cells of the column chosen by opts.Column. A column
+// chosen by name is looked up in the first row, which is then skipped.
+func (c csvImporter) Import(data []byte, opts ImportOptions) (*ImportedDocument, error) {
+	text, encoding := DecodeText(data)
+	comma := c.comma
+	if comma == 0 {
+		comma = sniffComma(text)
+	}
+
+	r := csv.NewReader(strings.NewReader(text))
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	records, err := r.ReadAll()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("is not a valid table: line %d: %v", parseErr.Line, parseErr.Err)
+		}
+		return nil, fmt.Errorf("is not a valid table: %v", err)
+	}
+
+	column, skipHeader, err := csvColumn(records, opts.Column)
+	if err != nil {
+		return nil, err
+	}
+	doc := &ImportedDocument{Encoding: encoding}
+	for i, record := range records {
+		if (i == 0 && skipHeader) || column >= len(record) {
+			continue
+		}
+		if cell := strings.Join(strings.Fields(record[column]), " "); cell != "" {
+			doc.Lines = append(doc.Lines, cell)
+		}
+	}
+	return doc, nil
+}
+
+// csvColumn resolves the column option to a zero-based index and reports whether
+// the first row is a header naming it.
+func csvColumn(records [][]string, column string) (int, bool, error) {
+	if column == "" {
+		return 0, false, nil
+	}
+	if n, err := strconv.Atoi(column); err == nil {
+		if n < 1 {
+			return 0, false, domain.NewFieldError(domain.ErrInvalidTextInfo, "column", "must be a positive number or a column name")
+		}
+		return n - 1, false, nil
+	}
+	var header []string
+	if len(records) > 0 {
+		header = records[0]
+	}
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			return i, true, nil
+		}
+	}
+	return 0, false, domain.NewFieldError(domain.ErrInvalidTextInfo, "column",
+		fmt.Sprintf("%q is not in the header row (columns: %s)", column, strings.Join(header, ", ")))
+}
+
+// sniffComma picks the most frequent of comma, semicolon and tab outside quotes in
+// the first row, preferring the comma on a tie.
+func sniffComma(text string) rune {
+	counts := make(map[rune]int)
+	inQuotes := false
+scan:
+	for _, r := range text {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case r == '\n':
+			break scan
+		default:
+			counts[r]++
+		}
+	}
+	best := ','
+	for _, sep := range []rune{';', '\t'} {
+		if counts[sep] > counts[best] {
+			best = sep
+		}
+	}
+	return best
+}
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"typeten/internal/domain"
+)
+
+func TestCSVImporter(t *testing.T) {
+	quotes := "quote,author\n\"Simplicity is prerequisite for reliability.\",Dijkstra\n" +
+		"\"Talk is cheap.\nShow me the code.\",Torvalds\n,Nobody\n"
+
+	tests := []struct {
+		name      string
+		importer  csvImporter
+		data      string
+		column    string
+		wantLines []string
+		wantErr   bool
+	}{
+		{
+			name:      "header name",
+			data:      quotes,
+			column:    "Quote",
+			wantLines: []string{"Simplicity is prerequisite for reliability.", "Talk is cheap. Show me the code."},
+		},
+		{
+			name:      "column number keeps the first row",
+			data:      quotes,
+			column:    "2",
+			wantLines: []string{"author", "Dijkstra", "Torvalds", "Nobody"},
+		},
+		{
+			name:      "first column by default",
+			data:      "one,x\ntwo\n",
+			wantLines: []string{"one", "two"},
+		},
+		{
+			name:      "semicolons detected",
+			data:      "a;\"b, c\"\nd;e\n",
+			column:    "2",
+			wantLines: []string{"b, c", "e"},
+		},
+		{
+			name:      "TSV with stray quotes",
+			importer:  csvImporter{comma: '\t'},
+			data:      "id\ttext\n1\tHe said \"hi\" twice\n2\tplain\n",
+			column:    "text",
+			wantLines: []string{`He said "hi" twice`, "plain"},
+		},
+		{name: "unknown column name", data: quotes, column: "source", wantErr: true},
+		{name: "zero column", data: quotes, column: "0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := tt.importer.Import([]byte(tt.data), ImportOptions{Column: tt.column})
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidTextInfo) {
+					t.Fatalf("Import() error = %v, want ErrInvalidTextInfo", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+			if !reflect.DeepEqual(doc.Lines, tt.wantLines) {
+				t.Errorf("Import() lines = %q, want %q", doc.Lines, tt.wantLines)
+			}
+		})
+	}
+}
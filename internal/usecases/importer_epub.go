@@ -0,0 +1,375 @@
+package usecases
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"typeten/internal/domain"
+)
+
+// maxUnpackedBytes bounds the total size of the files read from an uploaded archive.
+const maxUnpackedBytes = 8 * MaxImportBytes
+
+// epubContainer is META-INF/container.xml.
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// epubPackage is the OPF package document.
+type epubPackage struct {
+	Titles   []string `xml:"metadata>title"`
+	Creators []struct {
+		Name string `xml:",chardata"`
+		Role string `xml:"role,attr"`
+	} `xml:"metadata>creator"`
+	Items []struct {
+		ID         string `xml:"id,attr"`
+		Href       string `xml:"href,attr"`
+		MediaType  string `xml:"media-type,attr"`
+		Properties string `xml:"properties,attr"`
+	} `xml:"manifest>item"`
+	Spine struct {
+		Toc      string `xml:"toc,attr"`
+		ItemRefs []struct {
+			IDRef  string `xml:"idref,attr"`
+			Linear string `xml:"linear,attr"`
+		} `xml:"itemref"`
+	} `xml:"spine"`
+}
+
+// epubNavPoint is a table of contents entry of an EPUB 2 NCX file.
+type epubNavPoint struct {
+	Label   string `xml:"navLabel>text"`
+	Content struct {
+		Src string `xml:"src,attr"`
+	} `xml:"content"`
+	Points []epubNavPoint `xml:"navPoint"`
+}
+
+// epubArchive reads files of an EPUB, counting the unpacked bytes.
+type epubArchive struct {
+	files    map[string]*zip.File
+	unpacked int64
+}
+
+// importEPUB reads an EPUB 2 or 3 book. The spine documents become the lines of the
+// text; each one that starts a table of contents entry starts a chapter.
+func importEPUB(data []byte) (*ImportedDocument, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.New("is not a valid EPUB archive")
+	}
+	archive := &epubArchive{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		archive.files[f.Name] = f
+	}
+
+	var container epubContainer
+	if err := archive.decodeXML("META-INF/container.xml", &container); err != nil {
+		return nil, err
+	}
+	if len(container.Rootfiles) == 0 {
+		return nil, errors.New("has no EPUB package document")
+	}
+	opfPath := container.Rootfiles[0].FullPath
+	var pkg epubPackage
+	if err := archive.decodeXML(opfPath, &pkg); err != nil {
+		return nil, err
+	}
+	base := path.Dir(opfPath)
+
+	doc := &ImportedDocument{Encoding: EncodingUTF8}
+	if len(pkg.Titles) > 0 {
+		doc.Title = strings.Join(strings.Fields(pkg.Titles[0]), " ")
+	}
+	var authors []string
+	for _, c := range pkg.Creators {
+		if name := strings.Join(strings.Fields(c.Name), " "); name != "" && (c.Role == "" || c.Role == "aut") {
+			authors = append(authors, name)
+		}
+	}
+	doc.Author = strings.Join(authors, ", ")
+
+	hrefs := make(map[string]string, len(pkg.Items))
+	var navPath, ncxPath string
+	for _, item := range pkg.Items {
+		hrefs[item.ID] = resolveHref(base, item.Href)
+		if strings.Contains(" "+item.Properties+" ", " nav ") {
+			navPath = hrefs[item.ID]
+		}
+		if item.MediaType == "application/x-dtbncx+xml" && (pkg.Spine.Toc == "" || pkg.Spine.Toc == item.ID) {
+			ncxPath = hrefs[item.ID]
+		}
+	}
+	toc, err := archive.tableOfContents(navPath, ncxPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range pkg.Spine.ItemRefs {
+		file := hrefs[ref.IDRef]
+		if file == "" || file == navPath || ref.Linear == "no" {
+			continue
+		}
+		content, err := archive.read(file)
+		if err != nil {
+			return nil, err
+		}
+		lines := xhtmlLines(content)
+		if len(lines) == 0 {
+			continue
+		}
+		title := toc[file]
+		if title == "" && len(toc) == 0 {
+			// Without a table of contents every document is a chapter, named by its heading.
+			title = lines[0]
+		}
+		if title != "" {
+			doc.Chapters = append(doc.Chapters, domain.TextChapter{Title: title, Line: len(doc.Lines)})
+		}
+		doc.Lines = append(doc.Lines, lines...)
+	}
+	return doc, nil
+}
+
+// tableOfContents maps each document of the book to the title of the first table of
+// contents entry pointing into it. The EPUB 3 navigation document is preferred over
+// the EPUB 2 NCX file; a book may have neither.
+func (a *epubArchive) tableOfContents(navPath, ncxPath string) (map[string]string, error) {
+	toc := make(map[string]string)
+	add := func(dir, href, title string) {
+		file := resolveHref(dir, href)
+		if title = strings.Join(strings.Fields(title), " "); title != "" && toc[file] == "" {
+			toc[file] = title
+		}
+	}
+
+	switch {
+	case navPath != "":
+		content, err := a.read(navPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, link := range navLinks(content) {
+			add(path.Dir(navPath), link[0], link[1])
+		}
+	case ncxPath != "":
+		var ncx struct {
+			Points []epubNavPoint `xml:"navMap>navPoint"`
+		}
+		if err := a.decodeXML(ncxPath, &ncx); err != nil {
+			return nil, err
+		}
+		var walk func(points []epubNavPoint)
+		walk = func(points []epubNavPoint) {
+			for _, p := range points {
+				add(path.Dir(ncxPath), p.Content.Src, p.Label)
+				walk(p.Points)
+			}
+		}
+		walk(ncx.Points)
+	}
+	return toc, nil
+}
+
+// navLinks returns the href and text of the links in the toc nav element of an
+// EPUB 3 navigation document, or of all its links if no nav is marked as the toc.
+func navLinks(content []byte) [][2]string {
+	var (
+		all, inToc [][2]string
+		navDepth   int // nesting of nav elements once inside the toc nav
+		href       string
+		text       strings.Builder
+		inLink     bool
+	)
+	dec := xml.NewDecoder(bytes.NewReader(content))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "nav":
+				if navDepth > 0 || xmlAttr(t, "type") == "toc" {
+					navDepth++
+				}
+			case "a":
+				inLink, href = true, xmlAttr(t, "href")
+				text.Reset()
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "nav":
+				if navDepth > 0 {
+					navDepth--
+				}
+			case "a":
+				if inLink && href != "" {
+					link := [2]string{href, text.String()}
+					all = append(all, link)
+					if navDepth > 0 {
+						inToc = append(inToc, link)
+					}
+				}
+				inLink = false
+			}
+		case xml.CharData:
+			if inLink {
+				text.Write(t)
+			}
+		}
+	}
+	if len(inToc) > 0 {
+		return inToc
+	}
+	return all
+}
+
+// xhtmlLines extracts the readable text of an XHTML content document the way
+// importHTML does for HTML: each block element becomes one line, whitespace is
+// collapsed except inside pre, and scripts, styles and the head are dropped.
+// Markup that is not well-formed ends the document where the decoder gives up.
+func xhtmlLines(content []byte) []string {
+	var (
+		lines []string
+		line  strings.Builder
+		skip  []string // open skipped elements, innermost last
+		inPre int
+	)
+	flush := func() {
+		if s := strings.Join(strings.Fields(line.String()), " "); s != "" {
+			lines = append(lines, s)
+		}
+		line.Reset()
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(content))
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			tag := strings.ToLower(t.Name.Local)
+			if tag == "body" {
+				skip = nil
+			}
+			if htmlSkippedTags[tag] {
+				skip = append(skip, tag)
+				continue
+			}
+			if len(skip) > 0 {
+				continue
+			}
+			if htmlBlockTags[tag] {
+				flush()
+			}
+			if tag == "pre" {
+				inPre++
+			}
+			if tag == "td" || tag == "th" {
+				line.WriteByte(' ')
+			}
+		case xml.EndElement:
+			tag := strings.ToLower(t.Name.Local)
+			if n := len(skip); n > 0 {
+				if skip[n-1] == tag {
+					skip = skip[:n-1]
+				}
+				continue
+			}
+			if tag == "pre" && inPre > 0 {
+				inPre--
+			}
+			if htmlBlockTags[tag] {
+				flush()
+			}
+		case xml.CharData:
+			if len(skip) > 0 {
+				continue
+			}
+			if inPre == 0 {
+				line.Write(t)
+				continue
+			}
+			// Inside pre every source line is a line of its own.
+			for i, part := range bytes.Split(t, []byte("\n")) {
+				if i > 0 {
+					flush()
+				}
+				line.Write(part)
+			}
+		}
+	}
+	flush()
+	return lines
+}
+
+// xmlAttr returns the value of the attribute with the given local name.
+func xmlAttr(el xml.StartElement, name string) string {
+	for _, attr := range el.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// resolveHref resolves a relative, possibly escaped href against dir and drops its fragment.
+func resolveHref(dir, href string) string {
+	href, _, _ = strings.Cut(href, "#")
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	return path.Join(dir, href)
+}
+
+// read returns the contents of the named file.
+func (a *epubArchive) read(name string) ([]byte, error) {
+	f, ok := a.files[name]
+	if !ok {
+		return nil, fmt.Errorf("is missing %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("has an unreadable %s", name)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxUnpackedBytes-a.unpacked+1))
+	if err != nil {
+		return nil, fmt.Errorf("has an unreadable %s", name)
+	}
+	a.unpacked += int64(len(data))
+	if a.unpacked > maxUnpackedBytes {
+		return nil, fmt.Errorf("unpacks to more than %d MB", maxUnpackedBytes>>20)
+	}
+	return data, nil
+}
+
+// decodeXML reads the named file and unmarshals it into v.
+func (a *epubArchive) decodeXML(name string, v any) error {
+	data, err := a.read(name)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("has a malformed %s", name)
+	}
+	return nil
+}
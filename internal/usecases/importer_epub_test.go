@@ -0,0 +1,210 @@
+package usecases
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+	"typeten/internal/domain"
+)
+
+// zipFiles builds a zip archive holding files in the given order, name then content.
+func zipFiles(t *testing.T, files ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i := 0; i+1 < len(files); i += 2 {
+		w, err := zw.Create(files[i])
+		if err != nil {
+			t.Fatalf("zip create: %v", err)
+		}
+		if _, err := w.Write([]byte(files[i+1])); err != nil {
+			t.Fatalf("zip write: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+const epubContainerXML = `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`
+
+func TestImportEPUB(t *testing.T) {
+	t.Run("EPUB 3 with navigation document", func(t *testing.T) {
+		opf := `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title> The  Book </dc:title>
+    <dc:creator>Anna Writer</dc:creator>
+    <dc:creator>Ben Coauthor</dc:creator>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="cover" href="cover.xhtml" media-type="application/xhtml+xml"/>
+    <item id="c1" href="text/chapter%201.xhtml" media-type="application/xhtml+xml"/>
+    <item id="c2" href="text/ch2.xhtml" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine>
+    <itemref idref="nav"/>
+    <itemref idref="cover" linear="no"/>
+    <itemref idref="c1"/>
+    <itemref idref="c2"/>
+  </spine>
+</package>`
+		nav := `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops"><body>
+  <nav epub:type="landmarks"><ol><li><a href="cover.xhtml">Cover</a></li></ol></nav>
+  <nav epub:type="toc"><ol>
+    <li><a href="text/chapter%201.xhtml">Chapter One</a></li>
+    <li><a href="text/ch2.xhtml#start">Chapter  Two</a></li>
+  </ol></nav>
+</body></html>`
+		data := zipFiles(t,
+			"mimetype", "application/epub+zip",
+			"META-INF/container.xml", epubContainerXML,
+			"OEBPS/content.opf", opf,
+			"OEBPS/nav.xhtml", nav,
+			"OEBPS/cover.xhtml", `<html><body><p>Cover page</p></body></html>`,
+			"OEBPS/text/chapter 1.xhtml", `<html><body><h1>One</h1><p>First line.</p><p>Second line.</p></body></html>`,
+			"OEBPS/text/ch2.xhtml", `<html><body><h1>Two</h1><p>Third line.</p></body></html>`,
+		)
+
+		doc, err := importEPUB(data)
+		if err != nil {
+			t.Fatalf("importEPUB() error = %v", err)
+		}
+		if doc.Title != "The Book" {
+			t.Errorf("Title = %q, want %q", doc.Title, "The Book")
+		}
+		if doc.Author != "Anna Writer, Ben Coauthor" {
+			t.Errorf("Author = %q, want %q", doc.Author, "Anna Writer, Ben Coauthor")
+		}
+		wantLines := []string{"One", "First line.", "Second line.", "Two", "Third line."}
+		if !reflect.DeepEqual(doc.Lines, wantLines) {
+			t.Errorf("Lines = %q, want %q", doc.Lines, wantLines)
+		}
+		wantChapters := []domain.TextChapter{{Title: "Chapter One", Line: 0}, {Title: "Chapter Two", Line: 3}}
+		if !reflect.DeepEqual(doc.Chapters, wantChapters) {
+			t.Errorf("Chapters = %+v, want %+v", doc.Chapters, wantChapters)
+		}
+	})
+
+	t.Run("EPUB 2 with NCX", func(t *testing.T) {
+		opf := `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
+    <dc:title>Old Book</dc:title>
+    <dc:creator opf:role="aut">Writer</dc:creator>
+    <dc:creator opf:role="ill">Illustrator</dc:creator>
+  </metadata>
+  <manifest>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="a" href="a.html" media-type="application/xhtml+xml"/>
+    <item id="b" href="b.html" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine toc="ncx"><itemref idref="a"/><itemref idref="b"/></spine>
+</package>`
+		ncx := `<?xml version="1.0"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1"><navMap>
+  <navPoint id="p1"><navLabel><text>Part</text></navLabel><content src="a.html"/>
+    <navPoint id="p2"><navLabel><text>Nested</text></navLabel><content src="b.html#x"/></navPoint>
+  </navPoint>
+</navMap></ncx>`
+		data := zipFiles(t,
+			"META-INF/container.xml", epubContainerXML,
+			"OEBPS/content.opf", opf,
+			"OEBPS/toc.ncx", ncx,
+			"OEBPS/a.html", `<p>alpha</p>`,
+			"OEBPS/b.html", `<p>beta</p><p>gamma</p>`,
+		)
+
+		doc, err := importEPUB(data)
+		if err != nil {
+			t.Fatalf("importEPUB() error = %v", err)
+		}
+		if doc.Author != "Writer" {
+			t.Errorf("Author = %q, want %q", doc.Author, "Writer")
+		}
+		wantChapters := []domain.TextChapter{{Title: "Part", Line: 0}, {Title: "Nested", Line: 1}}
+		if !reflect.DeepEqual(doc.Chapters, wantChapters) {
+			t.Errorf("Chapters = %+v, want %+v", doc.Chapters, wantChapters)
+		}
+	})
+
+	t.Run("no table of contents", func(t *testing.T) {
+		opf := `<package><manifest><item id="a" href="a.html"/><item id="b" href="b.html"/></manifest>
+  <spine><itemref idref="a"/><itemref idref="b"/></spine></package>`
+		data := zipFiles(t,
+			"META-INF/container.xml", epubContainerXML,
+			"OEBPS/content.opf", opf,
+			"OEBPS/a.html", `<h1>First</h1><p>x</p>`,
+			"OEBPS/b.html", `<h1>Second</h1><p>y</p>`,
+		)
+
+		doc, err := importEPUB(data)
+		if err != nil {
+			t.Fatalf("importEPUB() error = %v", err)
+		}
+		wantChapters := []domain.TextChapter{{Title: "First", Line: 0}, {Title: "Second", Line: 2}}
+		if !reflect.DeepEqual(doc.Chapters, wantChapters) {
+			t.Errorf("Chapters = %+v, want %+v", doc.Chapters, wantChapters)
+		}
+	})
+
+	t.Run("invalid archives", func(t *testing.T) {
+		for name, data := range map[string][]byte{
+			"not a zip":         []byte("plain text"),
+			"missing container": zipFiles(t, "mimetype", "application/epub+zip"),
+			"missing package":   zipFiles(t, "META-INF/container.xml", epubContainerXML),
+			"missing chapter": zipFiles(t,
+				"META-INF/container.xml", epubContainerXML,
+				"OEBPS/content.opf", `<package><manifest><item id="a" href="a.html"/></manifest><spine><itemref idref="a"/></spine></package>`),
+		} {
+			if _, err := importEPUB(data); err == nil {
+				t.Errorf("%s: importEPUB() error = nil, want error", name)
+			}
+		}
+	})
+}
+
+func TestXHTMLLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		xhtml     string
+		wantLines []string
+	}{
+		{
+			name: "document",
+			xhtml: `<?xml version="1.0" encoding="utf-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml"><head><title>Chapter</title><style>p { color: red }</style></head>
+<body><h1>Heading</h1><p>First
+paragraph with <b>bold</b> &amp; <a href="#">link</a>.</p><!-- comment --><p>Second&nbsp;one&mdash;two</p></body></html>`,
+			wantLines: []string{"Heading", "First paragraph with bold & link.", "Second one—two"},
+		},
+		{
+			name:      "breaks and tables",
+			xhtml:     `<div>one<br/>two</div><table><tr><td>a</td><td>b</td></tr></table>`,
+			wantLines: []string{"one", "two", "a b"},
+		},
+		{
+			name:      "pre keeps lines",
+			xhtml:     "<pre>line one\nline two</pre><p>after</p>",
+			wantLines: []string{"line one", "line two", "after"},
+		},
+		{
+			name:      "HTML void element",
+			xhtml:     `<p>one<br>two</p><p>three</p>`,
+			wantLines: []string{"one", "two", "three"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := xhtmlLines([]byte(tt.xhtml)); !reflect.DeepEqual(got, tt.wantLines) {
+				t.Errorf("xhtmlLines() = %q, want %q", got, tt.wantLines)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"typeten/internal/domain"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// fb2TextElements are the FictionBook elements whose text makes up one line.
+var fb2TextElements = map[string]bool{
+	"p": true, "v": true, "subtitle": true, "text-author": true, "td": true, "th": true,
+}
+
+// fb2SkippedBodies name the bodies holding notes rather than the book itself.
+var fb2SkippedBodies = map[string]bool{"notes": true, "comments": true}
+
+// importFB2 reads a FictionBook 2 book, plain or zipped (.fb2.zip renamed to .fb2).
+// Every paragraph, verse line and subtitle becomes a line; every section with a title
+// starts a chapter. Footnotes and embedded binaries are skipped.
+func importFB2(data []byte) (*ImportedDocument, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		unzipped, err := unzipFB2(data)
+		if err != nil {
+			return nil, err
+		}
+		data = unzipped
+	}
+
+	doc := &ImportedDocument{Encoding: EncodingUTF8}
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		switch strings.ToLower(label) {
+		case "windows-1251", "cp1251":
+			doc.Encoding = EncodingWindows1251
+			return charmap.Windows1251.NewDecoder().Reader(input), nil
+		case "koi8-r":
+			doc.Encoding = EncodingKOI8R
+			return charmap.KOI8R.NewDecoder().Reader(input), nil
+		}
+		return nil, fmt.Errorf("unsupported encoding %q", label)
+	}
+	dec.Entity = xml.HTMLEntity
+
+	var (
+		stack       []string // open elements, innermost last
+		line        strings.Builder
+		inLine      bool
+		skipDepth   int // > 0 inside skipped elements
+		titleLines  []string
+		titleStart  int
+		inTitle     int // nesting depth of section titles
+		authorParts []string
+		authors     []string
+		inTitleInfo bool
+		sawRoot     bool
+	)
+	flushLine := func() {
+		if s := strings.Join(strings.Fields(line.String()), " "); s != "" {
+			if inTitle > 0 {
+				titleLines = append(titleLines, s)
+			}
+			doc.Lines = append(doc.Lines, s)
+		}
+		line.Reset()
+		inLine = false
+	}
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("is not a valid FB2 document: %v", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+			stack = append(stack, name)
+			if !sawRoot {
+				if name != "FictionBook" {
+					return nil, errors.New("is not a FictionBook document")
+				}
+				sawRoot = true
+			}
+			switch {
+			case skipDepth > 0:
+				skipDepth++
+			case name == "binary", name == "body" && fb2SkippedBodies[xmlAttr(t, "name")]:
+				skipDepth = 1
+			case name == "a" && xmlAttr(t, "type") == "note":
+				skipDepth = 1 // footnote reference such as [1]
+			case name == "title-info":
+				inTitleInfo = true
+			case name == "title" && parentIs(stack, "section"):
+				inTitle++
+				titleStart, titleLines = len(doc.Lines), nil
+			case fb2TextElements[name] && !inTitleInfo:
+				inLine = true
+				line.Reset()
+			}
+		case xml.EndElement:
+			name := t.Name.Local
+			underTitleInfo := parentIs(stack, "title-info")
+			stack = stack[:len(stack)-1]
+			switch {
+			case skipDepth > 0:
+				skipDepth--
+			case name == "title-info":
+				inTitleInfo = false
+			case name == "author" && underTitleInfo:
+				if a := strings.Join(authorParts, " "); a != "" {
+					authors = append(authors, a)
+				}
+				authorParts = nil
+			case name == "title" && inTitle > 0:
+				inTitle--
+				if len(titleLines) > 0 {
+					doc.Chapters = append(doc.Chapters, domain.TextChapter{Title: strings.Join(titleLines, ". "), Line: titleStart})
+				}
+			case fb2TextElements[name] && inLine:
+				flushLine()
+			}
+		case xml.CharData:
+			switch {
+			case skipDepth > 0:
+			case inTitleInfo && len(stack) > 0:
+				text := strings.Join(strings.Fields(string(t)), " ")
+				if text == "" {
+					continue
+				}
+				switch field := stack[len(stack)-1]; {
+				case field == "book-title" && doc.Title == "":
+					doc.Title = text
+				case parentIs(stack, "author") && (field == "first-name" || field == "middle-name" || field == "last-name"):
+					authorParts = append(authorParts, text)
+				}
+			case inLine:
+				line.Write(t)
+			}
+		}
+	}
+	if !sawRoot {
+		return nil, errors.New("is not a FictionBook document")
+	}
+	doc.Author = strings.Join(authors, ", ")
+	return doc, nil
+}
+
+// parentIs reports whether the innermost open element below the last one is name.
+// stack includes the element itself as its last entry.
+func parentIs(stack []string, name string) bool {
+	return len(stack) >= 2 && stack[len(stack)-2] == name
+}
+
+// unzipFB2 returns the first .fb2 file of a zip archive.
+func unzipFB2(data []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.New("is not a valid zip archive")
+	}
+	for _, f := range zr.File {
+		if !strings.EqualFold(path.Ext(f.Name), ".fb2") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("has an unreadable %s", f.Name)
+		}
+		defer rc.Close()
+		unpacked, err := io.ReadAll(io.LimitReader(rc, maxUnpackedBytes+1))
+		if err != nil {
+			return nil, fmt.Errorf("has an unreadable %s", f.Name)
+		}
+		if len(unpacked) > maxUnpackedBytes {
+			return nil, fmt.Errorf("unpacks to more than %d MB", maxUnpackedBytes>>20)
+		}
+		return unpacked, nil
+	}
+	return nil, errors.New("has no .fb2 file in the archive")
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"typeten/internal/domain"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const fb2Book = `<?xml version="1.0" encoding="%s"?>
+<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0" xmlns:l="http://www.w3.org/1999/xlink">
+  <description>
+    <title-info>
+      <author><first-name>Лев</first-name><middle-name>Николаевич</middle-name><last-name>Толстой</last-name></author>
+      <book-title>Детство</book-title>
+      <annotation><p>Not part of the text.</p></annotation>
+    </title-info>
+    <document-info><author><nickname>scanner</nickname></author></document-info>
+  </description>
+  <body>
+    <title><p>Детство</p></title>
+    <section>
+      <title><p>Глава I</p><p>Учитель</p></title>
+      <p>Первый абзац<a l:href="#n1" type="note">[1]</a>.</p>
+      <poem><stanza><v>Строка стиха</v></stanza></poem>
+    </section>
+    <section>
+      <title><p>Глава II</p></title>
+      <subtitle>* * *</subtitle>
+      <p>Второй  абзац.</p>
+      <empty-line/>
+    </section>
+  </body>
+  <body name="notes"><section id="n1"><p>Сноска.</p></section></body>
+  <binary id="cover.jpg" content-type="image/jpeg">AAAA</binary>
+</FictionBook>`
+
+func TestImportFB2(t *testing.T) {
+	wantLines := []string{"Детство", "Глава I", "Учитель", "Первый абзац.", "Строка стиха", "Глава II", "* * *", "Второй абзац."}
+	wantChapters := []domain.TextChapter{{Title: "Глава I. Учитель", Line: 1}, {Title: "Глава II", Line: 5}}
+
+	utf8Book := []byte(fmt.Sprintf(fb2Book, "UTF-8"))
+	cp1251Book, err := charmap.Windows1251.NewEncoder().Bytes([]byte(fmt.Sprintf(fb2Book, "windows-1251")))
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		data         []byte
+		wantEncoding string
+	}{
+		{name: "UTF-8", data: utf8Book, wantEncoding: EncodingUTF8},
+		{name: "windows-1251", data: cp1251Book, wantEncoding: EncodingWindows1251},
+		{name: "zipped", data: zipFiles(t, "book.fb2", string(utf8Book)), wantEncoding: EncodingUTF8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := importFB2(tt.data)
+			if err != nil {
+				t.Fatalf("importFB2() error = %v", err)
+			}
+			if doc.Title != "Детство" {
+				t.Errorf("Title = %q, want %q", doc.Title, "Детство")
+			}
+			if doc.Author != "Лев Николаевич Толстой" {
+				t.Errorf("Author = %q, want %q", doc.Author, "Лев Николаевич Толстой")
+			}
+			if doc.Encoding != tt.wantEncoding {
+				t.Errorf("Encoding = %q, want %q", doc.Encoding, tt.wantEncoding)
+			}
+			if !reflect.DeepEqual(doc.Lines, wantLines) {
+				t.Errorf("Lines = %q, want %q", doc.Lines, wantLines)
+			}
+			if !reflect.DeepEqual(doc.Chapters, wantChapters) {
+				t.Errorf("Chapters = %+v, want %+v", doc.Chapters, wantChapters)
+			}
+		})
+	}
+
+	t.Run("invalid documents", func(t *testing.T) {
+		for name, data := range map[string][]byte{
+			"not XML":          []byte("plain <text"),
+			"other XML":        []byte(`<html><body><p>x</p></body></html>`),
+			"zip without fb2":  zipFiles(t, "book.txt", "text"),
+			"unknown encoding": []byte(`<?xml version="1.0" encoding="x-unknown"?><FictionBook/>`),
+		} {
+			if _, err := importFB2(data); err == nil {
+				t.Errorf("%s: importFB2() error = nil, want error", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// htmlBlockTags end the current line of text. Table cells only add a space, so a
+// table row becomes one line.
+var htmlBlockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "body": true,
+	"br": true, "caption": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"figcaption": true, "figure": true, "footer": true, "h1": true, "h2": true,
+	"h3": true, "h4": true, "h5": true, "h6": true, "header": true, "hr": true,
+	"li": true, "main": true, "nav": true, "ol": true, "p": true, "pre": true,
+	"section": true, "table": true, "tr": true, "ul": true,
+}
+
+// htmlSkippedTags hold no readable text.
+var htmlSkippedTags = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true, "template": true,
+	"svg": true, "iframe": true, "object": true, "select": true, "button": true,
+}
+
+// importHTML extracts the readable text of an HTML document: each block element
+// becomes one line, whitespace is collapsed except inside pre, and scripts, styles
+// and the head are dropped. The document title is taken from the title element.
+func importHTML(data []byte) (*ImportedDocument, error) {
+	text, encoding := DecodeText(data)
+	doc := &ImportedDocument{Encoding: encoding}
+
+	var (
+		line    strings.Builder
+		title   strings.Builder
+		skip    []string // open skipped elements, innermost last
+		inTitle bool
+		inPre   int
+	)
+	flush := func() {
+		if s := strings.Join(strings.Fields(line.String()), " "); s != "" {
+			doc.Lines = append(doc.Lines, s)
+		}
+		line.Reset()
+	}
+
+	z := html.NewTokenizer(strings.NewReader(text))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break // io.EOF; the tokenizer does not fail on malformed markup
+		}
+		name, _ := z.TagName()
+		tag := string(name)
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if tag == "title" && tt == html.StartTagToken {
+				inTitle = true
+				continue
+			}
+			if tag == "body" {
+				skip = nil // the end tag of head is optional
+			}
+			if htmlSkippedTags[tag] && tt == html.StartTagToken {
+				skip = append(skip, tag)
+				continue
+			}
+			if len(skip) > 0 {
+				continue
+			}
+			if htmlBlockTags[tag] {
+				flush()
+			}
+			if tag == "pre" && tt == html.StartTagToken {
+				inPre++
+			}
+			if tag == "td" || tag == "th" {
+				line.WriteByte(' ')
+			}
+		case html.EndTagToken:
+			if tag == "title" {
+				inTitle = false
+				continue
+			}
+			if n := len(skip); n > 0 {
+				if skip[n-1] == tag {
+					skip = skip[:n-1]
+				}
+				continue
+			}
+			if tag == "pre" && inPre > 0 {
+				inPre--
+			}
+			if htmlBlockTags[tag] {
+				flush()
+			}
+		case html.TextToken:
+			if inTitle {
+				title.Write(z.Text())
+				continue
+			}
+			if len(skip) > 0 {
+				continue
+			}
+			if inPre == 0 {
+				line.Write(z.Text())
+				continue
+			}
+			// Inside pre every source line is a line of its own.
+			for i, part := range bytes.Split(z.Text(), []byte("\n")) {
+				if i > 0 {
+					flush()
+				}
+				line.Write(part)
+			}
+		}
+	}
+	flush()
+
+	doc.Title = strings.Join(strings.Fields(title.String()), " ")
+	return doc, nil
+}
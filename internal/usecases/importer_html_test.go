@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportHTML(t *testing.T) {
+	tests := []struct {
+		name      string
+		html      string
+		wantTitle string
+		wantLines []string
+	}{
+		{
+			name: "document",
+			html: `<!doctype html><html><head><title> My   Page </title><style>p { color: red }</style>
+				<script>var x = "<p>no</p>";</script></head>
+				<body><h1>Heading</h1><p>First
+				paragraph with <b>bold</b> &amp; <a href="#">link</a>.</p><!-- comment --><p>Second&nbsp;one</p></body></html>`,
+			wantTitle: "My Page",
+			wantLines: []string{"Heading", "First paragraph with bold & link.", "Second one"},
+		},
+		{
+			name:      "breaks and lists",
+			html:      `<div>one<br>two</div><ul><li>three</li><li>four</li></ul>`,
+			wantLines: []string{"one", "two", "three", "four"},
+		},
+		{
+			name:      "table cells",
+			html:      `<table><tr><td>a</td><td>b</td></tr><tr><th>c</th><td>d</td></tr></table>`,
+			wantLines: []string{"a b", "c d"},
+		},
+		{
+			name:      "pre keeps lines",
+			html:      "<pre>line one\nline two</pre><p>after</p>",
+			wantLines: []string{"line one", "line two", "after"},
+		},
+		{
+			name:      "unclosed head",
+			html:      `<html><head><title>T</title><body><p>text</p>`,
+			wantTitle: "T",
+			wantLines: []string{"text"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := importHTML([]byte(tt.html))
+			if err != nil {
+				t.Fatalf("importHTML() error = %v", err)
+			}
+			if doc.Title != tt.wantTitle {
+				t.Errorf("importHTML() title = %q, want %q", doc.Title, tt.wantTitle)
+			}
+			if !reflect.DeepEqual(doc.Lines, tt.wantLines) {
+				t.Errorf("importHTML() lines = %q, want %q", doc.Lines, tt.wantLines)
+			}
+		})
+	}
+}
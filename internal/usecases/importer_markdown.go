@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	mdFence         = regexp.MustCompile("^\\s*(```|~~~)")
+	mdHeading       = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)\s*#*\s*$`)
+	mdSetextUnder   = regexp.MustCompile(`^\s{0,3}(=+|-+)\s*$`)
+	mdRule          = regexp.MustCompile(`^\s{0,3}([-*_])(\s*[-*_]){2,}\s*$`)
+	mdBlockquote    = regexp.MustCompile(`^\s{0,3}(>\s?)+`)
+	mdListItem      = regexp.MustCompile(`^\s*([-*+]|\d{1,9}[.)])\s+(\[[ xX]\]\s+)?`)
+	mdLinkDef       = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*\S+`)
+	mdTableDivider  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdImage         = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLink          = regexp.MustCompile(`\[([^\]]+)\](\([^)]*\)|\[[^\]]*\])`)
+	mdAutolink      = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	mdHTMLTag       = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	mdCode          = regexp.MustCompile("`+([^`]+)`+")
+	mdStrong        = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	mdEmphasisStar  = regexp.MustCompile(`\*(\S(?:.*?\S)?)\*`)
+	mdEmphasisUnder = regexp.MustCompile(`(^|[^\p{L}\p{N}_])_(\S(?:.*?\S)?)_([^\p{L}\p{N}_]|$)`)
+	mdStrike        = regexp.MustCompile(`~~(.+?)~~`)
+	mdEscape        = regexp.MustCompile(`\\([\\` + "`" + `*_{}\[\]()#+\-.!>~|])`)
+)
+
+// importMarkdown strips Markdown markup. Each paragraph, heading, list item and
+// table row becomes one line; code blocks keep their lines.
+func importMarkdown(data []byte) (*ImportedDocument, error) {
+	text, encoding := DecodeText(data)
+	doc := &ImportedDocument{Encoding: encoding}
+
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			doc.Lines = append(doc.Lines, strings.Join(paragraph, " "))
+			paragraph = paragraph[:0]
+		}
+	}
+	inFence := false
+	for _, line := range splitLines(text) {
+		if mdFence.MatchString(line) {
+			flush()
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			if line = strings.TrimRight(line, " \t"); strings.TrimSpace(line) != "" {
+				doc.Lines = append(doc.Lines, line)
+			}
+			continue
+		}
+
+		switch {
+		case strings.TrimSpace(line) == "", mdLinkDef.MatchString(line):
+			flush()
+		case mdSetextUnder.MatchString(line) && len(paragraph) > 0:
+			// The paragraph so far was a heading.
+			if doc.Title == "" && strings.Contains(line, "=") {
+				doc.Title = strings.Join(paragraph, " ")
+			}
+			flush()
+		case mdRule.MatchString(line):
+			flush()
+		case mdHeading.MatchString(line):
+			flush()
+			heading := stripInlineMarkdown(mdHeading.FindStringSubmatch(line)[1])
+			if doc.Title == "" && strings.HasPrefix(strings.TrimSpace(line), "# ") {
+				doc.Title = heading
+			}
+			if heading != "" {
+				doc.Lines = append(doc.Lines, heading)
+			}
+		case mdTableDivider.MatchString(line) && strings.Contains(line, "|"):
+			// Alignment row of a table.
+		case strings.HasPrefix(strings.TrimSpace(line), "|"):
+			flush()
+			cells := strings.Split(strings.Trim(strings.TrimSpace(line), "|"), "|")
+			for i, cell := range cells {
+				cells[i] = strings.TrimSpace(stripInlineMarkdown(cell))
+			}
+			if row := strings.Join(strings.Fields(strings.Join(cells, " ")), " "); row != "" {
+				doc.Lines = append(doc.Lines, row)
+			}
+		case mdListItem.MatchString(line):
+			flush()
+			paragraph = append(paragraph, stripInlineMarkdown(mdListItem.ReplaceAllString(line, "")))
+		default:
+			line = mdBlockquote.ReplaceAllString(line, "")
+			if strings.TrimSpace(line) == "" {
+				flush()
+				continue
+			}
+			paragraph = append(paragraph, stripInlineMarkdown(line))
+		}
+	}
+	flush()
+	return doc, nil
+}
+
+// stripInlineMarkdown removes links, emphasis, code spans and inline HTML, keeping
+// the visible text.
+func stripInlineMarkdown(s string) string {
+	s = mdImage.ReplaceAllString(s, "$1")
+	s = mdLink.ReplaceAllString(s, "$1")
+	s = mdAutolink.ReplaceAllString(s, "$1")
+	s = mdHTMLTag.ReplaceAllString(s, "")
+	s = mdCode.ReplaceAllString(s, "$1")
+	s = mdStrong.ReplaceAllString(s, "$2")
+	s = mdEmphasisStar.ReplaceAllString(s, "$1")
+	s = mdEmphasisUnder.ReplaceAllString(s, "$1$2$3")
+	s = mdStrike.ReplaceAllString(s, "$1")
+	s = mdEscape.ReplaceAllString(s, "$1")
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportMarkdown(t *testing.T) {
+	tests := []struct {
+		name      string
+		markdown  string
+		wantTitle string
+		wantLines []string
+	}{
+		{
+			name:      "headings and paragraphs",
+			markdown:  "# The Title\n\nFirst paragraph\ncontinues here.\n\n## Section ##\nSecond one.",
+			wantTitle: "The Title",
+			wantLines: []string{"The Title", "First paragraph continues here.", "Section", "Second one."},
+		},
+		{
+			name:      "setext heading",
+			markdown:  "Title\n=====\n\nBody text.\n\nSub\n---\n",
+			wantTitle: "Title",
+			wantLines: []string{"Title", "Body text.", "Sub"},
+		},
+		{
+			name:      "inline markup",
+			markdown:  "Some **bold**, *italic*, _under_ and ~~gone~~ words with `code` and snake_case_name.",
+			wantLines: []string{"Some bold, italic, under and gone words with code and snake_case_name."},
+		},
+		{
+			name:      "links and images",
+			markdown:  "See [the docs](https://example.com \"t\"), ![a cat](cat.png) and <https://go.dev>.\n\n[ref]: https://example.com",
+			wantLines: []string{"See the docs, a cat and https://go.dev."},
+		},
+		{
+			name:      "lists and quotes",
+			markdown:  "- one\n- [x] two\n1. three\n\n> quoted\n> text\n\n---\n",
+			wantLines: []string{"one", "two", "three", "quoted text"},
+		},
+		{
+			name:      "code block",
+			markdown:  "Before\n\n```go\nfunc main() {\n\n}\n```\nAfter",
+			wantLines: []string{"Before", "func main() {", "}", "After"},
+		},
+		{
+			name:      "table",
+			markdown:  "| Name | Age |\n|------|----:|\n| Ann | 30 |",
+			wantLines: []string{"Name Age", "Ann 30"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := importMarkdown([]byte(tt.markdown))
+			if err != nil {
+				t.Fatalf("importMarkdown() error = %v", err)
+			}
+			if doc.Title != tt.wantTitle {
+				t.Errorf("importMarkdown() title = %q, want %q", doc.Title, tt.wantTitle)
+			}
+			if !reflect.DeepEqual(doc.Lines, tt.wantLines) {
+				t.Errorf("importMarkdown() lines = %q, want %q", doc.Lines, tt.wantLines)
+			}
+		})
+	}
+}
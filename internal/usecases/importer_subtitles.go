@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	subtitleTag        = regexp.MustCompile(`<[^>]*>`)     // <i>, <font ...>, <v Speaker>, <00:00:01.000>
+	subtitleOverride   = regexp.MustCompile(`\{\\[^}]*\}`) // {\an8} and other SSA overrides in SRT
+	subtitleSoundEvent = regexp.MustCompile(`^(\[[^\]]*\]|\([^)]*\)|[♪♫\s]+)$`)
+	subtitleDialogue   = regexp.MustCompile(`^[-‐–—]\s*`)
+)
+
+// importSubtitles reads SRT and WebVTT subtitles. Cue numbers, timings, styling tags,
+// headers, notes and sound descriptions such as [music] are dropped. Cues are joined
+// until a sentence ends, so a sentence split across cues becomes one line, and every
+// dialogue turn marked with a leading dash starts a line of its own.
+func importSubtitles(data []byte) (*ImportedDocument, error) {
+	text, encoding := DecodeText(data)
+	doc := &ImportedDocument{Encoding: encoding}
+
+	var (
+		line     strings.Builder
+		previous string // last cue line, to skip the repeats of rolling captions
+	)
+	flush := func() {
+		if line.Len() > 0 {
+			doc.Lines = append(doc.Lines, line.String())
+			line.Reset()
+		}
+	}
+
+	for _, block := range subtitleBlocks(splitLines(text)) {
+		for _, raw := range block {
+			s := subtitleTag.ReplaceAllString(raw, "")
+			s = subtitleOverride.ReplaceAllString(s, "")
+			s = strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+			if s == "" || s == previous || subtitleSoundEvent.MatchString(s) {
+				continue
+			}
+			previous = s
+			if subtitleDialogue.MatchString(s) {
+				flush()
+				s = subtitleDialogue.ReplaceAllString(s, "")
+			}
+			if line.Len() > 0 {
+				line.WriteByte(' ')
+			}
+			line.WriteString(s)
+			if endsSentence(s) {
+				flush()
+			}
+		}
+	}
+	flush()
+	return doc, nil
+}
+
+// subtitleBlocks returns the text lines of every cue: the lines after a timing line
+// ("00:00:01,000 --> ...") up to the next blank line. Blocks without a timing line,
+// such as the WEBVTT header, NOTE, STYLE and REGION, are skipped.
+func subtitleBlocks(lines []string) [][]string {
+	var (
+		blocks [][]string
+		cue    []string
+		inCue  bool
+	)
+	for _, l := range lines {
+		switch {
+		case strings.TrimSpace(l) == "":
+			if inCue {
+				blocks = append(blocks, cue)
+			}
+			cue, inCue = nil, false
+		case strings.Contains(l, "-->"):
+			cue, inCue = nil, true
+		case inCue:
+			cue = append(cue, l)
+		}
+	}
+	if inCue {
+		blocks = append(blocks, cue)
+	}
+	return blocks
+}
+
+// endsSentence reports whether s ends with sentence-final punctuation, possibly
+// followed by closing quotes or brackets.
+func endsSentence(s string) bool {
+	s = strings.TrimRight(s, `"'»”’)]`)
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return strings.ContainsRune(".!?…", r)
+}
@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportSubtitles(t *testing.T) {
+	tests := []struct {
+		name      string
+		subtitles string
+		wantLines []string
+	}{
+		{
+			name: "SRT",
+			subtitles: "\ufeff1\r\n00:00:01,000 --> 00:00:03,500\r\n<i>I never thought</i>\r\nwe would get\r\n\r\n" +
+				"2\r\n00:00:03,600 --> 00:00:05,000\r\n{\\an8}this far.\r\n\r\n" +
+				"3\r\n00:00:06,000 --> 00:00:08,000\r\n[DOOR CLOSES]\r\n\r\n" +
+				"4\r\n00:00:09,000 --> 00:00:11,000\r\n- Are you sure?\r\n- Yes &amp; no\r\n",
+			wantLines: []string{"I never thought we would get this far.", "Are you sure?", "Yes & no"},
+		},
+		{
+			name: "WebVTT",
+			subtitles: "WEBVTT - Example\n\nNOTE This is a comment\nspanning lines\n\n" +
+				"STYLE\n::cue { color: yellow }\n\n" +
+				"intro\n00:01.000 --> 00:04.000 align:start position:10%\n<v Roger Bingham>We are in New York City\n\n" +
+				"00:04.000 --> 00:06.000\n<c.loud>and it's <00:05.000>cold.</c>\n\n" +
+				"00:06.000 --> 00:07.000\n♪ ♪\n",
+			wantLines: []string{"We are in New York City and it's cold."},
+		},
+		{
+			name:      "rolling captions repeat lines",
+			subtitles: "WEBVTT\n\n00:01.000 --> 00:02.000\nhello there\n\n00:02.000 --> 00:03.000\nhello there\nhow are you?\n",
+			wantLines: []string{"hello there how are you?"},
+		},
+		{
+			name:      "no cues",
+			subtitles: "WEBVTT\n\nNOTE nothing here\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := importSubtitles([]byte(tt.subtitles))
+			if err != nil {
+				t.Fatalf("importSubtitles() error = %v", err)
+			}
+			if !reflect.DeepEqual(doc.Lines, tt.wantLines) {
+				t.Errorf("importSubtitles() lines = %q, want %q", doc.Lines, tt.wantLines)
+			}
+		})
+	}
+}
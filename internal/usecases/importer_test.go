@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"typeten/internal/domain"
+)
+
+func TestImporterRegistry_Lookup(t *testing.T) {
+	registry := NewDefaultImporterRegistry()
+
+	for _, name := range []string{"a.txt", "notes.MD", "page.html", "page.htm", "README.markdown"} {
+		if _, err := registry.Lookup(name); err != nil {
+			t.Errorf("Lookup(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"book.pdf", "noext", ""} {
+		if _, err := registry.Lookup(name); !errors.Is(err, domain.ErrInvalidTextInfo) {
+			t.Errorf("Lookup(%q) error = %v, want ErrInvalidTextInfo", name, err)
+		}
+	}
+
+	custom := ImporterFunc(func(data []byte) (*ImportedDocument, error) {
+		return &ImportedDocument{Lines: []string{"custom"}}, nil
+	})
+	registry.Register(custom, ".TXT")
+	importer, err := registry.Lookup("a.txt")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if doc, _ := importer.Import(nil, ImportOptions{}); !reflect.DeepEqual(doc.Lines, []string{"custom"}) {
+		t.Errorf("Lookup() after Register returned %v, want the custom importer", doc.Lines)
+	}
+}
+
+func TestImportPlainText(t *testing.T) {
+	doc, err := importPlainText([]byte("first line  \r\n\r\n  indented\rlast\n"))
+	if err != nil {
+		t.Fatalf("importPlainText() error = %v", err)
+	}
+	want := []string{"first line", "  indented", "last"}
+	if !reflect.DeepEqual(doc.Lines, want) {
+		t.Errorf("importPlainText() lines = %q, want %q", doc.Lines, want)
+	}
+	if doc.Encoding != EncodingUTF8 {
+		t.Errorf("importPlainText() encoding = %q, want %q", doc.Encoding, EncodingUTF8)
+	}
+}
@@ -0,0 +1,317 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxBigramLatency drops pauses between two key presses from bigram latency;
+// a longer gap means the user stopped typing rather than reached for the next key.
+const maxBigramLatency = 2 * time.Second
+
+// GetKeyStatsUseCase handles computing per-character error rates and per-bigram latency.
+type GetKeyStatsUseCase struct {
+	sessionRepo   repository.SessionRepository
+	textRepo      repository.TextRepository
+	keystrokeRepo repository.KeystrokeRepository
+	lineRepo      repository.LineResultRepository
+}
+
+// NewGetKeyStatsUseCase creates a new GetKeyStatsUseCase.
+func NewGetKeyStatsUseCase(
+	sessionRepo repository.SessionRepository,
+	textRepo repository.TextRepository,
+	keystrokeRepo repository.KeystrokeRepository,
+	lineRepo repository.LineResultRepository,
+) *GetKeyStatsUseCase {
+	return &GetKeyStatsUseCase{
+		sessionRepo:   sessionRepo,
+		textRepo:      textRepo,
+		keystrokeRepo: keystrokeRepo,
+		lineRepo:      lineRepo,
+	}
+}
+
+// GetKeyStatsInput represents the input for key statistics.
+// Zero From/To leave the range unbounded; sessions are selected by creation time,
+// From inclusive, To exclusive.
+type GetKeyStatsInput struct {
+	UserID domain.UserID
+	From   time.Time
+	To     time.Time
+}
+
+// KeyStat is the error rate of one expected character, lower-cased.
+type KeyStat struct {
+	Char         string
+	Attempts     int
+	Errors       int
+	ErrorPercent float64
+}
+
+// BigramStat is the average time from the first to the second letter of a pair.
+type BigramStat struct {
+	Bigram         string
+	Count          int
+	AverageLatency time.Duration
+}
+
+// GetKeyStatsOutput represents key statistics. Keys are sorted by error rate, then by
+// attempts, highest first; Bigrams by average latency, slowest first.
+type GetKeyStatsOutput struct {
+	Keys    []KeyStat
+	Bigrams []BigramStat
+}
+
+// Execute aggregates the user's typing per character and per letter pair.
+// Lines with captured keystrokes are analysed press by press; lines without them
+// fall back to aligning the typed line with the expected one, which yields error
+// rates but no latency. Returns domain.ErrInvalidQuery for an empty time range.
+func (uc *GetKeyStatsUseCase) Execute(ctx context.Context, input GetKeyStatsInput) (*GetKeyStatsOutput, error) {
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return nil, domain.NewFieldError(domain.ErrInvalidQuery, "to", "must be after from")
+	}
+
+	sessions, err := uc.sessionRepo.ListPage(ctx, repository.SessionQuery{UserID: input.UserID, From: input.From, To: input.To})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	events, err := uc.keystrokeRepo.ListByUserID(ctx, input.UserID, input.From, input.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keystrokes: %w", err)
+	}
+	results, err := uc.lineRepo.ListByUserID(ctx, input.UserID, input.From, input.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list line results: %w", err)
+	}
+
+	eventsBySession := map[domain.SessionID]map[int][]*domain.KeystrokeEvent{}
+	for _, event := range events {
+		byLine, ok := eventsBySession[event.SessionID]
+		if !ok {
+			byLine = map[int][]*domain.KeystrokeEvent{}
+			eventsBySession[event.SessionID] = byLine
+		}
+		byLine[event.LineIdx] = append(byLine[event.LineIdx], event)
+	}
+	resultsBySession := map[domain.SessionID][]*domain.LineResult{}
+	for _, result := range results {
+		resultsBySession[result.SessionID] = append(resultsBySession[result.SessionID], result)
+	}
+
+	acc := newKeyAccumulator()
+	texts := map[domain.TextID]*textLines{}
+	for _, session := range sessions {
+		byLine := eventsBySession[session.ID]
+		for _, lineEvents := range byLine {
+			acc.addKeystrokes(lineEvents)
+		}
+
+		results := resultsBySession[session.ID]
+		if len(results) == 0 {
+			continue
+		}
+		text, ok := texts[session.TextID]
+		if !ok {
+			if text, err = uc.loadText(ctx, session.TextID); err != nil {
+				return nil, err
+			}
+			texts[session.TextID] = text
+		}
+		for _, result := range results {
+			lineIdx := result.TextLine(text.fragmentSize)
+			if _, captured := byLine[lineIdx]; captured || lineIdx >= len(text.lines) {
+				continue
+			}
+			acc.addTypedLine(text.lines[lineIdx], result.Typed)
+		}
+	}
+
+	return acc.output(), nil
+}
+
+// textLines is the text of a session split into lines, cached per text.
+type textLines struct {
+	fragmentSize int
+	lines        []string
+}
+
+func (uc *GetKeyStatsUseCase) loadText(ctx context.Context, textID domain.TextID) (*textLines, error) {
+	info, err := uc.textRepo.GetTextInfo(ctx, textID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get text: %w", err)
+	}
+	fragments, err := uc.textRepo.GetFragmentsByTextID(ctx, textID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fragments: %w", err)
+	}
+	sort.Slice(fragments, func(i, j int) bool { return fragments[i].FragmentIdx < fragments[j].FragmentIdx })
+
+	text := &textLines{fragmentSize: info.FragmentSize}
+	for _, fragment := range fragments {
+		text.lines = append(text.lines, fragment.Lines()...)
+	}
+	return text, nil
+}
+
+// keyAccumulator sums attempts, errors and bigram latency.
+type keyAccumulator struct {
+	keys    map[string]*KeyStat
+	bigrams map[string]*bigramSum
+}
+
+type bigramSum struct {
+	count   int
+	latency time.Duration
+}
+
+func newKeyAccumulator() *keyAccumulator {
+	return &keyAccumulator{
+		keys:    map[string]*KeyStat{},
+		bigrams: map[string]*bigramSum{},
+	}
+}
+
+func (a *keyAccumulator) addAttempt(expected string, missed bool) {
+	char := strings.ToLower(expected)
+	stat, ok := a.keys[char]
+	if !ok {
+		stat = &KeyStat{Char: char}
+		a.keys[char] = stat
+	}
+	stat.Attempts++
+	if missed {
+		stat.Errors++
+	}
+}
+
+// addKeystrokes counts the presses of one line. Corrections are not attempts; a
+// bigram is two correct presses of letters with no correction in between.
+func (a *keyAccumulator) addKeystrokes(events []*domain.KeystrokeEvent) {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].OffsetMs < events[j].OffsetMs })
+
+	var prev *domain.KeystrokeEvent
+	for _, event := range events {
+		if event.IsCorrection || event.Expected == "" {
+			prev = nil
+			continue
+		}
+		missed := event.Key != event.Expected
+		a.addAttempt(event.Expected, missed)
+		if missed {
+			prev = nil
+			continue
+		}
+		if prev != nil && isLetter(prev.Expected) && isLetter(event.Expected) {
+			if latency := time.Duration(event.OffsetMs-prev.OffsetMs) * time.Millisecond; latency <= maxBigramLatency {
+				bigram := strings.ToLower(prev.Expected + event.Expected)
+				sum, ok := a.bigrams[bigram]
+				if !ok {
+					sum = &bigramSum{}
+					a.bigrams[bigram] = sum
+				}
+				sum.count++
+				sum.latency += latency
+			}
+		}
+		prev = event
+	}
+}
+
+// addTypedLine counts every expected character of a line, missed if the optimal
+// alignment with the typed line does not match it exactly.
+func (a *keyAccumulator) addTypedLine(expected, typed string) {
+	missed := missedRunes(expected, typed)
+	for i, r := range []rune(expected) {
+		a.addAttempt(string(r), missed[i])
+	}
+}
+
+func (a *keyAccumulator) output() *GetKeyStatsOutput {
+	output := &GetKeyStatsOutput{
+		Keys:    make([]KeyStat, 0, len(a.keys)),
+		Bigrams: make([]BigramStat, 0, len(a.bigrams)),
+	}
+	for _, stat := range a.keys {
+		stat.ErrorPercent = float64(stat.Errors) / float64(stat.Attempts) * 100
+		output.Keys = append(output.Keys, *stat)
+	}
+	sort.Slice(output.Keys, func(i, j int) bool {
+		ki, kj := output.Keys[i], output.Keys[j]
+		if ki.ErrorPercent != kj.ErrorPercent {
+			return ki.ErrorPercent > kj.ErrorPercent
+		}
+		if ki.Attempts != kj.Attempts {
+			return ki.Attempts > kj.Attempts
+		}
+		return ki.Char < kj.Char
+	})
+
+	for bigram, sum := range a.bigrams {
+		output.Bigrams = append(output.Bigrams, BigramStat{
+			Bigram:         bigram,
+			Count:          sum.count,
+			AverageLatency: sum.latency / time.Duration(sum.count),
+		})
+	}
+	sort.Slice(output.Bigrams, func(i, j int) bool {
+		bi, bj := output.Bigrams[i], output.Bigrams[j]
+		if bi.AverageLatency != bj.AverageLatency {
+			return bi.AverageLatency > bj.AverageLatency
+		}
+		return bi.Bigram < bj.Bigram
+	})
+	return output
+}
+
+func isLetter(s string) bool {
+	r, size := utf8.DecodeRuneInString(s)
+	return size == len(s) && unicode.IsLetter(r)
+}
+
+// missedRunes aligns typed with expected by Levenshtein distance and reports, for
+// each rune of expected, whether it was deleted or substituted.
+func missedRunes(expected, typed string) []bool {
+	re, rt := []rune(expected), []rune(typed)
+	dist := make([][]int, len(re)+1)
+	for i := range dist {
+		dist[i] = make([]int, len(rt)+1)
+		dist[i][0] = i
+	}
+	for j := range dist[0] {
+		dist[0][j] = j
+	}
+	for i := 1; i <= len(re); i++ {
+		for j := 1; j <= len(rt); j++ {
+			cost := 1
+			if re[i-1] == rt[j-1] {
+				cost = 0
+			}
+			dist[i][j] = min(dist[i-1][j]+1, dist[i][j-1]+1, dist[i-1][j-1]+cost)
+		}
+	}
+
+	missed := make([]bool, len(re))
+	for i, j := len(re), len(rt); i > 0; {
+		switch {
+		case j > 0 && re[i-1] == rt[j-1] && dist[i][j] == dist[i-1][j-1]:
+			i, j = i-1, j-1
+		case j > 0 && dist[i][j] == dist[i-1][j-1]+1:
+			missed[i-1] = true
+			i, j = i-1, j-1
+		case dist[i][j] == dist[i-1][j]+1:
+			missed[i-1] = true
+			i--
+		default:
+			j--
+		}
+	}
+	return missed
+}
@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestGetKeyStatsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 6, 9, 0, 0, 0, time.UTC)
+
+	sessionRepo := NewMockSessionRepository()
+	textRepo := NewMockTextRepository()
+	keystrokeRepo := NewMockKeystrokeRepository(sessionRepo)
+	lineRepo := NewMockLineResultRepository(sessionRepo)
+
+	info, err := domain.NewTextInfo("text_1", "user_1", "Keys", 2, 2, 1, now)
+	if err != nil {
+		t.Fatalf("Failed to create text: %v", err)
+	}
+	if err := textRepo.CreateTextInfo(ctx, info); err != nil {
+		t.Fatalf("Failed to store text: %v", err)
+	}
+	storeFragments(t, textRepo, "text_1", [][]string{{"the", "cat"}})
+
+	for _, spec := range []struct {
+		id     domain.SessionID
+		userID domain.UserID
+	}{
+		{id: "session_1", userID: "user_1"},
+		{id: "session_2", userID: "user_2"},
+	} {
+		session, err := domain.NewSession(spec.id, spec.userID, "text_1", now)
+		if err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		if err := sessionRepo.Create(ctx, session); err != nil {
+			t.Fatalf("Failed to store session: %v", err)
+		}
+	}
+
+	// Line 0 "the" is captured press by press: "t", "x" instead of "h", Backspace,
+	// "h", "e". Line 1 "cat" only has its typed text, "cot".
+	var events []*domain.KeystrokeEvent
+	for _, e := range []struct {
+		key, expected string
+		offsetMs      int64
+		correction    bool
+	}{
+		{key: "t", expected: "t", offsetMs: 0},
+		{key: "x", expected: "h", offsetMs: 100},
+		{key: "Backspace", expected: "h", offsetMs: 300, correction: true},
+		{key: "h", expected: "h", offsetMs: 500},
+		{key: "e", expected: "e", offsetMs: 620},
+	} {
+		event, err := domain.NewKeystrokeEvent("session_1", 0, e.key, e.expected, e.offsetMs, e.correction, now)
+		if err != nil {
+			t.Fatalf("Failed to create keystroke: %v", err)
+		}
+		events = append(events, event)
+	}
+	if err := keystrokeRepo.Append(ctx, events); err != nil {
+		t.Fatalf("Failed to store keystrokes: %v", err)
+	}
+	for i, typed := range []string{"the", "cot"} {
+		line, err := domain.NewLineResult("session_1", 0, i, typed, 100, 30, time.Second, 0, now)
+		if err != nil {
+			t.Fatalf("Failed to create line result: %v", err)
+		}
+		if err := lineRepo.Create(ctx, line); err != nil {
+			t.Fatalf("Failed to store line result: %v", err)
+		}
+	}
+
+	useCase := NewGetKeyStatsUseCase(sessionRepo, textRepo, keystrokeRepo, lineRepo)
+
+	t.Run("keystrokes and typed lines", func(t *testing.T) {
+		output, err := useCase.Execute(ctx, GetKeyStatsInput{UserID: "user_1"})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		wantKeys := []KeyStat{
+			{Char: "a", Attempts: 1, Errors: 1, ErrorPercent: 100},
+			{Char: "h", Attempts: 2, Errors: 1, ErrorPercent: 50},
+			{Char: "t", Attempts: 2},
+			{Char: "c", Attempts: 1},
+			{Char: "e", Attempts: 1},
+		}
+		if !reflect.DeepEqual(output.Keys, wantKeys) {
+			t.Errorf("Execute() Keys = %+v, want %+v", output.Keys, wantKeys)
+		}
+		// "th" is broken by the miss; only "he" has two correct presses in a row.
+		wantBigrams := []BigramStat{{Bigram: "he", Count: 1, AverageLatency: 120 * time.Millisecond}}
+		if !reflect.DeepEqual(output.Bigrams, wantBigrams) {
+			t.Errorf("Execute() Bigrams = %+v, want %+v", output.Bigrams, wantBigrams)
+		}
+	})
+
+	t.Run("other users see nothing", func(t *testing.T) {
+		output, err := useCase.Execute(ctx, GetKeyStatsInput{UserID: "user_2"})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if len(output.Keys) != 0 || len(output.Bigrams) != 0 {
+			t.Errorf("Execute() = %+v, want no statistics", output)
+		}
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		_, err := useCase.Execute(ctx, GetKeyStatsInput{UserID: "user_1", From: now, To: now})
+		if !errors.Is(err, domain.ErrInvalidQuery) {
+			t.Errorf("Execute() error = %v, want %v", err, domain.ErrInvalidQuery)
+		}
+	})
+}
+
+func TestMissedRunes(t *testing.T) {
+	tests := []struct {
+		expected string
+		typed    string
+		want     []bool
+	}{
+		{expected: "cat", typed: "cat", want: []bool{false, false, false}},
+		{expected: "cat", typed: "cot", want: []bool{false, true, false}},
+		{expected: "cat", typed: "ct", want: []bool{false, true, false}},
+		{expected: "cat", typed: "caat", want: []bool{false, false, false}},
+		{expected: "cat", typed: "", want: []bool{true, true, true}},
+		{expected: "кот", typed: "кит", want: []bool{false, true, false}},
+	}
+
+	for _, tt := range tests {
+		if got := missedRunes(tt.expected, tt.typed); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("missedRunes(%q, %q) = %v, want %v", tt.expected, tt.typed, got, tt.want)
+		}
+	}
+}
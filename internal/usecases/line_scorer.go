@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// charsPerWord is the standard word length used for WPM: five characters, spaces included.
+const charsPerWord = 5.0
+
+// LineScore is the server-computed result of typing one line.
+// Errors is the edit distance between the expected and typed line, in characters.
+// GrossWPM counts every typed character; NetWPM subtracts one word per error per minute.
+type LineScore struct {
+	AccuracyPercent float64
+	GrossWPM        float64
+	NetWPM          float64
+	Errors          int
+}
+
+// LineScorer computes accuracy and speed for a typed line.
+type LineScorer struct{}
+
+// NewLineScorer creates a new line scorer.
+func NewLineScorer() *LineScorer {
+	return &LineScorer{}
+}
+
+// Score compares typed with expected. Accuracy is based on the Levenshtein distance over
+// characters, so a single skipped or extra character costs one error instead of shifting
+// every character after it. A non-positive elapsed time yields zero WPM.
+func (s *LineScorer) Score(expected, typed string, elapsed time.Duration) LineScore {
+	errors := editDistance(expected, typed)
+
+	score := LineScore{AccuracyPercent: 100, Errors: errors}
+	if longest := max(utf8.RuneCountInString(expected), utf8.RuneCountInString(typed)); longest > 0 {
+		score.AccuracyPercent = float64(longest-errors) / float64(longest) * 100
+	}
+
+	minutes := elapsed.Minutes()
+	if minutes <= 0 {
+		return score
+	}
+	score.GrossWPM = float64(utf8.RuneCountInString(typed)) / charsPerWord / minutes
+	score.NetWPM = max(0, score.GrossWPM-float64(errors)/minutes)
+	return score
+}
+
+// editDistance returns the Levenshtein distance between a and b, counted in runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
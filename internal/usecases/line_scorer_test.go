@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "abc", b: "", want: 3},
+		{a: "", b: "abc", want: 3},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "hello world", b: "helo world", want: 1},
+		{a: "привет", b: "превет", want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestLineScorer_Score(t *testing.T) {
+	scorer := NewLineScorer()
+
+	tests := []struct {
+		name         string
+		expected     string
+		typed        string
+		elapsed      time.Duration
+		wantAccuracy float64
+		wantGross    float64
+		wantNet      float64
+		wantErrors   int
+	}{
+		{
+			name:         "perfect line",
+			expected:     "the quick brown fox",
+			typed:        "the quick brown fox",
+			elapsed:      6 * time.Second,
+			wantAccuracy: 100,
+			wantGross:    38,
+			wantNet:      38,
+		},
+		{
+			// A positional comparison would count every character after the gap as wrong.
+			name:         "skipped character costs one error",
+			expected:     "abcdefghij",
+			typed:        "acdefghij",
+			elapsed:      12 * time.Second,
+			wantAccuracy: 90,
+			wantGross:    9,
+			wantNet:      4,
+			wantErrors:   1,
+		},
+		{
+			name:         "nothing typed",
+			expected:     "abcde",
+			typed:        "",
+			elapsed:      time.Minute,
+			wantAccuracy: 0,
+			wantErrors:   5,
+		},
+		{
+			name:         "empty expected and typed",
+			expected:     "",
+			typed:        "",
+			elapsed:      time.Second,
+			wantAccuracy: 100,
+		},
+		{
+			name:         "no elapsed time",
+			expected:     "abc",
+			typed:        "abc",
+			elapsed:      0,
+			wantAccuracy: 100,
+		},
+		{
+			name:         "net WPM never negative",
+			expected:     "abcde",
+			typed:        "vwxyz",
+			elapsed:      time.Minute,
+			wantAccuracy: 0,
+			wantGross:    1,
+			wantNet:      0,
+			wantErrors:   5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorer.Score(tt.expected, tt.typed, tt.elapsed)
+			if got.Errors != tt.wantErrors {
+				t.Errorf("Score() Errors = %v, want %v", got.Errors, tt.wantErrors)
+			}
+			if !approxEqual(got.AccuracyPercent, tt.wantAccuracy) {
+				t.Errorf("Score() AccuracyPercent = %v, want %v", got.AccuracyPercent, tt.wantAccuracy)
+			}
+			if !approxEqual(got.GrossWPM, tt.wantGross) {
+				t.Errorf("Score() GrossWPM = %v, want %v", got.GrossWPM, tt.wantGross)
+			}
+			if !approxEqual(got.NetWPM, tt.wantNet) {
+				t.Errorf("Score() NetWPM = %v, want %v", got.NetWPM, tt.wantNet)
+			}
+		})
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// ListAPITokensUseCase handles listing a user's API tokens.
+type ListAPITokensUseCase struct {
+	tokenRepo repository.APITokenRepository
+}
+
+// NewListAPITokensUseCase creates a new ListAPITokensUseCase.
+func NewListAPITokensUseCase(tokenRepo repository.APITokenRepository) *ListAPITokensUseCase {
+	return &ListAPITokensUseCase{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// ListAPITokensInput represents the input for listing API tokens.
+type ListAPITokensInput struct {
+	UserID domain.UserID
+}
+
+// ListAPITokensOutput represents the result of listing API tokens.
+type ListAPITokensOutput struct {
+	Tokens []*domain.APIToken
+}
+
+// Execute returns all of the user's tokens, including revoked ones, oldest first.
+func (uc *ListAPITokensUseCase) Execute(ctx context.Context, input ListAPITokensInput) (*ListAPITokensOutput, error) {
+	tokens, err := uc.tokenRepo.ListByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+
+	return &ListAPITokensOutput{Tokens: tokens}, nil
+}
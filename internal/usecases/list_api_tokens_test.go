@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestListAPITokensUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	tokenRepo := NewMockAPITokenRepository()
+	for _, id := range []domain.APITokenID{"token_1", "token_2"} {
+		token, _ := domain.NewAPIToken(id, "user_1", "ci", "hash_"+string(id), domain.ScopeRead, time.Now())
+		tokenRepo.Create(ctx, token)
+	}
+
+	useCase := NewListAPITokensUseCase(tokenRepo)
+
+	tests := []struct {
+		name    string
+		userID  domain.UserID
+		wantLen int
+	}{
+		{name: "user with tokens", userID: "user_1", wantLen: 2},
+		{name: "user without tokens", userID: "user_2", wantLen: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := useCase.Execute(ctx, ListAPITokensInput{UserID: tt.userID})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if len(output.Tokens) != tt.wantLen {
+				t.Errorf("Execute() length = %v, want %v", len(output.Tokens), tt.wantLen)
+			}
+		})
+	}
+}
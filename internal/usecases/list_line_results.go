@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// ListLineResultsUseCase handles listing the per-line results of a session.
+type ListLineResultsUseCase struct {
+	sessionRepo repository.SessionRepository
+	lineRepo    repository.LineResultRepository
+}
+
+// NewListLineResultsUseCase creates a new ListLineResultsUseCase.
+func NewListLineResultsUseCase(sessionRepo repository.SessionRepository, lineRepo repository.LineResultRepository) *ListLineResultsUseCase {
+	return &ListLineResultsUseCase{
+		sessionRepo: sessionRepo,
+		lineRepo:    lineRepo,
+	}
+}
+
+// ListLineResultsInput represents the input for listing line results.
+type ListLineResultsInput struct {
+	UserID    domain.UserID
+	SessionID domain.SessionID
+}
+
+// ListLineResultsOutput represents the result of listing line results.
+type ListLineResultsOutput struct {
+	Session *domain.Session
+	Lines   []*domain.LineResult
+}
+
+// Execute returns the results of every completed line of a session in text order.
+// Returns domain.ErrForbidden if the session belongs to another user.
+func (uc *ListLineResultsUseCase) Execute(ctx context.Context, input ListLineResultsInput) (*ListLineResultsOutput, error) {
+	session, err := getOwnedSession(ctx, uc.sessionRepo, input.SessionID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := uc.lineRepo.ListBySessionID(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list line results: %w", err)
+	}
+
+	return &ListLineResultsOutput{Session: session, Lines: lines}, nil
+}
@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestListLineResultsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	sessionRepo := NewMockSessionRepository()
+	session, err := domain.NewSession("session_1", "user_1", "text_1", now)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	lineRepo := NewMockLineResultRepository(sessionRepo)
+	for _, spec := range []struct{ fragmentIdx, lineIdx int }{{1, 0}, {0, 1}, {0, 0}} {
+		line, err := domain.NewLineResult("session_1", spec.fragmentIdx, spec.lineIdx, "typed", 100, 40, time.Second, 0, now)
+		if err != nil {
+			t.Fatalf("Failed to create line result: %v", err)
+		}
+		if err := lineRepo.Create(ctx, line); err != nil {
+			t.Fatalf("Failed to store line result: %v", err)
+		}
+	}
+
+	useCase := NewListLineResultsUseCase(sessionRepo, lineRepo)
+
+	tests := []struct {
+		name      string
+		input     ListLineResultsInput
+		wantLines [][2]int
+		wantErr   error
+	}{
+		{
+			name:      "ordered by line",
+			input:     ListLineResultsInput{UserID: "user_1", SessionID: "session_1"},
+			wantLines: [][2]int{{0, 0}, {0, 1}, {1, 0}},
+		},
+		{
+			name:    "other user's session",
+			input:   ListLineResultsInput{UserID: "user_2", SessionID: "session_1"},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := useCase.Execute(ctx, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(output.Lines) != len(tt.wantLines) {
+				t.Fatalf("Execute() lines = %v, want %v", len(output.Lines), len(tt.wantLines))
+			}
+			for i, want := range tt.wantLines {
+				got := output.Lines[i]
+				if got.FragmentIdx != want[0] || got.LineIdx != want[1] {
+					t.Errorf("Execute() line %d = (%v, %v), want (%v, %v)", i, got.FragmentIdx, got.LineIdx, want[0], want[1])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// Page sizes for ListSessionsUseCase.
+const (
+	DefaultSessionsPageSize = 20
+	MaxSessionsPageSize     = 100
+)
+
+// ListSessionsUseCase handles listing a user's sessions with filters and cursor pagination.
+type ListSessionsUseCase struct {
+	sessionRepo repository.SessionRepository
+}
+
+// NewListSessionsUseCase creates a new ListSessionsUseCase.
+func NewListSessionsUseCase(sessionRepo repository.SessionRepository) *ListSessionsUseCase {
+	return &ListSessionsUseCase{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// ListSessionsInput represents the input for listing sessions.
+// Zero values disable a filter: TextID matches any text, Completed nil matches both
+// completed and other sessions, an empty Status matches every status and zero From/To
+// leave the creation time unbounded. From is inclusive, To
+// exclusive. Cursor is the NextCursor of a previous page, empty for the first page.
+// Limit defaults to DefaultSessionsPageSize and may not exceed MaxSessionsPageSize.
+type ListSessionsInput struct {
+	UserID    domain.UserID
+	TextID    domain.TextID
+	Completed *bool
+	Status    domain.SessionStatus
+	From      time.Time
+	To        time.Time
+	Cursor    string
+	Limit     int
+}
+
+// ListSessionsOutput represents one page of sessions.
+// NextCursor is empty when there are no more sessions.
+type ListSessionsOutput struct {
+	Sessions   []*domain.Session
+	NextCursor string
+}
+
+// Execute returns the user's sessions matching the filters, newest first.
+// Returns domain.ErrInvalidQuery for a malformed cursor, limit or time range.
+func (uc *ListSessionsUseCase) Execute(ctx context.Context, input ListSessionsInput) (*ListSessionsOutput, error) {
+	limit := input.Limit
+	if limit == 0 {
+		limit = DefaultSessionsPageSize
+	}
+	if limit < 0 || limit > MaxSessionsPageSize {
+		return nil, domain.NewFieldError(domain.ErrInvalidQuery, "limit", fmt.Sprintf("must be between 1 and %d", MaxSessionsPageSize))
+	}
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return nil, domain.NewFieldError(domain.ErrInvalidQuery, "to", "must be after from")
+	}
+	query := repository.SessionQuery{
+		UserID:    input.UserID,
+		TextID:    input.TextID,
+		Completed: input.Completed,
+		Status:    input.Status,
+		From:      input.From,
+		To:        input.To,
+		// One extra session tells whether there is a next page.
+		Limit: limit + 1,
+	}
+	if input.Cursor != "" {
+		after, err := decodeSessionCursor(input.Cursor)
+		if err != nil {
+			return nil, domain.NewFieldError(domain.ErrInvalidQuery, "cursor", "is invalid")
+		}
+		query.After = &after
+	}
+
+	sessions, err := uc.sessionRepo.ListPage(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	if len(sessions) <= limit {
+		return &ListSessionsOutput{Sessions: sessions}, nil
+	}
+	page := sessions[:limit]
+	next := encodeSessionCursor(repository.PositionOf(page[limit-1]))
+	return &ListSessionsOutput{Sessions: page, NextCursor: next}, nil
+}
+
+// encodeSessionCursor returns the opaque form of a listing position handed to clients.
+func encodeSessionCursor(p repository.SessionPosition) string {
+	raw := strconv.FormatInt(p.CreatedAt.UnixNano(), 10) + ":" + string(p.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeSessionCursor(s string) (repository.SessionPosition, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return repository.SessionPosition{}, err
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return repository.SessionPosition{}, fmt.Errorf("malformed cursor %q", raw)
+	}
+	createdAt, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return repository.SessionPosition{}, err
+	}
+	return repository.SessionPosition{CreatedAt: time.Unix(0, createdAt), ID: domain.SessionID(id)}, nil
+}
@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestListSessionsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	sessionRepo := NewMockSessionRepository()
+	for i, spec := range []struct {
+		id        domain.SessionID
+		userID    domain.UserID
+		textID    domain.TextID
+		completed bool
+	}{
+		{id: "session_1", userID: "user_1", textID: "text_1", completed: true},
+		{id: "session_2", userID: "user_1", textID: "text_2"},
+		{id: "session_3", userID: "user_1", textID: "text_1"},
+		{id: "session_4", userID: "user_2", textID: "text_1"},
+		{id: "session_5", userID: "user_1", textID: "text_1", completed: true},
+	} {
+		session, err := domain.NewSession(spec.id, spec.userID, spec.textID, base.Add(time.Duration(i)*24*time.Hour))
+		if err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		if spec.completed {
+			session.Status = domain.SessionCompleted
+		}
+		if err := sessionRepo.Create(ctx, session); err != nil {
+			t.Fatalf("Failed to store session: %v", err)
+		}
+	}
+
+	useCase := NewListSessionsUseCase(sessionRepo)
+	completed := true
+
+	tests := []struct {
+		name    string
+		input   ListSessionsInput
+		wantIDs []domain.SessionID
+		wantErr error
+	}{
+		{
+			name:    "newest first",
+			input:   ListSessionsInput{UserID: "user_1"},
+			wantIDs: []domain.SessionID{"session_5", "session_3", "session_2", "session_1"},
+		},
+		{
+			name:    "by text",
+			input:   ListSessionsInput{UserID: "user_1", TextID: "text_2"},
+			wantIDs: []domain.SessionID{"session_2"},
+		},
+		{
+			name:    "completed only",
+			input:   ListSessionsInput{UserID: "user_1", Completed: &completed},
+			wantIDs: []domain.SessionID{"session_5", "session_1"},
+		},
+		{
+			name:    "time range",
+			input:   ListSessionsInput{UserID: "user_1", From: base.Add(24 * time.Hour), To: base.Add(3 * 24 * time.Hour)},
+			wantIDs: []domain.SessionID{"session_3", "session_2"},
+		},
+		{
+			name:    "empty range",
+			input:   ListSessionsInput{UserID: "user_1", From: base, To: base},
+			wantErr: domain.ErrInvalidQuery,
+		},
+		{
+			name:    "limit too large",
+			input:   ListSessionsInput{UserID: "user_1", Limit: MaxSessionsPageSize + 1},
+			wantErr: domain.ErrInvalidQuery,
+		},
+		{
+			name:    "malformed cursor",
+			input:   ListSessionsInput{UserID: "user_1", Cursor: "not a cursor"},
+			wantErr: domain.ErrInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := useCase.Execute(ctx, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			assertSessionIDs(t, output.Sessions, tt.wantIDs)
+			if output.NextCursor != "" {
+				t.Errorf("Execute() NextCursor = %q, want none", output.NextCursor)
+			}
+		})
+	}
+
+	t.Run("pages through all sessions", func(t *testing.T) {
+		var got []*domain.Session
+		input := ListSessionsInput{UserID: "user_1", Limit: 3}
+		for pages := 0; ; pages++ {
+			if pages > 2 {
+				t.Fatal("Execute() did not stop paging")
+			}
+			output, err := useCase.Execute(ctx, input)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			got = append(got, output.Sessions...)
+			if output.NextCursor == "" {
+				break
+			}
+			input.Cursor = output.NextCursor
+		}
+		assertSessionIDs(t, got, []domain.SessionID{"session_5", "session_3", "session_2", "session_1"})
+	})
+}
+
+func assertSessionIDs(t *testing.T, sessions []*domain.Session, want []domain.SessionID) {
+	t.Helper()
+	if len(sessions) != len(want) {
+		t.Fatalf("Execute() returned %d sessions, want %d", len(sessions), len(want))
+	}
+	for i, id := range want {
+		if sessions[i].ID != id {
+			t.Errorf("Execute() session %d = %v, want %v", i, sessions[i].ID, id)
+		}
+	}
+}
@@ -36,7 +36,7 @@ func (uc *ListTextsUseCase) Execute(ctx context.Context, input ListTextsInput) (
 	// Verify user exists
 	_, err := uc.userRepo.GetByID(ctx, input.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	
 	texts, err := uc.textRepo.ListByUserID(ctx, input.UserID)
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// ListUnfinishedSessionsUseCase handles listing a user's sessions that can be resumed.
+type ListUnfinishedSessionsUseCase struct {
+	sessionRepo repository.SessionRepository
+}
+
+// NewListUnfinishedSessionsUseCase creates a new ListUnfinishedSessionsUseCase.
+func NewListUnfinishedSessionsUseCase(sessionRepo repository.SessionRepository) *ListUnfinishedSessionsUseCase {
+	return &ListUnfinishedSessionsUseCase{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// ListUnfinishedSessionsInput represents the input for listing unfinished sessions.
+// Limit caps the number of sessions returned; zero or less means no limit.
+type ListUnfinishedSessionsInput struct {
+	UserID domain.UserID
+	Limit  int
+}
+
+// ListUnfinishedSessionsOutput represents the result of listing unfinished sessions.
+type ListUnfinishedSessionsOutput struct {
+	Sessions []*domain.Session
+}
+
+// Execute returns the user's active and paused sessions, most recently updated first.
+func (uc *ListUnfinishedSessionsUseCase) Execute(ctx context.Context, input ListUnfinishedSessionsInput) (*ListUnfinishedSessionsOutput, error) {
+	sessions, err := uc.sessionRepo.ListByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	unfinished := make([]*domain.Session, 0, len(sessions))
+	for _, session := range sessions {
+		if session.IsOpen() {
+			unfinished = append(unfinished, session)
+		}
+	}
+	sort.SliceStable(unfinished, func(i, j int) bool {
+		return unfinished[i].UpdatedAt.After(unfinished[j].UpdatedAt)
+	})
+	if input.Limit > 0 && len(unfinished) > input.Limit {
+		unfinished = unfinished[:input.Limit]
+	}
+
+	return &ListUnfinishedSessionsOutput{Sessions: unfinished}, nil
+}
@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestListUnfinishedSessionsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	sessionRepo := NewMockSessionRepository()
+	for i, spec := range []struct {
+		id        domain.SessionID
+		userID    domain.UserID
+		completed bool
+	}{
+		{id: "session_1", userID: "user_1"},
+		{id: "session_2", userID: "user_1", completed: true},
+		{id: "session_3", userID: "user_1"},
+		{id: "session_4", userID: "user_2"},
+	} {
+		session, err := domain.NewSession(spec.id, spec.userID, "text_1", now.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		if spec.completed {
+			session.Status = domain.SessionCompleted
+		}
+		if err := sessionRepo.Create(ctx, session); err != nil {
+			t.Fatalf("Failed to store session: %v", err)
+		}
+	}
+
+	useCase := NewListUnfinishedSessionsUseCase(sessionRepo)
+
+	tests := []struct {
+		name    string
+		input   ListUnfinishedSessionsInput
+		wantIDs []domain.SessionID
+	}{
+		{
+			name:    "most recent first, completed skipped",
+			input:   ListUnfinishedSessionsInput{UserID: "user_1"},
+			wantIDs: []domain.SessionID{"session_3", "session_1"},
+		},
+		{
+			name:    "limit",
+			input:   ListUnfinishedSessionsInput{UserID: "user_1", Limit: 1},
+			wantIDs: []domain.SessionID{"session_3"},
+		},
+		{
+			name:    "no sessions",
+			input:   ListUnfinishedSessionsInput{UserID: "user_3"},
+			wantIDs: []domain.SessionID{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := useCase.Execute(ctx, tt.input)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if len(output.Sessions) != len(tt.wantIDs) {
+				t.Fatalf("Execute() length = %v, want %v", len(output.Sessions), len(tt.wantIDs))
+			}
+			for i, id := range tt.wantIDs {
+				if output.Sessions[i].ID != id {
+					t.Errorf("Execute() Sessions[%d] = %v, want %v", i, output.Sessions[i].ID, id)
+				}
+			}
+		})
+	}
+}
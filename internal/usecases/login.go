@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// LoginUseCase handles verifying an email and password.
+type LoginUseCase struct {
+	userRepo repository.UserRepository
+	hasher   PasswordHasher
+}
+
+// NewLoginUseCase creates a new LoginUseCase.
+func NewLoginUseCase(userRepo repository.UserRepository, hasher PasswordHasher) *LoginUseCase {
+	return &LoginUseCase{
+		userRepo: userRepo,
+		hasher:   hasher,
+	}
+}
+
+// LoginInput represents the input for logging in.
+type LoginInput struct {
+	Email    string
+	Password string
+}
+
+// LoginOutput represents the result of logging in.
+type LoginOutput struct {
+	User *domain.User
+}
+
+// Execute returns the user if the password matches.
+// Returns domain.ErrInvalidCredentials for an unknown email or wrong password.
+func (uc *LoginUseCase) Execute(ctx context.Context, input LoginInput) (*LoginOutput, error) {
+	user, err := uc.userRepo.GetByEmail(ctx, normalizeEmail(input.Email))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := uc.hasher.Compare(user.PasswordHash, input.Password); err != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	return &LoginOutput{User: user}, nil
+}
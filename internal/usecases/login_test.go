@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"typeten/internal/domain"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLoginUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := NewMockUserRepository()
+	hasher := NewBcryptPasswordHasher(bcrypt.MinCost)
+	registered, err := NewRegisterUserUseCase(userRepo, hasher).Execute(ctx, RegisterUserInput{
+		Email:    "test@example.com",
+		Username: "testuser",
+		Password: "correct horse",
+	})
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+
+	useCase := NewLoginUseCase(userRepo, hasher)
+
+	tests := []struct {
+		name    string
+		input   LoginInput
+		wantErr error
+	}{
+		{
+			name:    "valid credentials",
+			input:   LoginInput{Email: "TEST@example.com", Password: "correct horse"},
+			wantErr: nil,
+		},
+		{
+			name:    "wrong password",
+			input:   LoginInput{Email: "test@example.com", Password: "wrong horse"},
+			wantErr: domain.ErrInvalidCredentials,
+		},
+		{
+			name:    "unknown email",
+			input:   LoginInput{Email: "nobody@example.com", Password: "correct horse"},
+			wantErr: domain.ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := useCase.Execute(ctx, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && output.User.ID != registered.User.ID {
+				t.Errorf("Execute() UserID = %v, want %v", output.User.ID, registered.User.ID)
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 	"typeten/internal/domain"
+	"typeten/internal/repository"
 )
 
 // MockUserRepository is a mock implementation of UserRepository for testing.
@@ -21,7 +24,10 @@ func NewMockUserRepository() *MockUserRepository {
 
 func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
 	if _, exists := m.users[user.ID]; exists {
-		return fmt.Errorf("user already exists")
+		return fmt.Errorf("user: %w", repository.ErrAlreadyExists)
+	}
+	if _, exists := m.byEmail[user.Email]; exists {
+		return fmt.Errorf("user: %w", repository.ErrAlreadyExists)
 	}
 	m.users[user.ID] = user
 	m.byEmail[user.Email] = user
@@ -31,7 +37,7 @@ func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) erro
 func (m *MockUserRepository) GetByID(ctx context.Context, id domain.UserID) (*domain.User, error) {
 	user, exists := m.users[id]
 	if !exists {
-		return nil, fmt.Errorf("user not found")
+		return nil, fmt.Errorf("user: %w", repository.ErrNotFound)
 	}
 	return user, nil
 }
@@ -39,7 +45,7 @@ func (m *MockUserRepository) GetByID(ctx context.Context, id domain.UserID) (*do
 func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	user, exists := m.byEmail[email]
 	if !exists {
-		return nil, fmt.Errorf("user not found")
+		return nil, fmt.Errorf("user: %w", repository.ErrNotFound)
 	}
 	return user, nil
 }
@@ -63,7 +69,7 @@ func NewMockTextRepository() *MockTextRepository {
 
 func (m *MockTextRepository) CreateTextInfo(ctx context.Context, info *domain.TextInfo) error {
 	if _, exists := m.texts[info.ID]; exists {
-		return fmt.Errorf("text already exists")
+		return fmt.Errorf("text: %w", repository.ErrAlreadyExists)
 	}
 	m.texts[info.ID] = info
 	m.byUser[info.UserID] = append(m.byUser[info.UserID], info)
@@ -73,7 +79,7 @@ func (m *MockTextRepository) CreateTextInfo(ctx context.Context, info *domain.Te
 func (m *MockTextRepository) GetTextInfo(ctx context.Context, id domain.TextID) (*domain.TextInfo, error) {
 	info, exists := m.texts[id]
 	if !exists {
-		return nil, fmt.Errorf("text not found")
+		return nil, fmt.Errorf("text: %w", repository.ErrNotFound)
 	}
 	return info, nil
 }
@@ -90,7 +96,7 @@ func (m *MockTextRepository) ListByUserID(ctx context.Context, userID domain.Use
 
 func (m *MockTextRepository) CreateFragment(ctx context.Context, fragment *domain.TextFragment) error {
 	if _, exists := m.fragments[fragment.ID]; exists {
-		return fmt.Errorf("fragment already exists")
+		return fmt.Errorf("fragment: %w", repository.ErrAlreadyExists)
 	}
 	m.fragments[fragment.ID] = fragment
 	m.byTextID[fragment.TextID] = append(m.byTextID[fragment.TextID], fragment)
@@ -100,7 +106,7 @@ func (m *MockTextRepository) CreateFragment(ctx context.Context, fragment *domai
 func (m *MockTextRepository) GetFragment(ctx context.Context, id domain.TextFragmentID) (*domain.TextFragment, error) {
 	fragment, exists := m.fragments[id]
 	if !exists {
-		return nil, fmt.Errorf("fragment not found")
+		return nil, fmt.Errorf("fragment: %w", repository.ErrNotFound)
 	}
 	return fragment, nil
 }
@@ -130,7 +136,7 @@ func NewMockSessionRepository() *MockSessionRepository {
 
 func (m *MockSessionRepository) Create(ctx context.Context, session *domain.Session) error {
 	if _, exists := m.sessions[session.ID]; exists {
-		return fmt.Errorf("session already exists")
+		return fmt.Errorf("session: %w", repository.ErrAlreadyExists)
 	}
 	m.sessions[session.ID] = session
 	m.byUser[session.UserID] = append(m.byUser[session.UserID], session)
@@ -140,16 +146,18 @@ func (m *MockSessionRepository) Create(ctx context.Context, session *domain.Sess
 func (m *MockSessionRepository) GetByID(ctx context.Context, id domain.SessionID) (*domain.Session, error) {
 	session, exists := m.sessions[id]
 	if !exists {
-		return nil, fmt.Errorf("session not found")
+		return nil, fmt.Errorf("session: %w", repository.ErrNotFound)
 	}
-	return session, nil
+	cp := *session
+	return &cp, nil
 }
 
 func (m *MockSessionRepository) Update(ctx context.Context, session *domain.Session) error {
-	if _, exists := m.sessions[session.ID]; !exists {
-		return fmt.Errorf("session not found")
+	stored, exists := m.sessions[session.ID]
+	if !exists {
+		return fmt.Errorf("session: %w", repository.ErrNotFound)
 	}
-	m.sessions[session.ID] = session
+	*stored = *session
 	return nil
 }
 
@@ -162,3 +170,230 @@ func (m *MockSessionRepository) ListByUserID(ctx context.Context, userID domain.
 	copy(result, sessions)
 	return result, nil
 }
+
+func (m *MockSessionRepository) ListPage(ctx context.Context, query repository.SessionQuery) ([]*domain.Session, error) {
+	result := []*domain.Session{}
+	for _, session := range m.byUser[query.UserID] {
+		if query.Matches(session) {
+			result = append(result, session)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return repository.PositionOf(result[i]).Before(repository.PositionOf(result[j]))
+	})
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result, nil
+}
+
+func (m *MockSessionRepository) ListIdle(ctx context.Context, before time.Time) ([]*domain.Session, error) {
+	result := []*domain.Session{}
+	for _, session := range m.sessions {
+		if session.IsOpen() && session.UpdatedAt.Before(before) {
+			cp := *session
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].UpdatedAt.Before(result[j].UpdatedAt) })
+	return result, nil
+}
+
+func (m *MockSessionRepository) UpdateStatus(ctx context.Context, session *domain.Session, from domain.SessionStatus) error {
+	stored, exists := m.sessions[session.ID]
+	if !exists || stored.Status != from {
+		return fmt.Errorf("session: %w", repository.ErrNotFound)
+	}
+	stored.Status = session.Status
+	stored.PausedAt = session.PausedAt
+	stored.PausedTime = session.PausedTime
+	stored.UpdatedAt = session.UpdatedAt
+	return nil
+}
+
+func (m *MockSessionRepository) AbandonIfIdle(ctx context.Context, id domain.SessionID, before time.Time) error {
+	session, exists := m.sessions[id]
+	if !exists || !session.IsOpen() || !session.UpdatedAt.Before(before) {
+		return fmt.Errorf("session: %w", repository.ErrNotFound)
+	}
+	return session.Abandon(session.UpdatedAt)
+}
+
+// MockAPITokenRepository is a mock implementation of APITokenRepository for testing.
+type MockAPITokenRepository struct {
+	tokens map[domain.APITokenID]*domain.APIToken
+	byUser map[domain.UserID][]*domain.APIToken
+}
+
+func NewMockAPITokenRepository() *MockAPITokenRepository {
+	return &MockAPITokenRepository{
+		tokens: make(map[domain.APITokenID]*domain.APIToken),
+		byUser: make(map[domain.UserID][]*domain.APIToken),
+	}
+}
+
+func (m *MockAPITokenRepository) Create(ctx context.Context, token *domain.APIToken) error {
+	if _, exists := m.tokens[token.ID]; exists {
+		return fmt.Errorf("api token: %w", repository.ErrAlreadyExists)
+	}
+	m.tokens[token.ID] = token
+	m.byUser[token.UserID] = append(m.byUser[token.UserID], token)
+	return nil
+}
+
+func (m *MockAPITokenRepository) GetByID(ctx context.Context, id domain.APITokenID) (*domain.APIToken, error) {
+	token, exists := m.tokens[id]
+	if !exists {
+		return nil, fmt.Errorf("api token: %w", repository.ErrNotFound)
+	}
+	return token, nil
+}
+
+func (m *MockAPITokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, fmt.Errorf("api token: %w", repository.ErrNotFound)
+}
+
+func (m *MockAPITokenRepository) Update(ctx context.Context, token *domain.APIToken) error {
+	if _, exists := m.tokens[token.ID]; !exists {
+		return fmt.Errorf("api token: %w", repository.ErrNotFound)
+	}
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *MockAPITokenRepository) MarkUsed(ctx context.Context, id domain.APITokenID, at time.Time) error {
+	token, exists := m.tokens[id]
+	if !exists || token.IsRevoked() {
+		return fmt.Errorf("api token: %w", repository.ErrNotFound)
+	}
+	token.MarkUsed(at)
+	return nil
+}
+
+func (m *MockAPITokenRepository) ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.APIToken, error) {
+	result := make([]*domain.APIToken, len(m.byUser[userID]))
+	copy(result, m.byUser[userID])
+	return result, nil
+}
+
+// MockKeystrokeRepository is a mock implementation of KeystrokeRepository for testing.
+type MockKeystrokeRepository struct {
+	events   []*domain.KeystrokeEvent
+	sessions repository.SessionRepository
+}
+
+func NewMockKeystrokeRepository(sessions repository.SessionRepository) *MockKeystrokeRepository {
+	return &MockKeystrokeRepository{sessions: sessions}
+}
+
+func (m *MockKeystrokeRepository) Append(ctx context.Context, events []*domain.KeystrokeEvent) error {
+	m.events = append(m.events, events...)
+	return nil
+}
+
+func (m *MockKeystrokeRepository) ListBySessionID(ctx context.Context, sessionID domain.SessionID) ([]*domain.KeystrokeEvent, error) {
+	result := []*domain.KeystrokeEvent{}
+	for _, event := range m.events {
+		if event.SessionID == sessionID {
+			result = append(result, event)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LineIdx < result[j].LineIdx
+	})
+	return result, nil
+}
+
+func (m *MockKeystrokeRepository) ListByLine(ctx context.Context, sessionID domain.SessionID, lineIdx int) ([]*domain.KeystrokeEvent, error) {
+	result := []*domain.KeystrokeEvent{}
+	for _, event := range m.events {
+		if event.SessionID == sessionID && event.LineIdx == lineIdx {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockKeystrokeRepository) CountByLine(ctx context.Context, sessionID domain.SessionID, lineIdx int) (int, error) {
+	events, _ := m.ListByLine(ctx, sessionID, lineIdx)
+	return len(events), nil
+}
+
+func (m *MockKeystrokeRepository) ListByUserID(ctx context.Context, userID domain.UserID, from, to time.Time) ([]*domain.KeystrokeEvent, error) {
+	sessions, err := mockSessionsCreatedIn(ctx, m.sessions, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	result := []*domain.KeystrokeEvent{}
+	for _, session := range sessions {
+		events, _ := m.ListBySessionID(ctx, session.ID)
+		result = append(result, events...)
+	}
+	return result, nil
+}
+
+// MockLineResultRepository is a mock implementation of LineResultRepository for testing.
+type MockLineResultRepository struct {
+	results  []*domain.LineResult
+	sessions repository.SessionRepository
+}
+
+func NewMockLineResultRepository(sessions repository.SessionRepository) *MockLineResultRepository {
+	return &MockLineResultRepository{sessions: sessions}
+}
+
+func (m *MockLineResultRepository) Create(ctx context.Context, result *domain.LineResult) error {
+	for _, existing := range m.results {
+		if existing.SessionID == result.SessionID && existing.FragmentIdx == result.FragmentIdx && existing.LineIdx == result.LineIdx {
+			return fmt.Errorf("line result: %w", repository.ErrAlreadyExists)
+		}
+	}
+	m.results = append(m.results, result)
+	return nil
+}
+
+func (m *MockLineResultRepository) ListBySessionID(ctx context.Context, sessionID domain.SessionID) ([]*domain.LineResult, error) {
+	result := []*domain.LineResult{}
+	for _, line := range m.results {
+		if line.SessionID == sessionID {
+			result = append(result, line)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].FragmentIdx != result[j].FragmentIdx {
+			return result[i].FragmentIdx < result[j].FragmentIdx
+		}
+		return result[i].LineIdx < result[j].LineIdx
+	})
+	return result, nil
+}
+
+func (m *MockLineResultRepository) ListByUserID(ctx context.Context, userID domain.UserID, from, to time.Time) ([]*domain.LineResult, error) {
+	sessions, err := mockSessionsCreatedIn(ctx, m.sessions, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	result := []*domain.LineResult{}
+	for _, session := range sessions {
+		lines, _ := m.ListBySessionID(ctx, session.ID)
+		result = append(result, lines...)
+	}
+	return result, nil
+}
+
+// mockSessionsCreatedIn returns the user's sessions created in [from, to), oldest first.
+func mockSessionsCreatedIn(ctx context.Context, sessions repository.SessionRepository, userID domain.UserID, from, to time.Time) ([]*domain.Session, error) {
+	page, err := sessions.ListPage(ctx, repository.SessionQuery{UserID: userID, From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(page, func(i, j int) bool {
+		return repository.PositionOf(page[j]).Before(repository.PositionOf(page[i]))
+	})
+	return page, nil
+}
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"errors"
+	"typeten/internal/domain"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MinPasswordLength is the minimum number of bytes accepted for a new password.
+const MinPasswordLength = 8
+
+// PasswordHasher hashes passwords and verifies them against stored hashes.
+// Hashes must embed their own salt.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Compare returns domain.ErrInvalidCredentials if password does not match hash.
+	Compare(hash, password string) error
+}
+
+// BcryptPasswordHasher is a PasswordHasher using bcrypt, which salts every hash.
+type BcryptPasswordHasher struct {
+	cost int
+}
+
+// NewBcryptPasswordHasher creates a bcrypt hasher. A cost outside bcrypt's range uses bcrypt.DefaultCost.
+func NewBcryptPasswordHasher(cost int) *BcryptPasswordHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptPasswordHasher{cost: cost}
+}
+
+// Hash returns a salted bcrypt hash of password.
+// Returns domain.ErrWeakPassword if the password is too long for bcrypt.
+func (h *BcryptPasswordHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return "", domain.ErrWeakPassword
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Compare checks password against a bcrypt hash.
+func (h *BcryptPasswordHasher) Compare(hash, password string) error {
+	if hash == "" {
+		return domain.ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return domain.ErrInvalidCredentials
+	}
+	return nil
+}
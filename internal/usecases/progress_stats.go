@@ -0,0 +1,185 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// StatsPeriod is the bucket size of progress statistics.
+type StatsPeriod string
+
+const (
+	StatsPeriodDay  StatsPeriod = "day"
+	StatsPeriodWeek StatsPeriod = "week"
+)
+
+// MaxProgressBuckets caps the buckets of GetProgressStatsOutput, empty ones included.
+const MaxProgressBuckets = 366
+
+// GetProgressStatsUseCase handles aggregating a user's sessions into daily or weekly statistics.
+type GetProgressStatsUseCase struct {
+	sessionRepo repository.SessionRepository
+}
+
+// NewGetProgressStatsUseCase creates a new GetProgressStatsUseCase.
+func NewGetProgressStatsUseCase(sessionRepo repository.SessionRepository) *GetProgressStatsUseCase {
+	return &GetProgressStatsUseCase{
+		sessionRepo: sessionRepo,
+	}
+}
+
+// GetProgressStatsInput represents the input for progress statistics.
+// Period defaults to StatsPeriodDay. Sessions are bucketed by their creation time in
+// UTC; weeks start on Monday. Zero From/To leave the range unbounded; From is
+// inclusive, To exclusive.
+type GetProgressStatsInput struct {
+	UserID domain.UserID
+	Period StatsPeriod
+	From   time.Time
+	To     time.Time
+}
+
+// ProgressBucket aggregates the sessions started in one day or week.
+// AverageWPM and AccuracyPercent are weighted by completed lines; BestWPM is the
+// highest session average. PracticeTime sums the sessions' time spent typing lines.
+type ProgressBucket struct {
+	Start           time.Time
+	Sessions        int
+	Lines           int
+	AverageWPM      float64
+	BestWPM         float64
+	AccuracyPercent float64
+	PracticeTime    time.Duration
+}
+
+// GetProgressStatsOutput represents progress statistics.
+// Buckets are in chronological order and contiguous from the first to the last bucket
+// with a session; buckets without sessions have zero values. Total covers all of them.
+type GetProgressStatsOutput struct {
+	Period  StatsPeriod
+	Buckets []ProgressBucket
+	Total   ProgressBucket
+}
+
+// Execute aggregates the user's sessions with at least one completed line.
+// Returns domain.ErrInvalidQuery for an unknown period, an empty time range or
+// sessions spanning more than MaxProgressBuckets buckets.
+func (uc *GetProgressStatsUseCase) Execute(ctx context.Context, input GetProgressStatsInput) (*GetProgressStatsOutput, error) {
+	period := input.Period
+	if period == "" {
+		period = StatsPeriodDay
+	}
+	if period != StatsPeriodDay && period != StatsPeriodWeek {
+		return nil, domain.NewFieldError(domain.ErrInvalidQuery, "period", "must be day or week")
+	}
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return nil, domain.NewFieldError(domain.ErrInvalidQuery, "to", "must be after from")
+	}
+
+	sessions, err := uc.sessionRepo.ListByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	acc := map[time.Time]*bucketAccumulator{}
+	total := &bucketAccumulator{}
+	for _, session := range sessions {
+		if session.CompletedLines == 0 {
+			continue
+		}
+		if !input.From.IsZero() && session.CreatedAt.Before(input.From) {
+			continue
+		}
+		if !input.To.IsZero() && !session.CreatedAt.Before(input.To) {
+			continue
+		}
+		start := bucketStart(session.CreatedAt, period)
+		if acc[start] == nil {
+			acc[start] = &bucketAccumulator{}
+		}
+		acc[start].add(session)
+		total.add(session)
+	}
+
+	output := &GetProgressStatsOutput{Period: period, Buckets: []ProgressBucket{}}
+	if len(acc) == 0 {
+		return output, nil
+	}
+	starts := make([]time.Time, 0, len(acc))
+	for start := range acc {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+	for start := starts[0]; !start.After(starts[len(starts)-1]); start = nextBucket(start, period) {
+		if len(output.Buckets) == MaxProgressBuckets {
+			return nil, domain.NewFieldError(domain.ErrInvalidQuery, "from",
+				fmt.Sprintf("must be at most %d %ss before the last session", MaxProgressBuckets, period))
+		}
+		bucket := ProgressBucket{Start: start}
+		if a, ok := acc[start]; ok {
+			bucket = a.bucket(start)
+		}
+		output.Buckets = append(output.Buckets, bucket)
+	}
+	output.Total = total.bucket(starts[0])
+
+	return output, nil
+}
+
+// bucketAccumulator sums sessions of one bucket.
+type bucketAccumulator struct {
+	sessions    int
+	lines       int
+	wpmSum      float64 // session average WPM times its completed lines
+	accuracySum float64 // session accuracy times its completed lines
+	bestWPM     float64
+	practice    time.Duration
+}
+
+func (a *bucketAccumulator) add(session *domain.Session) {
+	a.sessions++
+	a.lines += session.CompletedLines
+	a.wpmSum += session.AverageWPM * float64(session.CompletedLines)
+	a.accuracySum += session.TotalAccuracyPercent * float64(session.CompletedLines)
+	if session.AverageWPM > a.bestWPM {
+		a.bestWPM = session.AverageWPM
+	}
+	a.practice += session.PracticeTime
+}
+
+func (a *bucketAccumulator) bucket(start time.Time) ProgressBucket {
+	bucket := ProgressBucket{
+		Start:        start,
+		Sessions:     a.sessions,
+		Lines:        a.lines,
+		BestWPM:      a.bestWPM,
+		PracticeTime: a.practice,
+	}
+	if a.lines > 0 {
+		bucket.AverageWPM = a.wpmSum / float64(a.lines)
+		bucket.AccuracyPercent = a.accuracySum / float64(a.lines)
+	}
+	return bucket
+}
+
+// bucketStart returns the start of the UTC day or ISO week (Monday) containing t.
+func bucketStart(t time.Time, period StatsPeriod) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if period == StatsPeriodWeek {
+		offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+		day = day.AddDate(0, 0, -offset)
+	}
+	return day
+}
+
+func nextBucket(start time.Time, period StatsPeriod) time.Time {
+	if period == StatsPeriodWeek {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
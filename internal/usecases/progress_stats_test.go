@@ -0,0 +1,128 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestGetProgressStatsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	// Wednesday
+	base := time.Date(2024, 3, 6, 9, 0, 0, 0, time.UTC)
+
+	sessionRepo := NewMockSessionRepository()
+	for _, spec := range []struct {
+		id        domain.SessionID
+		userID    domain.UserID
+		createdAt time.Time
+		lines     []float64 // WPM per line, each at 100% accuracy and 30s
+	}{
+		{id: "session_1", userID: "user_1", createdAt: base, lines: []float64{40, 40}},
+		{id: "session_2", userID: "user_1", createdAt: base.Add(2 * time.Hour), lines: []float64{70}},
+		{id: "session_3", userID: "user_1", createdAt: base.AddDate(0, 0, 2), lines: []float64{50}},
+		{id: "session_4", userID: "user_1", createdAt: base.AddDate(0, 0, 6), lines: []float64{60}},
+		{id: "session_5", userID: "user_1", createdAt: base.AddDate(0, 0, 1)},
+		{id: "session_6", userID: "user_2", createdAt: base, lines: []float64{90}},
+		{id: "session_7", userID: "user_4", createdAt: base, lines: []float64{40}},
+		{id: "session_8", userID: "user_4", createdAt: base.AddDate(2, 0, 0), lines: []float64{40}},
+	} {
+		session, err := domain.NewSession(spec.id, spec.userID, "text_1", spec.createdAt)
+		if err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		for _, wpm := range spec.lines {
+			if err := session.RecordLineCompleted(100, wpm, 0, 30*time.Second, spec.createdAt); err != nil {
+				t.Fatalf("RecordLineCompleted() error = %v", err)
+			}
+		}
+		if err := sessionRepo.Create(ctx, session); err != nil {
+			t.Fatalf("Failed to store session: %v", err)
+		}
+	}
+
+	useCase := NewGetProgressStatsUseCase(sessionRepo)
+
+	t.Run("too many buckets", func(t *testing.T) {
+		_, err := useCase.Execute(ctx, GetProgressStatsInput{UserID: "user_4"})
+		if !errors.Is(err, domain.ErrInvalidQuery) {
+			t.Errorf("Execute() daily over two years error = %v, want %v", err, domain.ErrInvalidQuery)
+		}
+		output, err := useCase.Execute(ctx, GetProgressStatsInput{UserID: "user_4", Period: StatsPeriodWeek})
+		if err != nil {
+			t.Fatalf("Execute() weekly error = %v", err)
+		}
+		if len(output.Buckets) > MaxProgressBuckets || output.Total.Sessions != 2 {
+			t.Errorf("Execute() weekly = %v buckets, %v sessions, want at most %v buckets, 2 sessions",
+				len(output.Buckets), output.Total.Sessions, MaxProgressBuckets)
+		}
+	})
+
+	t.Run("daily buckets", func(t *testing.T) {
+		output, err := useCase.Execute(ctx, GetProgressStatsInput{UserID: "user_1"})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if output.Period != StatsPeriodDay || len(output.Buckets) != 7 {
+			t.Fatalf("Execute() = %v buckets of %v, want 7 days", len(output.Buckets), output.Period)
+		}
+		first := output.Buckets[0]
+		if !first.Start.Equal(time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Execute() first bucket starts %v, want 2024-03-06", first.Start)
+		}
+		if first.Sessions != 2 || first.Lines != 3 || first.AverageWPM != 50 || first.BestWPM != 70 ||
+			first.PracticeTime != 90*time.Second || first.AccuracyPercent != 100 {
+			t.Errorf("Execute() first bucket = %+v, want 2 sessions, 3 lines, 50/70 WPM, 90s", first)
+		}
+		if empty := output.Buckets[1]; empty.Sessions != 0 || empty.Lines != 0 {
+			t.Errorf("Execute() bucket without completed lines = %+v, want empty", empty)
+		}
+		if output.Total.Sessions != 4 || output.Total.Lines != 5 || output.Total.BestWPM != 70 {
+			t.Errorf("Execute() Total = %+v, want 4 sessions, 5 lines, best 70", output.Total)
+		}
+	})
+
+	t.Run("weekly buckets start on Monday", func(t *testing.T) {
+		output, err := useCase.Execute(ctx, GetProgressStatsInput{UserID: "user_1", Period: StatsPeriodWeek})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if len(output.Buckets) != 2 {
+			t.Fatalf("Execute() = %v buckets, want 2 weeks", len(output.Buckets))
+		}
+		if !output.Buckets[0].Start.Equal(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Execute() first week starts %v, want Monday 2024-03-04", output.Buckets[0].Start)
+		}
+		if output.Buckets[0].Sessions != 3 || output.Buckets[1].Sessions != 1 {
+			t.Errorf("Execute() weekly sessions = %v, %v, want 3, 1", output.Buckets[0].Sessions, output.Buckets[1].Sessions)
+		}
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		output, err := useCase.Execute(ctx, GetProgressStatsInput{UserID: "user_1", From: base.AddDate(0, 0, 1), To: base.AddDate(0, 0, 3)})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if len(output.Buckets) != 1 || output.Buckets[0].Sessions != 1 {
+			t.Errorf("Execute() buckets = %+v, want only session_3", output.Buckets)
+		}
+	})
+
+	t.Run("no sessions", func(t *testing.T) {
+		output, err := useCase.Execute(ctx, GetProgressStatsInput{UserID: "user_3"})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if len(output.Buckets) != 0 || output.Total.Sessions != 0 {
+			t.Errorf("Execute() = %+v, want no buckets", output)
+		}
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		if _, err := useCase.Execute(ctx, GetProgressStatsInput{UserID: "user_1", Period: "month"}); !errors.Is(err, domain.ErrInvalidQuery) {
+			t.Errorf("Execute() error = %v, want %v", err, domain.ErrInvalidQuery)
+		}
+	})
+}
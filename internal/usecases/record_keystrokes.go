@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// MaxKeystrokesPerBatch bounds one RecordKeystrokes call; clients send one batch per line.
+const MaxKeystrokesPerBatch = 2000
+
+// MaxKeystrokesPerLine bounds the events stored for one line over all its batches.
+const MaxKeystrokesPerLine = 5000
+
+// RecordKeystrokesUseCase handles storing raw keystroke events captured while typing a line.
+type RecordKeystrokesUseCase struct {
+	sessionRepo   repository.SessionRepository
+	keystrokeRepo repository.KeystrokeRepository
+}
+
+// NewRecordKeystrokesUseCase creates a new RecordKeystrokesUseCase.
+func NewRecordKeystrokesUseCase(sessionRepo repository.SessionRepository, keystrokeRepo repository.KeystrokeRepository) *RecordKeystrokesUseCase {
+	return &RecordKeystrokesUseCase{
+		sessionRepo:   sessionRepo,
+		keystrokeRepo: keystrokeRepo,
+	}
+}
+
+// KeystrokeInput is one captured key press. See domain.KeystrokeEvent for the fields.
+type KeystrokeInput struct {
+	Key          string
+	Expected     string
+	OffsetMs     int64
+	IsCorrection bool
+}
+
+// RecordKeystrokesInput represents the input for recording keystrokes of one line.
+// LineIdx is the zero-based line index into the whole text.
+type RecordKeystrokesInput struct {
+	UserID    domain.UserID
+	SessionID domain.SessionID
+	LineIdx   int
+	Events    []KeystrokeInput
+}
+
+// RecordKeystrokesOutput represents the result of recording keystrokes.
+type RecordKeystrokesOutput struct {
+	Recorded int
+}
+
+// Execute validates and stores a batch of keystroke events for the session's current
+// line. The batch is stored entirely or not at all; an invalid event is reported with
+// its index, e.g. "events[3].key". A long line may be sent in several batches.
+// Returns domain.ErrForbidden if the session belongs to another user,
+// domain.ErrInvalidSessionOp if the session is completed or abandoned and
+// domain.ErrInvalidKeystroke if the batch is empty, too large, for another line or
+// would take the line over MaxKeystrokesPerLine.
+func (uc *RecordKeystrokesUseCase) Execute(ctx context.Context, input RecordKeystrokesInput) (*RecordKeystrokesOutput, error) {
+	if len(input.Events) == 0 {
+		return nil, domain.NewFieldError(domain.ErrInvalidKeystroke, "events", "must not be empty")
+	}
+	if len(input.Events) > MaxKeystrokesPerBatch {
+		return nil, domain.NewFieldError(domain.ErrInvalidKeystroke, "events",
+			fmt.Sprintf("must contain at most %d events", MaxKeystrokesPerBatch))
+	}
+
+	session, err := getOwnedSession(ctx, uc.sessionRepo, input.SessionID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsOpen() {
+		return nil, domain.ErrInvalidSessionOp
+	}
+	// Finished lines are closed: their keystrokes were sent before their progress.
+	if input.LineIdx != session.CurrentLine() {
+		return nil, domain.NewFieldError(domain.ErrInvalidKeystroke, "line_idx",
+			fmt.Sprintf("must be the current line %d", session.CurrentLine()))
+	}
+
+	stored, err := uc.keystrokeRepo.CountByLine(ctx, session.ID, input.LineIdx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count keystroke events: %w", err)
+	}
+	if stored+len(input.Events) > MaxKeystrokesPerLine {
+		return nil, domain.NewFieldError(domain.ErrInvalidKeystroke, "events",
+			fmt.Sprintf("must bring the line to at most %d events, it has %d", MaxKeystrokesPerLine, stored))
+	}
+
+	now := time.Now()
+	events := make([]*domain.KeystrokeEvent, len(input.Events))
+	for i, in := range input.Events {
+		event, err := domain.NewKeystrokeEvent(session.ID, input.LineIdx, in.Key, in.Expected, in.OffsetMs, in.IsCorrection, now)
+		var fieldErr *domain.FieldError
+		if errors.As(err, &fieldErr) && fieldErr.Field != "line_idx" {
+			return nil, domain.NewFieldError(fieldErr.Err, fmt.Sprintf("events[%d].%s", i, fieldErr.Field), fieldErr.Message)
+		}
+		if err != nil {
+			return nil, err
+		}
+		events[i] = event
+	}
+
+	if err := uc.keystrokeRepo.Append(ctx, events); err != nil {
+		return nil, fmt.Errorf("failed to store keystroke events: %w", err)
+	}
+
+	return &RecordKeystrokesOutput{Recorded: len(events)}, nil
+}
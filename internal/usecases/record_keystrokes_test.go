@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+func TestRecordKeystrokesUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	sessionRepo := NewMockSessionRepository()
+	session, err := domain.NewSession("session_1", "user_1", "text_1", now)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := session.SetLayout(3, 2); err != nil {
+		t.Fatalf("SetLayout() error = %v", err)
+	}
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	advanced, _ := domain.NewSession("session_2", "user_1", "text_1", now)
+	advanced.SetLayout(3, 2)
+	if err := advanced.RecordLineCompleted(100, 40, 1, time.Second, now); err != nil {
+		t.Fatalf("RecordLineCompleted() error = %v", err)
+	}
+	done, _ := domain.NewSession("session_done", "user_1", "text_1", now)
+	done.SetLayout(3, 2)
+	done.MarkCompleted(now)
+	for _, s := range []*domain.Session{advanced, done} {
+		if err := sessionRepo.Create(ctx, s); err != nil {
+			t.Fatalf("Failed to store session: %v", err)
+		}
+	}
+
+	validEvents := []KeystrokeInput{
+		{Key: "h", Expected: "h", OffsetMs: 0},
+		{Key: "x", Expected: "i", OffsetMs: 140},
+		{Key: "Backspace", Expected: "i", OffsetMs: 300, IsCorrection: true},
+		{Key: "i", Expected: "i", OffsetMs: 420},
+	}
+	tooMany := make([]KeystrokeInput, MaxKeystrokesPerBatch+1)
+	for i := range tooMany {
+		tooMany[i] = KeystrokeInput{Key: "a"}
+	}
+
+	tests := []struct {
+		name      string
+		input     RecordKeystrokesInput
+		wantErr   error
+		wantField string
+	}{
+		{
+			name:  "valid batch",
+			input: RecordKeystrokesInput{UserID: "user_1", SessionID: "session_1", LineIdx: 0, Events: validEvents},
+		},
+		{
+			name:  "current line of an advanced session",
+			input: RecordKeystrokesInput{UserID: "user_1", SessionID: "session_2", LineIdx: 1, Events: validEvents},
+		},
+		{
+			name:      "finished line",
+			input:     RecordKeystrokesInput{UserID: "user_1", SessionID: "session_2", LineIdx: 0, Events: validEvents},
+			wantErr:   domain.ErrInvalidKeystroke,
+			wantField: "line_idx",
+		},
+		{
+			name:      "line ahead of the cursor",
+			input:     RecordKeystrokesInput{UserID: "user_1", SessionID: "session_1", LineIdx: 1, Events: validEvents},
+			wantErr:   domain.ErrInvalidKeystroke,
+			wantField: "line_idx",
+		},
+		{
+			name:    "completed session",
+			input:   RecordKeystrokesInput{UserID: "user_1", SessionID: "session_done", LineIdx: 0, Events: validEvents},
+			wantErr: domain.ErrInvalidSessionOp,
+		},
+		{
+			name:      "empty batch",
+			input:     RecordKeystrokesInput{UserID: "user_1", SessionID: "session_1", LineIdx: 0},
+			wantErr:   domain.ErrInvalidKeystroke,
+			wantField: "events",
+		},
+		{
+			name:      "batch too large",
+			input:     RecordKeystrokesInput{UserID: "user_1", SessionID: "session_1", LineIdx: 0, Events: tooMany},
+			wantErr:   domain.ErrInvalidKeystroke,
+			wantField: "events",
+		},
+		{
+			name:      "line past the end",
+			input:     RecordKeystrokesInput{UserID: "user_1", SessionID: "session_1", LineIdx: 3, Events: validEvents},
+			wantErr:   domain.ErrInvalidKeystroke,
+			wantField: "line_idx",
+		},
+		{
+			name:      "negative line",
+			input:     RecordKeystrokesInput{UserID: "user_1", SessionID: "session_1", LineIdx: -1, Events: validEvents},
+			wantErr:   domain.ErrInvalidKeystroke,
+			wantField: "line_idx",
+		},
+		{
+			name: "invalid event",
+			input: RecordKeystrokesInput{UserID: "user_1", SessionID: "session_1", LineIdx: 0, Events: []KeystrokeInput{
+				{Key: "a"}, {Key: "b", OffsetMs: -1},
+			}},
+			wantErr:   domain.ErrInvalidKeystroke,
+			wantField: "events[1].offset_ms",
+		},
+		{
+			name:    "other user's session",
+			input:   RecordKeystrokesInput{UserID: "user_2", SessionID: "session_1", LineIdx: 0, Events: validEvents},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name:    "non-existent session",
+			input:   RecordKeystrokesInput{UserID: "user_1", SessionID: "nonexistent", LineIdx: 0, Events: validEvents},
+			wantErr: repository.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keystrokeRepo := NewMockKeystrokeRepository(sessionRepo)
+			useCase := NewRecordKeystrokesUseCase(sessionRepo, keystrokeRepo)
+
+			output, err := useCase.Execute(ctx, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantField != "" {
+				var fieldErr *domain.FieldError
+				if !errors.As(err, &fieldErr) || fieldErr.Field != tt.wantField {
+					t.Errorf("Execute() error = %v, want field %q", err, tt.wantField)
+				}
+			}
+
+			stored, _ := keystrokeRepo.ListByLine(ctx, tt.input.SessionID, tt.input.LineIdx)
+			if tt.wantErr != nil {
+				if len(stored) != 0 {
+					t.Errorf("Execute() stored %v events on error, want 0", len(stored))
+				}
+				return
+			}
+			if output.Recorded != len(tt.input.Events) || len(stored) != len(tt.input.Events) {
+				t.Errorf("Execute() Recorded = %v, stored %v, want %v", output.Recorded, len(stored), len(tt.input.Events))
+			}
+		})
+	}
+}
+
+func TestRecordKeystrokesUseCase_LineLimit(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := NewMockSessionRepository()
+	session, _ := domain.NewSession("session_1", "user_1", "text_1", time.Now())
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+	keystrokeRepo := NewMockKeystrokeRepository(sessionRepo)
+	useCase := NewRecordKeystrokesUseCase(sessionRepo, keystrokeRepo)
+
+	batch := make([]KeystrokeInput, MaxKeystrokesPerBatch)
+	for i := range batch {
+		batch[i] = KeystrokeInput{Key: "a", Expected: "a", OffsetMs: int64(i)}
+	}
+	input := RecordKeystrokesInput{UserID: "user_1", SessionID: "session_1", LineIdx: 0, Events: batch}
+	for sent := 0; sent+len(batch) <= MaxKeystrokesPerLine; sent += len(batch) {
+		if _, err := useCase.Execute(ctx, input); err != nil {
+			t.Fatalf("Execute() after %d events error = %v", sent, err)
+		}
+	}
+
+	_, err := useCase.Execute(ctx, input)
+	var fieldErr *domain.FieldError
+	if !errors.As(err, &fieldErr) || !errors.Is(err, domain.ErrInvalidKeystroke) || fieldErr.Field != "events" {
+		t.Errorf("Execute() over the line limit error = %v, want %v on events", err, domain.ErrInvalidKeystroke)
+	}
+	if stored, _ := keystrokeRepo.CountByLine(ctx, "session_1", 0); stored > MaxKeystrokesPerLine {
+		t.Errorf("stored %v events, want at most %v", stored, MaxKeystrokesPerLine)
+	}
+}
@@ -2,47 +2,112 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 	"typeten/internal/domain"
 	"typeten/internal/repository"
+	"unicode/utf8"
 )
 
 // RecordProgressUseCase handles recording typing progress for a session.
 type RecordProgressUseCase struct {
 	sessionRepo repository.SessionRepository
+	textRepo    repository.TextRepository
+	lineRepo    repository.LineResultRepository
+	scorer      *LineScorer
 }
 
 // NewRecordProgressUseCase creates a new RecordProgressUseCase.
-func NewRecordProgressUseCase(sessionRepo repository.SessionRepository) *RecordProgressUseCase {
+func NewRecordProgressUseCase(sessionRepo repository.SessionRepository, textRepo repository.TextRepository, lineRepo repository.LineResultRepository) *RecordProgressUseCase {
 	return &RecordProgressUseCase{
 		sessionRepo: sessionRepo,
+		textRepo:    textRepo,
+		lineRepo:    lineRepo,
+		scorer:      NewLineScorer(),
 	}
 }
 
 // RecordProgressInput represents the input for recording progress.
+// Typed is what the user typed for the current line and Elapsed how long it took;
+// accuracy and speed are computed from them on the server.
 type RecordProgressInput struct {
-	SessionID       string
-	AccuracyPercent float64
-	WPM             float64
+	UserID    domain.UserID
+	SessionID string
+	Typed     string
+	Elapsed   time.Duration
 }
 
 // RecordProgressOutput represents the result of recording progress.
+// Score is the result for the line just completed and Line its stored record.
 type RecordProgressOutput struct {
 	Session *domain.Session
+	Score   LineScore
+	Line    *domain.LineResult
 }
 
-// Execute records a completed line and updates session statistics.
+// Execute scores the typed line against the session's current line, stores a
+// domain.LineResult for it, advances the session cursor and updates session statistics.
+// If a result for the line is already stored, that result is replayed instead of the
+// typed line. The session's average WPM is the net WPM. The session completes itself
+// after the text's last line or once its mode's limit is reached. A line that runs out
+// the time of a minutes session is scored against the part of the line the user got
+// to, so stopping mid-line is not counted as errors.
+// Returns domain.ErrForbidden if the session belongs to another user and
+// domain.ErrInvalidSessionOp if the session is not active (paused, completed or abandoned),
+// has no lines left or the elapsed time is invalid.
 func (uc *RecordProgressUseCase) Execute(ctx context.Context, input RecordProgressInput) (*RecordProgressOutput, error) {
+	if input.Elapsed < 0 || (input.Elapsed == 0 && input.Typed != "") {
+		return nil, domain.NewFieldError(domain.ErrInvalidSessionOp, "elapsed_ms", "must be positive")
+	}
+
 	// Get session
-	session, err := uc.sessionRepo.GetByID(ctx, domain.SessionID(input.SessionID))
+	session, err := getOwnedSession(ctx, uc.sessionRepo, domain.SessionID(input.SessionID), input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Sessions created before layout tracking learn it from their text
+	if !session.HasLayout() {
+		text, err := uc.textRepo.GetTextInfo(ctx, session.TextID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get text: %w", err)
+		}
+		if err := session.SetLayout(text.TotalLines, text.FragmentSize); err != nil {
+			return nil, fmt.Errorf("failed to set session layout: %w", err)
+		}
+	}
+	if session.Status != domain.SessionActive {
+		return nil, domain.ErrInvalidSessionOp
+	}
+	
+	// Score the line against the text
+	line, err := uc.currentLine(ctx, session)
 	if err != nil {
-		return nil, fmt.Errorf("session not found: %w", err)
+		return nil, err
 	}
+	expected, score := uc.score(session, line, input.Typed, input.Elapsed)
 	
-	// Record line completion
+	// Record line completion. The line result is stored before the session moves on,
+	// so a failure in between leaves the session on this line; the retry finds the
+	// stored result and replays it instead of scoring the line twice.
 	now := time.Now()
-	if err := session.RecordLineCompleted(input.AccuracyPercent, input.WPM, now); err != nil {
+	result, err := domain.NewLineResult(session.ID, session.CurrentFragmentIdx, session.CurrentLineIdx,
+		input.Typed, score.AccuracyPercent, score.NetWPM, input.Elapsed, score.Errors, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create line result: %w", err)
+	}
+	if err := uc.lineRepo.Create(ctx, result); errors.Is(err, repository.ErrAlreadyExists) {
+		if result, err = uc.storedLine(ctx, session); err != nil {
+			return nil, err
+		}
+		expected, score = uc.score(session, line, result.Typed, result.Duration)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to store line result: %w", err)
+	}
+	words := len(strings.Fields(expected))
+	if err := session.RecordLineCompleted(score.AccuracyPercent, score.NetWPM, words, result.Duration, now); err != nil {
 		return nil, fmt.Errorf("failed to record progress: %w", err)
 	}
 	
@@ -51,5 +116,57 @@ func (uc *RecordProgressUseCase) Execute(ctx context.Context, input RecordProgre
 		return nil, fmt.Errorf("failed to update session: %w", err)
 	}
 	
-	return &RecordProgressOutput{Session: session}, nil
+	return &RecordProgressOutput{Session: session, Score: score, Line: result}, nil
+}
+
+// score scores typed against line, the session's current line. A line that runs out
+// the time of a minutes session is cut to the length of typed first; score returns
+// the text it scored against.
+func (uc *RecordProgressUseCase) score(session *domain.Session, line, typed string, elapsed time.Duration) (string, LineScore) {
+	if session.Mode.Kind == domain.SessionModeMinutes && elapsed >= session.RemainingTime() {
+		line = truncateRunes(line, utf8.RuneCountInString(typed))
+	}
+	return line, uc.scorer.Score(line, typed, elapsed)
+}
+
+// storedLine returns the line result already stored for the session's current line.
+func (uc *RecordProgressUseCase) storedLine(ctx context.Context, session *domain.Session) (*domain.LineResult, error) {
+	results, err := uc.lineRepo.ListBySessionID(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list line results: %w", err)
+	}
+	for _, result := range results {
+		if result.FragmentIdx == session.CurrentFragmentIdx && result.LineIdx == session.CurrentLineIdx {
+			return result, nil
+		}
+	}
+	return nil, fmt.Errorf("line result for line %d: %w", session.CurrentLine(), repository.ErrNotFound)
+}
+
+// currentLine returns the text of the line under the session cursor.
+func (uc *RecordProgressUseCase) currentLine(ctx context.Context, session *domain.Session) (string, error) {
+	fragments, err := uc.textRepo.GetFragmentsByTextID(ctx, session.TextID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get fragments: %w", err)
+	}
+	for _, fragment := range fragments {
+		if fragment.FragmentIdx != session.CurrentFragmentIdx {
+			continue
+		}
+		lines := fragment.Lines()
+		if session.CurrentLineIdx >= len(lines) {
+			break
+		}
+		return lines[session.CurrentLineIdx], nil
+	}
+	return "", fmt.Errorf("line %d of text %s: %w", session.CurrentLine(), session.TextID, repository.ErrNotFound)
+}
+
+// truncateRunes returns the first n runes of s.
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if n >= len(runes) {
+		return s
+	}
+	return string(runes[:n])
 }
@@ -2,6 +2,8 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 	"typeten/internal/domain"
@@ -11,6 +13,16 @@ func TestRecordProgressUseCase_Execute(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
 
+	textRepo := NewMockTextRepository()
+	text, err := domain.NewTextInfo("text_1", "user_1", "Test Text", 3, 2, 2, now)
+	if err != nil {
+		t.Fatalf("Failed to create text info: %v", err)
+	}
+	if err := textRepo.CreateTextInfo(ctx, text); err != nil {
+		t.Fatalf("Failed to store text info: %v", err)
+	}
+	storeFragments(t, textRepo, "text_1", [][]string{{"line1", "line2"}, {"line3"}})
+
 	sessionRepo := NewMockSessionRepository()
 	session, err := domain.NewSession("session_1", "user_1", "text_1", now)
 	if err != nil {
@@ -20,7 +32,7 @@ func TestRecordProgressUseCase_Execute(t *testing.T) {
 		t.Fatalf("Failed to store session: %v", err)
 	}
 
-	useCase := NewRecordProgressUseCase(sessionRepo)
+	useCase := NewRecordProgressUseCase(sessionRepo, textRepo, NewMockLineResultRepository(sessionRepo))
 
 	tests := []struct {
 		name    string
@@ -30,36 +42,49 @@ func TestRecordProgressUseCase_Execute(t *testing.T) {
 		{
 			name: "valid progress",
 			input: RecordProgressInput{
-				SessionID:       "session_1",
-				AccuracyPercent: 95.5,
-				WPM:             45.2,
+				UserID:    "user_1",
+				SessionID: "session_1",
+				Typed:     "line1",
+				Elapsed:   2 * time.Second,
 			},
 			wantErr: false,
 		},
+		{
+			name: "other user's session",
+			input: RecordProgressInput{
+				UserID:    "user_2",
+				SessionID: "session_1",
+				Typed:     "line1",
+				Elapsed:   2 * time.Second,
+			},
+			wantErr: true,
+		},
 		{
 			name: "non-existent session",
 			input: RecordProgressInput{
-				SessionID:       "nonexistent",
-				AccuracyPercent: 95.0,
-				WPM:             45.0,
+				UserID:    "user_1",
+				SessionID: "nonexistent",
+				Typed:     "line1",
+				Elapsed:   2 * time.Second,
 			},
 			wantErr: true,
 		},
 		{
-			name: "invalid accuracy",
+			name: "typed without elapsed time",
 			input: RecordProgressInput{
-				SessionID:       "session_1",
-				AccuracyPercent: 150.0,
-				WPM:             45.0,
+				UserID:    "user_1",
+				SessionID: "session_1",
+				Typed:     "line1",
 			},
 			wantErr: true,
 		},
 		{
-			name: "negative WPM",
+			name: "negative elapsed time",
 			input: RecordProgressInput{
-				SessionID:       "session_1",
-				AccuracyPercent: 95.0,
-				WPM:             -10.0,
+				UserID:    "user_1",
+				SessionID: "session_1",
+				Typed:     "line1",
+				Elapsed:   -time.Second,
 			},
 			wantErr: true,
 		},
@@ -76,7 +101,7 @@ func TestRecordProgressUseCase_Execute(t *testing.T) {
 			if err := sessionRepo.Create(ctx, session); err != nil {
 				t.Fatalf("Failed to store session: %v", err)
 			}
-			useCase = NewRecordProgressUseCase(sessionRepo)
+			useCase = NewRecordProgressUseCase(sessionRepo, textRepo, NewMockLineResultRepository(sessionRepo))
 
 			output, err := useCase.Execute(ctx, tt.input)
 			if (err != nil) != tt.wantErr {
@@ -93,7 +118,272 @@ func TestRecordProgressUseCase_Execute(t *testing.T) {
 				if output.Session.CompletedLines != 1 {
 					t.Errorf("Execute() CompletedLines = %v, want 1", output.Session.CompletedLines)
 				}
+				if output.Score.AccuracyPercent != 100 || output.Score.GrossWPM != 30 {
+					t.Errorf("Execute() Score = %+v, want 100%% accuracy at 30 WPM", output.Score)
+				}
 			}
 		})
 	}
 }
+
+func TestRecordProgressUseCase_Execute_AdvancesToCompletion(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	textRepo := NewMockTextRepository()
+	text, err := domain.NewTextInfo("text_1", "user_1", "Test Text", 3, 2, 2, now)
+	if err != nil {
+		t.Fatalf("Failed to create text info: %v", err)
+	}
+	if err := textRepo.CreateTextInfo(ctx, text); err != nil {
+		t.Fatalf("Failed to store text info: %v", err)
+	}
+	storeFragments(t, textRepo, "text_1", [][]string{{"line1", "line2"}, {"line3"}})
+
+	// A session stored without a layout picks it up from its text
+	sessionRepo := NewMockSessionRepository()
+	session, err := domain.NewSession("session_1", "user_1", "text_1", now)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	useCase := NewRecordProgressUseCase(sessionRepo, textRepo, NewMockLineResultRepository(sessionRepo))
+	typed := []string{"line1", "line2", "line3"}
+
+	wantCursors := [][2]int{{0, 1}, {1, 0}, {1, 0}}
+	for i, want := range wantCursors {
+		input := RecordProgressInput{UserID: "user_1", SessionID: "session_1", Typed: typed[i], Elapsed: time.Second}
+		output, err := useCase.Execute(ctx, input)
+		if err != nil {
+			t.Fatalf("Execute() line %d error = %v", i, err)
+		}
+		got := output.Session
+		if got.CurrentFragmentIdx != want[0] || got.CurrentLineIdx != want[1] {
+			t.Errorf("Execute() line %d cursor = (%v, %v), want (%v, %v)",
+				i, got.CurrentFragmentIdx, got.CurrentLineIdx, want[0], want[1])
+		}
+		if wantDone := i == len(wantCursors)-1; got.IsCompleted() != wantDone {
+			t.Errorf("Execute() line %d IsCompleted = %v, want %v", i, got.IsCompleted(), wantDone)
+		}
+	}
+
+	stored, err := sessionRepo.GetByID(ctx, "session_1")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !stored.IsCompleted() || stored.TotalLines != 3 || stored.FragmentSize != 2 {
+		t.Errorf("stored session = %+v, want completed with layout 3/2", stored)
+	}
+
+	input := RecordProgressInput{UserID: "user_1", SessionID: "session_1", Typed: "line4", Elapsed: time.Second}
+	if _, err := useCase.Execute(ctx, input); !errors.Is(err, domain.ErrInvalidSessionOp) {
+		t.Errorf("Execute() past the end error = %v, wantErr %v", err, domain.ErrInvalidSessionOp)
+	}
+}
+
+func TestRecordProgressUseCase_Execute_ScoresAgainstCurrentLine(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	textRepo := NewMockTextRepository()
+	text, err := domain.NewTextInfo("text_1", "user_1", "Test Text", 2, 1, 2, now)
+	if err != nil {
+		t.Fatalf("Failed to create text info: %v", err)
+	}
+	if err := textRepo.CreateTextInfo(ctx, text); err != nil {
+		t.Fatalf("Failed to store text info: %v", err)
+	}
+	storeFragments(t, textRepo, "text_1", [][]string{{"hello"}, {"world"}})
+
+	sessionRepo := NewMockSessionRepository()
+	session, err := domain.NewSession("session_1", "user_1", "text_1", now)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	lineRepo := NewMockLineResultRepository(sessionRepo)
+	useCase := NewRecordProgressUseCase(sessionRepo, textRepo, lineRepo)
+
+	tests := []struct {
+		typed        string
+		wantAccuracy float64
+		wantErrors   int
+	}{
+		{typed: "hello", wantAccuracy: 100, wantErrors: 0},
+		{typed: "wrld", wantAccuracy: 80, wantErrors: 1},
+	}
+	for _, tt := range tests {
+		output, err := useCase.Execute(ctx, RecordProgressInput{
+			UserID:    "user_1",
+			SessionID: "session_1",
+			Typed:     tt.typed,
+			Elapsed:   time.Minute,
+		})
+		if err != nil {
+			t.Fatalf("Execute(%q) error = %v", tt.typed, err)
+		}
+		if output.Score.AccuracyPercent != tt.wantAccuracy || output.Score.Errors != tt.wantErrors {
+			t.Errorf("Execute(%q) Score = %+v, want accuracy %v with %d errors",
+				tt.typed, output.Score, tt.wantAccuracy, tt.wantErrors)
+		}
+	}
+
+	stored, err := sessionRepo.GetByID(ctx, "session_1")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if stored.TotalAccuracyPercent != 90 {
+		t.Errorf("stored TotalAccuracyPercent = %v, want 90", stored.TotalAccuracyPercent)
+	}
+
+	lines, err := lineRepo.ListBySessionID(ctx, "session_1")
+	if err != nil {
+		t.Fatalf("ListBySessionID() error = %v", err)
+	}
+	if len(lines) != len(tests) {
+		t.Fatalf("stored line results = %v, want %d", len(lines), len(tests))
+	}
+	if got := lines[1]; got.FragmentIdx != 1 || got.LineIdx != 0 || got.Typed != "wrld" || got.Errors != 1 || got.Duration != time.Minute {
+		t.Errorf("stored line result = %+v, want fragment 1 line 0 typed \"wrld\" with 1 error", got)
+	}
+}
+
+// storeFragments stores one fragment per entry of lines for the text.
+func storeFragments(t *testing.T, textRepo *MockTextRepository, textID domain.TextID, lines [][]string) {
+	t.Helper()
+	for i, fragmentLines := range lines {
+		id := domain.TextFragmentID(fmt.Sprintf("%s_frag_%d", textID, i))
+		fragment, err := domain.NewTextFragment(id, textID, i, fragmentLines)
+		if err != nil {
+			t.Fatalf("Failed to create fragment: %v", err)
+		}
+		if err := textRepo.CreateFragment(context.Background(), fragment); err != nil {
+			t.Fatalf("Failed to store fragment: %v", err)
+		}
+	}
+}
+
+func TestRecordProgressUseCase_Execute_TimeLimit(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	textRepo := NewMockTextRepository()
+	text, err := domain.NewTextInfo("text_1", "user_1", "Test Text", 3, 5, 1, now)
+	if err != nil {
+		t.Fatalf("Failed to create text info: %v", err)
+	}
+	if err := textRepo.CreateTextInfo(ctx, text); err != nil {
+		t.Fatalf("Failed to store text info: %v", err)
+	}
+	storeFragments(t, textRepo, "text_1", [][]string{{"one two", "three four five", "six"}})
+
+	sessionRepo := NewMockSessionRepository()
+	session, err := domain.NewSession("session_1", "user_1", "text_1", now)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := session.SetLayout(text.TotalLines, text.FragmentSize); err != nil {
+		t.Fatalf("SetLayout() error = %v", err)
+	}
+	if err := session.SetMode(domain.SessionMode{Kind: domain.SessionModeMinutes, Limit: 1}); err != nil {
+		t.Fatalf("SetMode() error = %v", err)
+	}
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	useCase := NewRecordProgressUseCase(sessionRepo, textRepo, NewMockLineResultRepository(sessionRepo))
+	record := func(typed string, elapsed time.Duration) (*RecordProgressOutput, error) {
+		return useCase.Execute(ctx, RecordProgressInput{
+			UserID:    "user_1",
+			SessionID: "session_1",
+			Typed:     typed,
+			Elapsed:   elapsed,
+		})
+	}
+
+	output, err := record("one two", 40*time.Second)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if output.Session.IsCompleted() || output.Session.CompletedWords != 2 {
+		t.Errorf("Execute() Session = %+v, want 2 words and not completed", output.Session)
+	}
+
+	// The time runs out mid-line: only the part typed so far is scored.
+	output, err = record("three fo", 20*time.Second)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if output.Score.Errors != 0 || output.Score.AccuracyPercent != 100 {
+		t.Errorf("Execute() Score = %+v, want no errors for the cut-off line", output.Score)
+	}
+	if !output.Session.IsCompleted() || output.Session.PracticeTime != time.Minute {
+		t.Errorf("Execute() Session = %+v, want completed after a minute", output.Session)
+	}
+
+	if _, err := record("six", time.Second); !errors.Is(err, domain.ErrInvalidSessionOp) {
+		t.Errorf("Execute() after the limit error = %v, want %v", err, domain.ErrInvalidSessionOp)
+	}
+}
+
+// failingUpdateSessionRepository fails the next Update, as if the process died
+// between storing a line result and saving the session.
+type failingUpdateSessionRepository struct {
+	*MockSessionRepository
+	fail *bool
+}
+
+func (r failingUpdateSessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	if *r.fail {
+		*r.fail = false
+		return errors.New("connection lost")
+	}
+	return r.MockSessionRepository.Update(ctx, session)
+}
+
+func TestRecordProgressUseCase_Execute_ReplaysStoredLine(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	textRepo := NewMockTextRepository()
+	text, _ := domain.NewTextInfo("text_1", "user_1", "Test Text", 2, 2, 1, now)
+	textRepo.CreateTextInfo(ctx, text)
+	storeFragments(t, textRepo, "text_1", [][]string{{"line1", "line2"}})
+
+	mockSessions := NewMockSessionRepository()
+	session, _ := domain.NewSession("session_1", "user_1", "text_1", now)
+	mockSessions.Create(ctx, session)
+
+	fail := true
+	lineRepo := NewMockLineResultRepository(mockSessions)
+	useCase := NewRecordProgressUseCase(failingUpdateSessionRepository{mockSessions, &fail}, textRepo, lineRepo)
+
+	first := RecordProgressInput{UserID: "user_1", SessionID: "session_1", Typed: "lime1", Elapsed: 2 * time.Second}
+	if _, err := useCase.Execute(ctx, first); err == nil {
+		t.Fatal("Execute() expected error when the session update fails")
+	}
+	if got, _ := mockSessions.GetByID(ctx, session.ID); got.CompletedLines != 0 {
+		t.Fatalf("CompletedLines after failed update = %v, want 0", got.CompletedLines)
+	}
+
+	// The retry types the line differently, but the stored result wins.
+	retry := RecordProgressInput{UserID: "user_1", SessionID: "session_1", Typed: "line1", Elapsed: time.Second}
+	output, err := useCase.Execute(ctx, retry)
+	if err != nil {
+		t.Fatalf("Execute() retry error = %v", err)
+	}
+	if output.Session.CompletedLines != 1 || output.Line.Typed != "lime1" || output.Score.Errors != 1 {
+		t.Errorf("Execute() retry CompletedLines = %v, Typed = %q, Errors = %v, want 1, \"lime1\", 1",
+			output.Session.CompletedLines, output.Line.Typed, output.Score.Errors)
+	}
+	if lines, _ := lineRepo.ListBySessionID(ctx, session.ID); len(lines) != 1 {
+		t.Errorf("ListBySessionID() = %v line results, want 1", len(lines))
+	}
+}
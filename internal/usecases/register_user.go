@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// RegisterUserUseCase handles signing up a new account with a password.
+type RegisterUserUseCase struct {
+	userRepo repository.UserRepository
+	hasher   PasswordHasher
+}
+
+// NewRegisterUserUseCase creates a new RegisterUserUseCase.
+func NewRegisterUserUseCase(userRepo repository.UserRepository, hasher PasswordHasher) *RegisterUserUseCase {
+	return &RegisterUserUseCase{
+		userRepo: userRepo,
+		hasher:   hasher,
+	}
+}
+
+// RegisterUserInput represents the input for registering a user.
+type RegisterUserInput struct {
+	Email    string
+	Username string
+	Password string
+}
+
+// RegisterUserOutput represents the result of registering a user.
+type RegisterUserOutput struct {
+	User *domain.User
+}
+
+// Execute validates the input, hashes the password and stores the new user.
+// Returns domain.ErrWeakPassword, domain.ErrEmailTaken or domain.ErrInvalidUser on invalid input.
+func (uc *RegisterUserUseCase) Execute(ctx context.Context, input RegisterUserInput) (*RegisterUserOutput, error) {
+	if len(input.Password) < MinPasswordLength {
+		return nil, domain.NewFieldError(domain.ErrWeakPassword, "password",
+			fmt.Sprintf("must be at least %d characters", MinPasswordLength))
+	}
+
+	email := normalizeEmail(input.Email)
+	_, err := uc.userRepo.GetByEmail(ctx, email)
+	if err == nil {
+		return nil, domain.ErrEmailTaken
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+
+	userID := domain.UserID(fmt.Sprintf("user_%d", time.Now().UnixNano()))
+	user, err := domain.NewUser(userID, email, input.Username, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := uc.hasher.Hash(input.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.PasswordHash = hash
+
+	// A concurrent registration with the same email is rejected by the repository,
+	// which keeps emails unique.
+	if err := uc.userRepo.Create(ctx, user); errors.Is(err, repository.ErrAlreadyExists) {
+		return nil, domain.ErrEmailTaken
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return &RegisterUserOutput{User: user}, nil
+}
+
+// normalizeEmail trims and lower-cases an email so lookups are case-insensitive.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
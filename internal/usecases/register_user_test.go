@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestRegisterUserUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	userRepo := NewMockUserRepository()
+	existing, err := domain.NewUser("user_1", "taken@example.com", "taken", time.Now())
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := userRepo.Create(ctx, existing); err != nil {
+		t.Fatalf("Failed to store user: %v", err)
+	}
+
+	useCase := NewRegisterUserUseCase(userRepo, NewBcryptPasswordHasher(bcrypt.MinCost))
+
+	tests := []struct {
+		name    string
+		input   RegisterUserInput
+		wantErr error
+	}{
+		{
+			name: "valid registration",
+			input: RegisterUserInput{
+				Email:    "  New@Example.com ",
+				Username: "newuser",
+				Password: "correct horse",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "short password",
+			input: RegisterUserInput{
+				Email:    "short@example.com",
+				Username: "short",
+				Password: "1234567",
+			},
+			wantErr: domain.ErrWeakPassword,
+		},
+		{
+			name: "email taken",
+			input: RegisterUserInput{
+				Email:    "Taken@example.com",
+				Username: "other",
+				Password: "correct horse",
+			},
+			wantErr: domain.ErrEmailTaken,
+		},
+		{
+			name: "invalid email",
+			input: RegisterUserInput{
+				Email:    "not-an-email",
+				Username: "bad",
+				Password: "correct horse",
+			},
+			wantErr: domain.ErrInvalidUser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := useCase.Execute(ctx, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil {
+				if output.User.Email != "new@example.com" {
+					t.Errorf("Execute() Email = %v, want new@example.com", output.User.Email)
+				}
+				if output.User.PasswordHash == "" || output.User.PasswordHash == tt.input.Password {
+					t.Error("Execute() did not store a password hash")
+				}
+				if _, err := userRepo.GetByEmail(ctx, "new@example.com"); err != nil {
+					t.Errorf("User was not stored: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"typeten/internal/domain"
+	"typeten/internal/repository"
+)
+
+// RevokeAPITokenUseCase handles revoking one of a user's API tokens.
+type RevokeAPITokenUseCase struct {
+	tokenRepo repository.APITokenRepository
+}
+
+// NewRevokeAPITokenUseCase creates a new RevokeAPITokenUseCase.
+func NewRevokeAPITokenUseCase(tokenRepo repository.APITokenRepository) *RevokeAPITokenUseCase {
+	return &RevokeAPITokenUseCase{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// RevokeAPITokenInput represents the input for revoking an API token.
+type RevokeAPITokenInput struct {
+	UserID  domain.UserID
+	TokenID domain.APITokenID
+}
+
+// RevokeAPITokenOutput represents the result of revoking an API token.
+type RevokeAPITokenOutput struct {
+	Token *domain.APIToken
+}
+
+// Execute revokes the token so it no longer authenticates requests.
+// Returns domain.ErrForbidden if the token belongs to another user.
+func (uc *RevokeAPITokenUseCase) Execute(ctx context.Context, input RevokeAPITokenInput) (*RevokeAPITokenOutput, error) {
+	token, err := uc.tokenRepo.GetByID(ctx, input.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api token: %w", err)
+	}
+	if token.UserID != input.UserID {
+		return nil, domain.ErrForbidden
+	}
+
+	token.Revoke(time.Now())
+	if err := uc.tokenRepo.Update(ctx, token); err != nil {
+		return nil, fmt.Errorf("failed to revoke api token: %w", err)
+	}
+
+	return &RevokeAPITokenOutput{Token: token}, nil
+}
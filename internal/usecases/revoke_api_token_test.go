@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+	"typeten/internal/domain"
+)
+
+func TestRevokeAPITokenUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	tokenRepo := NewMockAPITokenRepository()
+	token, _ := domain.NewAPIToken("token_1", "user_1", "ci", "hash_1", domain.ScopeRead, time.Now())
+	tokenRepo.Create(ctx, token)
+
+	useCase := NewRevokeAPITokenUseCase(tokenRepo)
+
+	tests := []struct {
+		name    string
+		input   RevokeAPITokenInput
+		wantErr bool
+	}{
+		{name: "other user's token", input: RevokeAPITokenInput{UserID: "user_2", TokenID: "token_1"}, wantErr: true},
+		{name: "unknown token", input: RevokeAPITokenInput{UserID: "user_1", TokenID: "token_x"}, wantErr: true},
+		{name: "own token", input: RevokeAPITokenInput{UserID: "user_1", TokenID: "token_1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := useCase.Execute(ctx, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !output.Token.IsRevoked() {
+				t.Error("Execute() token is not revoked")
+			}
+		})
+	}
+}
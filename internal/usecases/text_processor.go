@@ -1,41 +1,203 @@
 package usecases
 
-import "strings"
+import (
+	"strings"
+	"typeten/internal/domain"
+	"unicode"
+	"unicode/utf8"
 
-// TextProcessor splits raw text content into fragments of a specified size.
+	"golang.org/x/text/unicode/norm"
+)
+
+// typographyReplacer maps characters missing from a usual keyboard to what is typed
+// instead: quotes, dashes and the ellipsis to ASCII, special spaces to a space, and
+// invisible characters such as soft hyphens to nothing.
+var typographyReplacer = strings.NewReplacer(
+	"«", `"`, "»", `"`, "“", `"`, "”", `"`, "„", `"`, "‟", `"`, "″", `"`,
+	"‘", "'", "’", "'", "‚", "'", "‛", "'", "‹", "'", "›", "'", "′", "'",
+	"—", "-", "–", "-", "‒", "-", "―", "-", "‐", "-", "‑", "-", "−", "-",
+	"…", "...",
+	"\u00a0", " ", "\u2002", " ", "\u2003", " ", "\u2004", " ", "\u2005", " ", "\u2006", " ",
+	"\u2007", " ", "\u2008", " ", "\u2009", " ", "\u200a", " ", "\u202f", " ", "\u205f", " ", "\u3000", " ",
+	"\u00ad", "", "\u200b", "", "\u2060", "", "\ufeff", "",
+)
+
+var yoReplacer = strings.NewReplacer("ё", "е", "Ё", "Е")
+
+// lineNormalizers are the steps that rewrite one line at a time, in run order.
+var lineNormalizers = []struct {
+	step      domain.Normalization
+	normalize func(string) string
+}{
+	{domain.NormalizeUnicode, norm.NFC.String},
+	{domain.NormalizeTypography, typographyReplacer.Replace},
+	{domain.NormalizeYo, yoReplacer.Replace},
+	{domain.NormalizeWhitespace, func(line string) string { return strings.Join(strings.Fields(line), " ") }},
+}
+
+// TextProcessor normalizes raw text content, splits it into lines, wraps long lines
+// and splits the lines into fragments of a specified size.
 type TextProcessor struct {
-	FragmentSize int
+	FragmentSize  int
+	Normalization []domain.Normalization
+	Wrap          domain.TextWrap
 }
 
-// NewTextProcessor creates a new text processor with the given fragment size.
+// NewTextProcessor creates a new text processor with the given fragment size and
+// the default normalization.
 func NewTextProcessor(fragmentSize int) *TextProcessor {
 	if fragmentSize <= 0 {
 		fragmentSize = 10 // default
 	}
-	return &TextProcessor{FragmentSize: fragmentSize}
+	return &TextProcessor{FragmentSize: fragmentSize, Normalization: domain.DefaultNormalization()}
+}
+
+// WithNormalization returns a copy of p that applies the given normalization steps.
+func (p *TextProcessor) WithNormalization(steps []domain.Normalization) *TextProcessor {
+	cp := *p
+	cp.Normalization = steps
+	return &cp
+}
+
+// WithWrap returns a copy of p that wraps lines as given.
+func (p *TextProcessor) WithWrap(wrap domain.TextWrap) *TextProcessor {
+	cp := *p
+	cp.Wrap = wrap
+	return &cp
 }
 
 // ProcessText splits text into lines and then into fragments.
 // Returns the total line count, fragment count, and the fragments themselves.
 func (p *TextProcessor) ProcessText(text string) (totalLines int, fragments [][]string) {
-	trimmed := strings.TrimSpace(text)
-	if trimmed == "" {
-		return 0, nil
-	}
-	lines := strings.Split(trimmed, "\n")
-	totalLines = len(lines)
-	if totalLines == 0 {
-		return 0, nil
-	}
-	
-	// Split lines into fragments
-	for i := 0; i < totalLines; i += p.FragmentSize {
-		end := i + p.FragmentSize
-		if end > totalLines {
-			end = totalLines
+	lines, _ := p.Lines(text)
+	return len(lines), p.Fragment(lines)
+}
+
+// Lines normalizes text, splits it into lines and wraps them. Blank lines at the
+// start and end are always dropped. starts[i] is the index of the first line produced from line i
+// of text as split on \n; a line that produced none maps to the next line that did,
+// or to len(lines).
+func (p *TextProcessor) Lines(text string) (lines []string, starts []int) {
+	enabled := make(map[domain.Normalization]bool, len(p.Normalization))
+	for _, step := range p.Normalization {
+		enabled[step] = true
+	}
+
+	source := strings.Split(text, "\n")
+	starts = make([]int, len(source))
+	for i, src := range source {
+		starts[i] = len(lines)
+		parts := []string{src}
+		if enabled[domain.NormalizeLineEndings] {
+			parts = strings.Split(strings.TrimSuffix(src, "\r"), "\r")
 		}
+		for _, line := range parts {
+			for _, n := range lineNormalizers {
+				if enabled[n.step] {
+					line = n.normalize(line)
+				}
+			}
+			if enabled[domain.NormalizeBlankLines] && strings.TrimSpace(line) == "" {
+				continue
+			}
+			lines = append(lines, p.wrapLine(line)...)
+		}
+	}
+
+	first, end := 0, len(lines)
+	for first < end && strings.TrimSpace(lines[first]) == "" {
+		first++
+	}
+	for end > first && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	lines = lines[first:end]
+	for i, start := range starts {
+		starts[i] = min(max(start-first, 0), len(lines))
+	}
+	return lines, starts
+}
+
+// wrapLine breaks line at sentence ends if p.Wrap.Sentences is set, then breaks
+// every part longer than p.Wrap.Width runes between words. Parts that fit are kept
+// as they are; a word longer than the width gets a line of its own.
+func (p *TextProcessor) wrapLine(line string) []string {
+	parts := []string{line}
+	if p.Wrap.Sentences {
+		parts = splitSentences(line)
+	}
+	if p.Wrap.Width <= 0 {
+		return parts
+	}
+	var wrapped []string
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) <= p.Wrap.Width {
+			wrapped = append(wrapped, part)
+			continue
+		}
+		var (
+			cur      strings.Builder
+			curWidth int
+		)
+		for _, word := range strings.Fields(part) {
+			n := utf8.RuneCountInString(word)
+			if curWidth > 0 && curWidth+1+n > p.Wrap.Width {
+				wrapped = append(wrapped, cur.String())
+				cur.Reset()
+				curWidth = 0
+			}
+			if curWidth > 0 {
+				cur.WriteByte(' ')
+				curWidth++
+			}
+			cur.WriteString(word)
+			curWidth += n
+		}
+		if curWidth > 0 {
+			wrapped = append(wrapped, cur.String())
+		}
+	}
+	return wrapped
+}
+
+// splitSentences splits line after every run of sentence-final punctuation (with
+// any closing quotes or brackets) that is followed by a space and a word that does
+// not start with a lowercase letter or a dash, so "т. е.", "e.g. this" and
+// «Да?» — спросил он. stay whole.
+func splitSentences(line string) []string {
+	var sentences []string
+	runes := []rune(line)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		if !strings.ContainsRune(".!?…", runes[i]) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && strings.ContainsRune(".!?…\"'»”’)]", runes[end]) {
+			end++
+		}
+		next := end
+		for next < len(runes) && unicode.IsSpace(runes[next]) {
+			next++
+		}
+		i = end - 1
+		if next == end || next == len(runes) || unicode.IsLower(runes[next]) || strings.ContainsRune("-–—", runes[next]) {
+			continue
+		}
+		sentences = append(sentences, strings.TrimSpace(string(runes[start:end])))
+		start = next
+	}
+	if rest := strings.TrimSpace(string(runes[start:])); rest != "" || len(sentences) == 0 {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// Fragment splits lines into fragments of FragmentSize lines; the last one may be shorter.
+func (p *TextProcessor) Fragment(lines []string) (fragments [][]string) {
+	for i := 0; i < len(lines); i += p.FragmentSize {
+		end := min(i+p.FragmentSize, len(lines))
 		fragments = append(fragments, lines[i:end])
 	}
-	
-	return totalLines, fragments
+	return fragments
 }
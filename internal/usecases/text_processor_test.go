@@ -1,6 +1,10 @@
 package usecases
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+	"typeten/internal/domain"
+)
 
 func TestTextProcessor_ProcessText(t *testing.T) {
 	tests := []struct {
@@ -89,3 +93,107 @@ func TestTextProcessor_ProcessText(t *testing.T) {
 		})
 	}
 }
+
+func TestTextProcessor_Lines(t *testing.T) {
+	all := domain.AllNormalizations()
+	tests := []struct {
+		name       string
+		steps      []domain.Normalization
+		text       string
+		wantLines  []string
+		wantStarts []int
+	}{
+		{
+			name:       "default steps",
+			steps:      domain.DefaultNormalization(),
+			text:       "\r\n«Ёлки» — это ели…\r\n\r\n  two\t\tspaces  \rsplit\r\n",
+			wantLines:  []string{`"Ёлки" - это ели...`, "two spaces", "split"},
+			wantStarts: []int{0, 0, 1, 1, 3},
+		},
+		{
+			name:      "all steps",
+			steps:     all,
+			text:      "ещё\u00a0раз „quo\u00adted“ ‘single’ a–b",
+			wantLines: []string{`еще раз "quoted" 'single' a-b`},
+		},
+		{
+			name:      "NFC composes decomposed letters",
+			steps:     []domain.Normalization{domain.NormalizeUnicode},
+			text:      "ёж й",
+			wantLines: []string{"ёж й"},
+		},
+		{
+			name:      "no steps keeps blank lines inside",
+			steps:     []domain.Normalization{},
+			text:      "\none\r\n\n  two  \n\n",
+			wantLines: []string{"one\r", "", "  two  "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTextProcessor(10).WithNormalization(tt.steps)
+			lines, starts := p.Lines(tt.text)
+			if !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("Lines() lines = %q, want %q", lines, tt.wantLines)
+			}
+			if tt.wantStarts != nil && !reflect.DeepEqual(starts, tt.wantStarts) {
+				t.Errorf("Lines() starts = %v, want %v", starts, tt.wantStarts)
+			}
+		})
+	}
+}
+
+func TestTextProcessor_Wrap(t *testing.T) {
+	tests := []struct {
+		name       string
+		wrap       domain.TextWrap
+		text       string
+		wantLines  []string
+		wantStarts []int
+	}{
+		{
+			name:      "off",
+			text:      "One. Two three four five six seven.",
+			wantLines: []string{"One. Two three four five six seven."},
+		},
+		{
+			name:      "width counts runes",
+			wrap:      domain.TextWrap{Width: 20},
+			text:      "Съешь же ещё этих мягких французских булок\nshort line",
+			wantLines: []string{"Съешь же ещё этих", "мягких французских", "булок", "short line"},
+		},
+		{
+			name:      "long word gets its own line",
+			wrap:      domain.TextWrap{Width: 20},
+			text:      "a pneumonoultramicroscopicsilicovolcanoconiosis b",
+			wantLines: []string{"a", "pneumonoultramicroscopicsilicovolcanoconiosis", "b"},
+		},
+		{
+			name:      "sentences",
+			wrap:      domain.TextWrap{Sentences: true},
+			text:      "Hello, world! «Да?» — спросил он. Т. е. нет… and e.g. this. 3.14 is pi",
+			wantLines: []string{"Hello, world!", `"Да?" - спросил он.`, "Т. е. нет... and e.g. this.", "3.14 is pi"},
+		},
+		{
+			name:       "sentences then width",
+			wrap:       domain.TextWrap{Width: 20, Sentences: true},
+			text:       "Short one. This sentence is a bit longer than twenty.\nNext paragraph.",
+			wantLines:  []string{"Short one.", "This sentence is a", "bit longer than", "twenty.", "Next paragraph."},
+			wantStarts: []int{0, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTextProcessor(10).WithWrap(tt.wrap)
+			lines, starts := p.Lines(tt.text)
+			if !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("Lines() lines = %q, want %q", lines, tt.wantLines)
+			}
+			if tt.wantStarts != nil && !reflect.DeepEqual(starts, tt.wantStarts) {
+				t.Errorf("Lines() starts = %v, want %v", starts, tt.wantStarts)
+			}
+		})
+	}
+}